@@ -113,4 +113,201 @@ func GetParallacticAngle(datetime time.Time, observer common.GeographicCoordinat
 	return math.Mod(q, 360)
 }
 
-/*****************************************************************************************************************/
\ No newline at end of file
+/*****************************************************************************************************************/
+
+/*
+the angular separation between two celestial bodies, in degrees
+
+The angular separation is the angle between the directions to two celestial bodies, as seen by the observer,
+measured along the great circle passing through them. It is computed using the Vincenty formula, which is
+accurate for both very small and very large separations.
+*/
+func GetAngularSeparation(a common.EquatorialCoordinate, b common.EquatorialCoordinate) float64 {
+	α1 := common.Radians(a.RightAscension)
+
+	δ1 := common.Radians(a.Declination)
+
+	α2 := common.Radians(b.RightAscension)
+
+	δ2 := common.Radians(b.Declination)
+
+	Δα := α2 - α1
+
+	x := math.Cos(δ1)*math.Sin(δ2) - math.Sin(δ1)*math.Cos(δ2)*math.Cos(Δα)
+
+	y := math.Cos(δ2) * math.Sin(Δα)
+
+	z := math.Sin(δ1)*math.Sin(δ2) + math.Cos(δ1)*math.Cos(δ2)*math.Cos(Δα)
+
+	return common.Degrees(math.Atan2(math.Hypot(x, y), z))
+}
+
+/*****************************************************************************************************************/
+
+/*
+the nutation in longitude (Δψ) and in obliquity (Δε) for a given datetime, in degrees
+
+Nutation is the short-period oscillation of the Earth's axis of rotation about its mean position, caused
+chiefly by the torque of the Moon on the Earth's equatorial bulge as the Moon's orbit precesses over 18.6
+years. The nutation in longitude shifts the equinox along the ecliptic by up to around 17 arcseconds, and the
+nutation in obliquity changes the tilt of the Earth's axis by up to around 9 arcseconds.
+
+The four principal terms are used, which are accurate to 0.5 arcseconds in Δψ and 0.1 arcseconds in Δε; see
+Meeus, "Astronomical Algorithms", Chapter 22.
+*/
+func GetNutation(datetime time.Time) (Δψ float64, Δε float64) {
+	// the number of centuries since J2000.0:
+	T := (epoch.GetJulianDate(datetime) - 2451545.0) / 36525
+
+	// the mean longitudes of the Sun and the Moon:
+	L := common.Radians(280.4665 + 36000.7698*T)
+
+	Lʹ := common.Radians(218.3165 + 481267.8813*T)
+
+	// the longitude of the ascending node of the Moon's mean orbit on the ecliptic:
+	Ω := common.Radians(125.04452 - 1934.136261*T + 0.0020708*math.Pow(T, 2) + math.Pow(T, 3)/450000)
+
+	Δψ = (-17.20*math.Sin(Ω) - 1.32*math.Sin(2*L) - 0.23*math.Sin(2*Lʹ) + 0.21*math.Sin(2*Ω)) / 3600
+
+	Δε = (9.20*math.Cos(Ω) + 0.57*math.Cos(2*L) + 0.10*math.Cos(2*Lʹ) - 0.09*math.Cos(2*Ω)) / 3600
+
+	return Δψ, Δε
+}
+
+/*****************************************************************************************************************/
+
+// the rate of rotation of the Earth relative to the stars, in degrees per second of time:
+const SIDEREAL_ROTATION_RATE float64 = 360 / 86164.0905
+
+/*****************************************************************************************************************/
+
+/*
+the field rotation of a target for an alt-az mounted telescope at an instant of time
+
+The parallactic angle is in degrees, and is continuous (unwrapped) across a series of instants, so that it may
+fall outside of the range 0 to 360 degrees, the rate of field rotation is in degrees per second, and the altitude
+of the target is in degrees.
+*/
+type FieldRotation struct {
+	Datetime         time.Time
+	ParallacticAngle float64
+	Rate             float64
+	Altitude         float64
+}
+
+/*****************************************************************************************************************/
+
+/*
+the rate of field rotation of a target for an alt-az mounted telescope, in degrees per second
+
+The field of an alt-az mounted telescope rotates at the rate of change of the parallactic angle of the target,
+of magnitude Ω cos φ |cos A| / cos h, where Ω is the sidereal rotation rate of the Earth, φ is the latitude of
+the observer, and A and h are the azimuth and altitude of the target. The rate is positive when the parallactic
+angle increases, and is fastest for a target passing close to the zenith, where it becomes infinite.
+*/
+func GetFieldRotationRate(datetime time.Time, observer common.GeographicCoordinate, target common.EquatorialCoordinate) float64 {
+	φ := common.Radians(observer.Latitude)
+
+	δ := common.Radians(target.Declination)
+
+	ha := common.Radians(GetHourAngle(datetime, observer, target))
+
+	// the sine of the altitude of the target:
+	sinh := math.Sin(φ)*math.Sin(δ) + math.Cos(φ)*math.Cos(δ)*math.Cos(ha)
+
+	// cos φ cos A cos h = sin δ - sin h sin φ, so that cos φ cos A / cos h = (sin δ - sin h sin φ) / cos² h:
+	return -SIDEREAL_ROTATION_RATE * (math.Sin(δ) - sinh*math.Sin(φ)) / (1 - math.Pow(sinh, 2))
+}
+
+/*****************************************************************************************************************/
+
+/*
+the maximum length of an exposure of a target for an alt-az mounted telescope without a field derotator, for a
+sensor of a given width and height, in pixels, and a given tolerance, in pixels
+
+The field rotates about the centre of the sensor, and so the corners of the sensor, at a distance of half the
+diagonal from the centre, trail the furthest. The maximum exposure is the time for the field to rotate by the
+angle which moves the corners by the tolerance, at the current rate of field rotation.
+*/
+func GetMaximumUnrotatedExposure(
+	datetime time.Time,
+	observer common.GeographicCoordinate,
+	target common.EquatorialCoordinate,
+	width float64,
+	height float64,
+	tolerance float64,
+) time.Duration {
+	// the distance of the corners of the sensor from its centre, in pixels:
+	r := math.Hypot(width, height) / 2
+
+	// the angle of rotation which moves the corners by the tolerance, in degrees:
+	θ := common.Degrees(tolerance / r)
+
+	ω := math.Abs(GetFieldRotationRate(datetime, observer, target))
+
+	// the exposure is unlimited for a field which is not rotating, e.g., a target on the prime vertical:
+	if θ/ω >= float64(math.MaxInt64)/float64(time.Second) {
+		return time.Duration(math.MaxInt64)
+	}
+
+	return time.Duration(θ / ω * float64(time.Second))
+}
+
+/*****************************************************************************************************************/
+
+/*
+the field rotation of a target for an alt-az mounted telescope at regular intervals between two datetimes, for
+the control of a field derotator across the pass of the target
+
+Only the instants at which the target is above the horizon are included, and the parallactic angle is unwrapped
+so that it changes continuously across the series, e.g., through 360 degrees in a sidereal day for a circumpolar
+target passing north of the zenith, as seen from the northern hemisphere, whereas for a target passing south of
+the zenith it swings back and forth through less than 180 degrees. The series is empty for a step which is not
+positive.
+*/
+func GetFieldRotation(
+	start time.Time,
+	end time.Time,
+	step time.Duration,
+	observer common.GeographicCoordinate,
+	target common.EquatorialCoordinate,
+) []FieldRotation {
+	series := []FieldRotation{}
+
+	// a non-positive step would never reach the end of the series:
+	if step <= 0 {
+		return series
+	}
+
+	φ := common.Radians(observer.Latitude)
+
+	δ := common.Radians(target.Declination)
+
+	for datetime := start; !datetime.After(end); datetime = datetime.Add(step) {
+		ha := common.Radians(GetHourAngle(datetime, observer, target))
+
+		h := common.Degrees(math.Asin(math.Sin(φ)*math.Sin(δ) + math.Cos(φ)*math.Cos(δ)*math.Cos(ha)))
+
+		if h < 0 {
+			continue
+		}
+
+		q := GetParallacticAngle(datetime, observer, target)
+
+		// unwrap the parallactic angle relative to the previous instant in the series:
+		if n := len(series); n > 0 {
+			q += 360 * math.Round((series[n-1].ParallacticAngle-q)/360)
+		}
+
+		series = append(series, FieldRotation{
+			Datetime:         datetime,
+			ParallacticAngle: q,
+			Rate:             GetFieldRotationRate(datetime, observer, target),
+			Altitude:         h,
+		})
+	}
+
+	return series
+}
+
+/*****************************************************************************************************************/
@@ -9,6 +9,7 @@
 package astrometry
 
 import (
+	"math"
 	"testing"
 	"time"
 
@@ -87,4 +88,159 @@ func TestGetParallacticAngle(t *testing.T) {
 	}
 }
 
-/*****************************************************************************************************************/
\ No newline at end of file
+/*****************************************************************************************************************/
+
+func TestGetAngularSeparation(t *testing.T) {
+	rigel := common.EquatorialCoordinate{
+		RightAscension: 78.6344671,
+		Declination:    -8.2016383,
+	}
+
+	// the angular separation between Betelgeuse and Rigel is approximately 18.6 degrees:
+	θ := GetAngularSeparation(betelgeuse, rigel)
+
+	if math.Abs(θ-18.6060) > 0.001 {
+		t.Errorf("got %f, wanted %f", θ, 18.6060)
+	}
+
+	if GetAngularSeparation(betelgeuse, betelgeuse) != 0 {
+		t.Errorf("got %f, wanted a zero angular separation", GetAngularSeparation(betelgeuse, betelgeuse))
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetNutation(t *testing.T) {
+	// 1987 April 10, 0h TD, see Meeus, "Astronomical Algorithms", Example 22.a:
+	Δψ, Δε := GetNutation(time.Date(1987, 4, 10, 0, 0, 0, 0, time.UTC))
+
+	if math.Abs(Δψ*3600-(-3.788)) > 0.5 {
+		t.Errorf("got %f, wanted %f", Δψ*3600, -3.788)
+	}
+
+	if math.Abs(Δε*3600-9.443) > 0.1 {
+		t.Errorf("got %f, wanted %f", Δε*3600, 9.443)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetFieldRotationRate(t *testing.T) {
+	ω := GetFieldRotationRate(datetime, observer, betelgeuse)
+
+	// the rate of change of the parallactic angle, by a central difference over one second either side:
+	a := GetParallacticAngle(datetime.Add(-time.Second), observer, betelgeuse)
+
+	b := GetParallacticAngle(datetime.Add(time.Second), observer, betelgeuse)
+
+	want := math.Remainder(b-a, 360) / 2
+
+	if math.Abs(ω-want) > 1e-5 {
+		t.Errorf("got %f, wanted %f", ω, want)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetMaximumUnrotatedExposure(t *testing.T) {
+	exposure := GetMaximumUnrotatedExposure(datetime, observer, betelgeuse, 6000, 4000, 1)
+
+	ω := math.Abs(GetFieldRotationRate(datetime, observer, betelgeuse))
+
+	// the corners of a 6000 x 4000 pixel sensor are at around 3605.55 pixels from its centre:
+	want := common.Degrees(1/math.Hypot(3000, 2000)) / ω
+
+	if math.Abs(exposure.Seconds()-want) > 0.001 {
+		t.Errorf("got %f, wanted %f", exposure.Seconds(), want)
+	}
+
+	// a larger tolerance allows a proportionately longer exposure:
+	if e := GetMaximumUnrotatedExposure(datetime, observer, betelgeuse, 6000, 4000, 2); math.Abs(e.Seconds()-2*want) > 0.001 {
+		t.Errorf("got %f, wanted %f", e.Seconds(), 2*want)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetFieldRotation(t *testing.T) {
+	start := time.Date(2021, 5, 14, 0, 0, 0, 0, time.UTC)
+
+	end := start.Add(24 * time.Hour)
+
+	series := GetFieldRotation(start, end, 5*time.Minute, observer, betelgeuse)
+
+	if len(series) == 0 {
+		t.Fatalf("got no field rotations, wanted a series across the pass of the target")
+	}
+
+	for i, rotation := range series {
+		if rotation.Altitude < 0 {
+			t.Errorf("got an altitude of %f, wanted the target to be above the horizon", rotation.Altitude)
+		}
+
+		if i == 0 || rotation.Datetime.Sub(series[i-1].Datetime) != 5*time.Minute {
+			continue
+		}
+
+		// the parallactic angle is continuous, changing by no more than the rate across the interval:
+		Δq := rotation.ParallacticAngle - series[i-1].ParallacticAngle
+
+		if math.Abs(Δq) > 10 {
+			t.Errorf("got a change in parallactic angle of %f, wanted a continuous series", Δq)
+		}
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetFieldRotationWrapAround(t *testing.T) {
+	start := time.Date(2021, 5, 14, 0, 0, 0, 0, time.UTC)
+
+	end := start.Add(24 * time.Hour)
+
+	// an observer at a latitude of 51.5 degrees, for whom targets north of 38.5 degrees are circumpolar:
+	greenwich := common.GeographicCoordinate{Latitude: 51.5, Longitude: 0, Elevation: 0}
+
+	span := func(declination float64) float64 {
+		series := GetFieldRotation(start, end, 5*time.Minute, greenwich, common.EquatorialCoordinate{
+			RightAscension: 88.7929583,
+			Declination:    declination,
+		})
+
+		lo, hi := math.Inf(1), math.Inf(-1)
+
+		for _, rotation := range series {
+			lo, hi = math.Min(lo, rotation.ParallacticAngle), math.Max(hi, rotation.ParallacticAngle)
+		}
+
+		return hi - lo
+	}
+
+	// a circumpolar target north of the zenith winds through a full turn in a day:
+	if Δq := span(70); Δq < 355 {
+		t.Errorf("got a span of %f degrees, wanted a full turn for a target north of the zenith", Δq)
+	}
+
+	// whereas targets south of the zenith, circumpolar or not, swing through less than half a turn:
+	for _, declination := range []float64{30, 45} {
+		if Δq := span(declination); Δq > 180 {
+			t.Errorf("got a span of %f degrees, wanted less than half a turn at a declination of %f", Δq, declination)
+		}
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetFieldRotationNonPositiveStep(t *testing.T) {
+	start := time.Date(2021, 5, 14, 0, 0, 0, 0, time.UTC)
+
+	end := start.Add(24 * time.Hour)
+
+	for _, step := range []time.Duration{0, -time.Minute} {
+		if series := GetFieldRotation(start, end, step, observer, betelgeuse); len(series) != 0 {
+			t.Errorf("got %d field rotations for a step of %v, wanted none", len(series), step)
+		}
+	}
+}
+
+/*****************************************************************************************************************/
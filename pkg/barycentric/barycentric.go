@@ -0,0 +1,269 @@
+/*****************************************************************************************************************/
+
+//	@author		Michael Roberts <michael@observerly.com>
+//	@package	@observerly/sidera
+//	@license	Copyright © 2021-2024 observerly
+
+/*****************************************************************************************************************/
+
+package barycentric
+
+/*****************************************************************************************************************/
+
+import (
+	"math"
+	"time"
+
+	"github.com/observerly/sidera/pkg/common"
+	"github.com/observerly/sidera/pkg/coordinates"
+	"github.com/observerly/sidera/pkg/epoch"
+	"github.com/observerly/sidera/pkg/planets"
+	sun "github.com/observerly/sidera/pkg/solar"
+)
+
+/*****************************************************************************************************************/
+
+// the light travel time for one astronomical unit (AU), in seconds:
+const LIGHT_TIME_PER_AU float64 = 499.004783836
+
+/*****************************************************************************************************************/
+
+// the obliquity of the ecliptic at the J2000.0 epoch, in degrees:
+const J2000_OBLIQUITY float64 = 23.4392911
+
+/*****************************************************************************************************************/
+
+/*
+the giant planets, together with the ratio of the mass of each planet to the mass of the Sun, used to
+locate the Sun relative to the barycentre of the solar system.
+*/
+var giants = []struct {
+	planet planets.Planet
+	mass   float64
+}{
+	{planets.Jupiter, 1 / 1047.348644},
+	{planets.Saturn, 1 / 3497.9018},
+	{planets.Uranus, 1 / 22902.98},
+	{planets.Neptune, 1 / 19412.26},
+}
+
+/*****************************************************************************************************************/
+
+/*
+converts a position referred to the mean ecliptic and equinox of J2000.0 to the mean equator and
+equinox of J2000.0, i.e., a rotation about the x-axis by the obliquity of the ecliptic at J2000.0.
+*/
+func convertEclipticToEquatorialCartesianCoordinate(
+	ecliptic common.CartesianCoordinate,
+) common.CartesianCoordinate {
+	ε := common.Radians(J2000_OBLIQUITY)
+
+	return common.CartesianCoordinate{
+		X: ecliptic.X,
+		Y: ecliptic.Y*math.Cos(ε) - ecliptic.Z*math.Sin(ε),
+		Z: ecliptic.Y*math.Sin(ε) + ecliptic.Z*math.Cos(ε),
+	}
+}
+
+/*****************************************************************************************************************/
+
+/*
+the heliocentric position of the Earth for a given datetime, in AU, referred to the mean equator and
+equinox of J2000.0.
+
+The position of the Earth is the reverse of the geocentric position of the Sun, precessed in longitude
+from the mean equinox of date back to the mean equinox of J2000.0.
+*/
+func GetHeliocentricPosition(datetime time.Time) common.CartesianCoordinate {
+	// the number of centuries since J2000.0:
+	T := (epoch.GetJulianDate(datetime) - epoch.J2000) / 36525
+
+	// the general precession in longitude since J2000.0, in degrees:
+	p := (5029.0966*T + 1.11113*math.Pow(T, 2)) / 3600
+
+	// the heliocentric longitude of the Earth is opposite to the geocentric longitude of the Sun:
+	λ := common.Radians(sun.GetTrueEclipticLongitude(datetime) + 180 - p)
+
+	// the radius vector of the Earth, in AU:
+	R := sun.GetDistance(datetime)
+
+	return convertEclipticToEquatorialCartesianCoordinate(common.CartesianCoordinate{
+		X: R * math.Cos(λ),
+		Y: R * math.Sin(λ),
+		Z: 0,
+	})
+}
+
+/*****************************************************************************************************************/
+
+/*
+the position of the Sun relative to the barycentre of the solar system for a given datetime, in AU,
+referred to the mean equator and equinox of J2000.0.
+
+The Sun's reflex motion about the barycentre is dominated by Jupiter and Saturn, and displaces the Sun by
+up to about two solar radii (0.01 AU), i.e., a light travel time of up to around five seconds.
+*/
+func GetSolarBarycentricPosition(datetime time.Time) common.CartesianCoordinate {
+	position := common.CartesianCoordinate{}
+
+	// the total mass of the system, relative to the mass of the Sun:
+	M := 1.0
+
+	for _, giant := range giants {
+		// the heliocentric position of the planet, referred to the mean ecliptic and equinox of J2000.0:
+		p := planets.GetHeliocentricCartesianCoordinate(datetime, giant.planet)
+
+		position.X -= giant.mass * p.X
+		position.Y -= giant.mass * p.Y
+		position.Z -= giant.mass * p.Z
+
+		M += giant.mass
+	}
+
+	return convertEclipticToEquatorialCartesianCoordinate(common.CartesianCoordinate{
+		X: position.X / M,
+		Y: position.Y / M,
+		Z: position.Z / M,
+	})
+}
+
+/*****************************************************************************************************************/
+
+/*
+the geocentric position of the observer for a given datetime, in AU, referred to the equator of date.
+
+The observer's position is computed on the WGS84 reference ellipsoid from their geodetic latitude,
+longitude and elevation (in metres), rotated by the local sidereal time. Its contribution to the light
+travel time is never more than about 21 milliseconds.
+*/
+func GetObserverGeocentricPosition(
+	datetime time.Time,
+	observer common.GeographicCoordinate,
+) common.CartesianCoordinate {
+	// the geocentric position of the observer, in kilometres:
+	position, _ := coordinates.GetObserverPosition(datetime, observer)
+
+	return common.CartesianCoordinate{
+		X: position.X / common.AU,
+		Y: position.Y / common.AU,
+		Z: position.Z / common.AU,
+	}
+}
+
+/*****************************************************************************************************************/
+
+/*
+the light travel time correction to the heliocentre for a given datetime, observer and target, in seconds.
+
+The heliocentric light travel time correction is the difference between the time at which light from the
+target would arrive at the centre of the Sun, and the time at which it arrives at the observer. It is
+positive when the observer is closer to the target than the Sun, and is at most about 8.5 minutes.
+*/
+func GetHeliocentricLightTravelTime(
+	datetime time.Time,
+	observer common.GeographicCoordinate,
+	target common.EquatorialCoordinate,
+) float64 {
+	// the heliocentric position of the Earth:
+	earth := GetHeliocentricPosition(datetime)
+
+	// the geocentric position of the observer:
+	o := GetObserverGeocentricPosition(datetime, observer)
+
+	return getLightTravelTime(common.CartesianCoordinate{
+		X: earth.X + o.X,
+		Y: earth.Y + o.Y,
+		Z: earth.Z + o.Z,
+	}, target)
+}
+
+/*****************************************************************************************************************/
+
+/*
+the light travel time correction to the solar system barycentre for a given datetime, observer and target,
+in seconds.
+
+The barycentric light travel time correction (or Rømer delay) is the difference between the time at which
+light from the target would arrive at the barycentre of the solar system, and the time at which it arrives
+at the observer. It differs from the heliocentric correction by up to about five seconds.
+*/
+func GetBarycentricLightTravelTime(
+	datetime time.Time,
+	observer common.GeographicCoordinate,
+	target common.EquatorialCoordinate,
+) float64 {
+	// the heliocentric position of the Earth:
+	earth := GetHeliocentricPosition(datetime)
+
+	// the barycentric position of the Sun:
+	s := GetSolarBarycentricPosition(datetime)
+
+	// the geocentric position of the observer:
+	o := GetObserverGeocentricPosition(datetime, observer)
+
+	return getLightTravelTime(common.CartesianCoordinate{
+		X: earth.X + s.X + o.X,
+		Y: earth.Y + s.Y + o.Y,
+		Z: earth.Z + s.Z + o.Z,
+	}, target)
+}
+
+/*****************************************************************************************************************/
+
+/*
+the Heliocentric Julian Date (HJD) for a given datetime, observer and target.
+
+The Heliocentric Julian Date is the Julian Date (in UTC) corrected for the difference in the light travel
+time from the target to the observer and to the centre of the Sun. Following convention, HJD is expressed
+in UTC, and is accurate to a few seconds; for timing work BJD_TDB should be preferred.
+*/
+func GetHeliocentricJulianDate(
+	datetime time.Time,
+	observer common.GeographicCoordinate,
+	target common.EquatorialCoordinate,
+) float64 {
+	// get the Julian Date for the given datetime:
+	JD := epoch.GetJulianDate(datetime)
+
+	// return the Heliocentric Julian Date:
+	return JD + GetHeliocentricLightTravelTime(datetime, observer, target)/86400.0
+}
+
+/*****************************************************************************************************************/
+
+/*
+the Barycentric Julian Date (BJD_TDB) for a given datetime, observer and target.
+
+The Barycentric Julian Date is the Julian Date in Barycentric Dynamical Time (TDB), corrected for the
+difference in the light travel time from the target to the observer and to the barycentre of the solar
+system. BJD_TDB is the recommended time standard for the timing of variable stars and exoplanet transits.
+*/
+func GetBarycentricJulianDate(
+	datetime time.Time,
+	observer common.GeographicCoordinate,
+	target common.EquatorialCoordinate,
+) float64 {
+	// get the Julian Date in Barycentric Dynamical Time for the given datetime:
+	JD := epoch.GetBarycentricDynamicalTimeJulianDate(datetime)
+
+	// return the Barycentric Julian Date:
+	return JD + GetBarycentricLightTravelTime(datetime, observer, target)/86400.0
+}
+
+/*****************************************************************************************************************/
+
+/*
+the light travel time, in seconds, of the projection of a position (in AU) onto the direction of a target.
+*/
+func getLightTravelTime(position common.CartesianCoordinate, target common.EquatorialCoordinate) float64 {
+	α := common.Radians(target.RightAscension)
+
+	δ := common.Radians(target.Declination)
+
+	// the projection of the position onto the unit vector in the direction of the target:
+	d := position.X*math.Cos(δ)*math.Cos(α) + position.Y*math.Cos(δ)*math.Sin(α) + position.Z*math.Sin(δ)
+
+	return d * LIGHT_TIME_PER_AU
+}
+
+/*****************************************************************************************************************/
@@ -0,0 +1,186 @@
+/*****************************************************************************************************************/
+
+//	@author		Michael Roberts <michael@observerly.com>
+//	@package	@observerly/sidera
+//	@license	Copyright © 2021-2024 observerly
+
+/*****************************************************************************************************************/
+
+package barycentric
+
+/*****************************************************************************************************************/
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/observerly/sidera/pkg/common"
+)
+
+/*****************************************************************************************************************/
+
+// We define a datetime close to the March equinox of 2021, when the Sun is close to the vernal equinox:
+var datetime time.Time = time.Date(2021, 3, 20, 9, 37, 0, 0, time.UTC)
+
+/*****************************************************************************************************************/
+
+var observer common.GeographicCoordinate = common.GeographicCoordinate{
+	Latitude:  19.8207,
+	Longitude: -155.468094,
+	Elevation: 4205,
+}
+
+/*****************************************************************************************************************/
+
+func TestGetHeliocentricPosition(t *testing.T) {
+	earth := GetHeliocentricPosition(datetime)
+
+	// At the March equinox, the Earth lies in the direction of the autumnal equinox as seen from the Sun:
+	if earth.X > -0.99 || math.Abs(earth.Y) > 0.01 || math.Abs(earth.Z) > 0.01 {
+		t.Errorf("got %v, wanted a position close to (-0.996, 0, 0)", earth)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetHeliocentricPositionMeeus(t *testing.T) {
+	// 1992 October 13, 0h TD, see Meeus, "Astronomical Algorithms", Example 26.a, where the geocentric rectangular
+	// coordinates of the Sun, referred to the mean equator and equinox of J2000.0, are the reverse of these:
+	earth := GetHeliocentricPosition(time.Date(1992, 10, 13, 0, 0, 0, 0, time.UTC).Add(-59 * time.Second))
+
+	want := common.CartesianCoordinate{X: 0.93739590, Y: 0.31316793, Z: 0.13577924}
+
+	// to within 0.0001 AU, i.e., a light travel time of 0.05 seconds:
+	if math.Abs(earth.X-want.X) > 0.0001 || math.Abs(earth.Y-want.Y) > 0.0001 || math.Abs(earth.Z-want.Z) > 0.0001 {
+		t.Errorf("got %+v, wanted %+v", earth, want)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetHeliocentricLightTravelTimeMeeus(t *testing.T) {
+	d := time.Date(1992, 10, 13, 0, 0, 0, 0, time.UTC).Add(-59 * time.Second)
+
+	// a target in the direction of the vernal equinox, for which the correction is the X coordinate of the Earth of
+	// Meeus, Example 26.a, multiplied by the light travel time for one astronomical unit:
+	target := common.EquatorialCoordinate{RightAscension: 0, Declination: 0}
+
+	got := GetHeliocentricLightTravelTime(d, observer, target)
+
+	want := 0.93739590 * LIGHT_TIME_PER_AU
+
+	// to within 0.05 seconds for the position of the Earth, and 0.03 seconds for the position of the observer:
+	if math.Abs(got-want) > 0.08 {
+		t.Errorf("got %f seconds, wanted %f seconds", got, want)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetSolarBarycentricPosition(t *testing.T) {
+	s := GetSolarBarycentricPosition(datetime)
+
+	r := math.Sqrt(math.Pow(s.X, 2) + math.Pow(s.Y, 2) + math.Pow(s.Z, 2))
+
+	// The Sun is never more than about 0.01 AU from the barycentre of the solar system:
+	if r <= 0 || r > 0.011 {
+		t.Errorf("got %f AU, wanted a distance less than 0.011 AU", r)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetObserverGeocentricPosition(t *testing.T) {
+	o := GetObserverGeocentricPosition(datetime, observer)
+
+	r := math.Sqrt(math.Pow(o.X, 2)+math.Pow(o.Y, 2)+math.Pow(o.Z, 2)) * common.AU
+
+	// The observer is on the summit of Mauna Kea, some 6,380 kilometres from the centre of the Earth:
+	if math.Abs(r-6380.2) > 1 {
+		t.Errorf("got %f km, wanted %f km", r, 6380.2)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetHeliocentricLightTravelTimeTowardsTheSun(t *testing.T) {
+	got := GetHeliocentricLightTravelTime(datetime, observer, common.EquatorialCoordinate{
+		RightAscension: 0,
+		Declination:    0,
+	})
+
+	// Light from a target beyond the Sun reaches the Sun some 497 seconds before it reaches the Earth:
+	if math.Abs(got+497) > 1 {
+		t.Errorf("got %f, wanted %f", got, -497.0)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetHeliocentricLightTravelTimeAwayFromTheSun(t *testing.T) {
+	got := GetHeliocentricLightTravelTime(datetime, observer, common.EquatorialCoordinate{
+		RightAscension: 180,
+		Declination:    0,
+	})
+
+	// Light from a target opposite the Sun reaches the Earth some 497 seconds before it reaches the Sun:
+	if math.Abs(got-497) > 1 {
+		t.Errorf("got %f, wanted %f", got, 497.0)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetHeliocentricLightTravelTimeAtTheEclipticPole(t *testing.T) {
+	got := GetHeliocentricLightTravelTime(datetime, observer, common.EquatorialCoordinate{
+		RightAscension: 270,
+		Declination:    66.560708,
+	})
+
+	// The Earth's orbit lies (almost) perpendicular to the direction of the ecliptic pole:
+	if math.Abs(got) > 0.1 {
+		t.Errorf("got %f, wanted %f", got, 0.0)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetHeliocentricJulianDate(t *testing.T) {
+	// an observation of V402 Cygni on 1973 June 15 at 11:40 UT, see the IDL Astronomy User's Library, helio_jd:
+	d := time.Date(1973, 6, 15, 11, 40, 0, 0, time.UTC)
+
+	target := common.EquatorialCoordinate{
+		RightAscension: (20 + 9.0/60 + 7.8/3600) * 15,
+		Declination:    37 + 9.0/60 + 7.0/3600,
+	}
+
+	got := GetHeliocentricJulianDate(d, common.GeographicCoordinate{}, target)
+
+	// the published HJD of 2441848.9881, to the precision of its last decimal place:
+	if math.Abs(got-2441848.9881) > 0.00005 {
+		t.Errorf("got %f, wanted %f", got, 2441848.9881)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetBarycentricJulianDate(t *testing.T) {
+	target := common.EquatorialCoordinate{
+		RightAscension: 180,
+		Declination:    0,
+	}
+
+	BJD := GetBarycentricJulianDate(datetime, observer, target)
+
+	HJD := GetHeliocentricJulianDate(datetime, observer, target)
+
+	// BJD_TDB leads HJD_UTC by TT-UTC (69.184 seconds), plus up to five seconds of the Sun's reflex motion:
+	Δ := (BJD-HJD)*86400 - 69.184
+
+	if math.Abs(Δ) > 5 {
+		t.Errorf("got %f seconds, wanted less than 5 seconds", Δ)
+	}
+}
+
+/*****************************************************************************************************************/
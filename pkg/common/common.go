@@ -20,6 +20,29 @@ var DEGREES_TO_RADIANS = math.Pi / 180
 
 /*****************************************************************************************************************/
 
+// the astronomical unit (IAU 2012 Resolution B2), in kilometres:
+const AU float64 = 149597870.7
+
+/*****************************************************************************************************************/
+
+// the equatorial radius of the Earth (WGS-84), in kilometres:
+const EARTH_EQUATORIAL_RADIUS float64 = 6378.137
+
+/*****************************************************************************************************************/
+
+// the flattening of the Earth (WGS-84):
+const EARTH_FLATTENING float64 = 1 / 298.257223563
+
+/*****************************************************************************************************************/
+
+type CartesianCoordinate struct {
+	X float64
+	Y float64
+	Z float64
+}
+
+/*****************************************************************************************************************/
+
 type EclipticCoordinate struct {
 	Longitude float64
 	Latitude  float64
@@ -14,6 +14,7 @@ import (
 
 	"github.com/observerly/sidera/pkg/astrometry"
 	"github.com/observerly/sidera/pkg/common"
+	"github.com/observerly/sidera/pkg/epoch"
 )
 
 /*****************************************************************************************************************/
@@ -98,3 +99,140 @@ func ConvertEquatorialToHorizontalCoordinate(
 }
 
 /*****************************************************************************************************************/
+
+/*
+precesses ecliptic coordinates from the mean ecliptic and equinox of J2000.0 to the mean ecliptic and
+equinox of the given datetime
+
+The precession of the equinoxes is the slow westward motion of the equinoxes along the ecliptic, caused
+by the gravitational torque of the Sun and Moon on the Earth's equatorial bulge, together with the slow
+rotation of the ecliptic itself caused by the planets. The longitude of a fixed object increases by about
+50 arcseconds per year, i.e., by around a third of a degree over the quarter century since J2000.0.
+*/
+func GetPrecessedEclipticCoordinate(
+	datetime time.Time,
+	target common.EclipticCoordinate,
+) (ecliptic common.EclipticCoordinate) {
+	// the number of centuries since J2000.0:
+	t := (epoch.GetJulianDate(datetime) - epoch.J2000) / 36525
+
+	// the angle between the ecliptic of J2000.0 and the ecliptic of date:
+	η := common.Radians((47.0029*t - 0.03302*math.Pow(t, 2) + 0.000060*math.Pow(t, 3)) / 3600)
+
+	// the longitude of the axis of rotation of the ecliptic:
+	Π := common.Radians(174.876384 + (-869.8089*t+0.03536*math.Pow(t, 2))/3600)
+
+	// the general precession in longitude:
+	p := common.Radians((5029.0966*t + 1.11113*math.Pow(t, 2) - 0.000006*math.Pow(t, 3)) / 3600)
+
+	λ := common.Radians(target.Longitude)
+
+	β := common.Radians(target.Latitude)
+
+	A := math.Cos(η)*math.Cos(β)*math.Sin(Π-λ) - math.Sin(η)*math.Sin(β)
+
+	B := math.Cos(β) * math.Cos(Π-λ)
+
+	C := math.Cos(η)*math.Sin(β) + math.Sin(η)*math.Cos(β)*math.Sin(Π-λ)
+
+	λ = math.Mod(common.Degrees(p+Π-math.Atan2(A, B)), 360)
+
+	if λ < 0 {
+		λ += 360
+	}
+
+	return common.EclipticCoordinate{
+		Longitude: λ,
+		Latitude:  common.Degrees(math.Asin(C)),
+	}
+}
+
+/*****************************************************************************************************************/
+
+/*
+precesses equatorial coordinates from the mean equator and equinox of J2000.0 to the mean equator and
+equinox of the given datetime
+
+The rigorous method uses the three precessional angles ζ, z and θ of Lieske (1977), as given in Meeus,
+"Astronomical Algorithms", Chapter 21. The right ascension of a star near the celestial equator increases by
+about 3 seconds of time per year, and the declination changes by up to 20 arcseconds per year. Proper motion
+is not included, and should be applied to the J2000.0 coordinate beforehand.
+*/
+func GetPrecessedEquatorialCoordinate(
+	datetime time.Time,
+	target common.EquatorialCoordinate,
+) (equatorial common.EquatorialCoordinate) {
+	// the number of centuries since J2000.0:
+	t := (epoch.GetJulianDate(datetime) - epoch.J2000) / 36525
+
+	ζ := common.Radians((2306.2181*t + 0.30188*math.Pow(t, 2) + 0.017998*math.Pow(t, 3)) / 3600)
+
+	z := common.Radians((2306.2181*t + 1.09468*math.Pow(t, 2) + 0.018203*math.Pow(t, 3)) / 3600)
+
+	θ := common.Radians((2004.3109*t - 0.42665*math.Pow(t, 2) - 0.041833*math.Pow(t, 3)) / 3600)
+
+	α := common.Radians(target.RightAscension)
+
+	δ := common.Radians(target.Declination)
+
+	A := math.Cos(δ) * math.Sin(α+ζ)
+
+	B := math.Cos(θ)*math.Cos(δ)*math.Cos(α+ζ) - math.Sin(θ)*math.Sin(δ)
+
+	C := math.Sin(θ)*math.Cos(δ)*math.Cos(α+ζ) + math.Cos(θ)*math.Sin(δ)
+
+	α = math.Mod(common.Degrees(math.Atan2(A, B)+z), 360)
+
+	if α < 0 {
+		α += 360
+	}
+
+	return common.EquatorialCoordinate{
+		RightAscension: α,
+		Declination:    common.Degrees(math.Atan2(C, math.Hypot(A, B))),
+	}
+}
+
+/*****************************************************************************************************************/
+
+/*
+the geocentric position of an observer on the WGS-84 ellipsoid for a given datetime, in kilometres, in the
+equatorial frame of date, and the unit vector of the observer's local vertical in the same frame
+
+The observer's position is computed from their geodetic latitude, longitude and elevation (in metres), rotated
+by the local sidereal time, so that it may be subtracted from the geocentric position of a nearby body, e.g., the
+Moon, to give its topocentric position.
+*/
+func GetObserverPosition(
+	datetime time.Time,
+	observer common.GeographicCoordinate,
+) (common.CartesianCoordinate, common.CartesianCoordinate) {
+	φ := common.Radians(observer.Latitude)
+
+	// the local sidereal angle, i.e., the right ascension of the observer's meridian:
+	θ := common.Radians(epoch.GetGreenwichSiderealTime(datetime)*15 + observer.Longitude)
+
+	// the elevation of the observer above the reference ellipsoid, in kilometres:
+	h := observer.Elevation / 1000
+
+	e2 := common.EARTH_FLATTENING * (2 - common.EARTH_FLATTENING)
+
+	// the radius of curvature in the prime vertical:
+	N := common.EARTH_EQUATORIAL_RADIUS / math.Sqrt(1-e2*math.Pow(math.Sin(φ), 2))
+
+	position := common.CartesianCoordinate{
+		X: (N + h) * math.Cos(φ) * math.Cos(θ),
+		Y: (N + h) * math.Cos(φ) * math.Sin(θ),
+		Z: (N*(1-e2) + h) * math.Sin(φ),
+	}
+
+	vertical := common.CartesianCoordinate{
+		X: math.Cos(φ) * math.Cos(θ),
+		Y: math.Cos(φ) * math.Sin(θ),
+		Z: math.Sin(φ),
+	}
+
+	return position, vertical
+}
+
+/*****************************************************************************************************************/
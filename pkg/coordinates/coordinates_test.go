@@ -9,6 +9,7 @@
 package coordinates
 
 import (
+	"math"
 	"testing"
 	"time"
 
@@ -60,3 +61,114 @@ func TestConvertEquatorialToHorizontalCoordinate(t *testing.T) {
 }
 
 /*****************************************************************************************************************/
+
+func TestGetPrecessedEclipticCoordinate(t *testing.T) {
+	// Venus at J2000.0, see Meeus, "Astronomical Algorithms", Example 21.c:
+	venus := common.EclipticCoordinate{
+		Longitude: 149.48194,
+		Latitude:  1.76549,
+	}
+
+	ec := GetPrecessedEclipticCoordinate(time.Date(2050, 1, 1, 12, 0, 0, 0, time.UTC), venus)
+
+	// After half a century, the longitude has increased by around 0.7 degrees:
+	if math.Abs(ec.Longitude-venus.Longitude-0.6985) > 0.001 {
+		t.Errorf("got %f, wanted %f", ec.Longitude, venus.Longitude+0.6985)
+	}
+
+	if math.Abs(ec.Latitude-venus.Latitude) > 0.01 {
+		t.Errorf("got %f, wanted %f", ec.Latitude, venus.Latitude)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetPrecessedEclipticCoordinateAtJ2000(t *testing.T) {
+	target := common.EclipticCoordinate{
+		Longitude: 245.79403406596947,
+		Latitude:  1.8937944394473665,
+	}
+
+	ec := GetPrecessedEclipticCoordinate(time.Date(2000, 1, 1, 12, 0, 0, 0, time.UTC), target)
+
+	if math.Abs(ec.Longitude-target.Longitude) > 0.0000001 {
+		t.Errorf("got %f, wanted %f", ec.Longitude, target.Longitude)
+	}
+
+	if math.Abs(ec.Latitude-target.Latitude) > 0.0000001 {
+		t.Errorf("got %f, wanted %f", ec.Latitude, target.Latitude)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetPrecessedEquatorialCoordinate(t *testing.T) {
+	// θ Persei at J2000.0, with its proper motion to 2028 November 13.19 already applied, see Meeus,
+	// "Astronomical Algorithms", Example 21.b:
+	persei := common.EquatorialCoordinate{
+		RightAscension: 41.054063,
+		Declination:    49.227750,
+	}
+
+	eq := GetPrecessedEquatorialCoordinate(time.Date(2028, 11, 13, 4, 33, 36, 0, time.UTC), persei)
+
+	if math.Abs(eq.RightAscension-41.547214) > 0.00001 {
+		t.Errorf("got %f, wanted %f", eq.RightAscension, 41.547214)
+	}
+
+	if math.Abs(eq.Declination-49.348483) > 0.00001 {
+		t.Errorf("got %f, wanted %f", eq.Declination, 49.348483)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetPrecessedEquatorialCoordinateAtJ2000(t *testing.T) {
+	target := common.EquatorialCoordinate{
+		RightAscension: 88.792958,
+		Declination:    7.407064,
+	}
+
+	eq := GetPrecessedEquatorialCoordinate(time.Date(2000, 1, 1, 12, 0, 0, 0, time.UTC), target)
+
+	if math.Abs(eq.RightAscension-target.RightAscension) > 0.0000001 {
+		t.Errorf("got %f, wanted %f", eq.RightAscension, target.RightAscension)
+	}
+
+	if math.Abs(eq.Declination-target.Declination) > 0.0000001 {
+		t.Errorf("got %f, wanted %f", eq.Declination, target.Declination)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetObserverPosition(t *testing.T) {
+	// an observer on the equator, at sea level, is at the equatorial radius of the Earth, on their meridian:
+	position, vertical := GetObserverPosition(datetime, common.GeographicCoordinate{Latitude: 0, Longitude: 0, Elevation: 0})
+
+	if r := math.Hypot(position.X, position.Y); math.Abs(r-common.EARTH_EQUATORIAL_RADIUS) > 1e-9 || math.Abs(position.Z) > 1e-9 {
+		t.Errorf("got %+v, wanted a radius of %f", position, common.EARTH_EQUATORIAL_RADIUS)
+	}
+
+	if math.Abs(vertical.X*vertical.X+vertical.Y*vertical.Y+vertical.Z*vertical.Z-1) > 1e-12 {
+		t.Errorf("got %+v, wanted a unit vertical", vertical)
+	}
+
+	// an observer at the north pole, at sea level, is at the polar radius of the Earth (WGS-84):
+	position, _ = GetObserverPosition(datetime, common.GeographicCoordinate{Latitude: 90, Longitude: 0, Elevation: 0})
+
+	if math.Abs(position.Z-6356.752314) > 1e-6 {
+		t.Errorf("got %f, wanted %f", position.Z, 6356.752314)
+	}
+
+	// the elevation of the observer is along their local vertical:
+	a, vertical := GetObserverPosition(datetime, common.GeographicCoordinate{Latitude: 19.8207, Longitude: -155.468094, Elevation: 0})
+
+	b, _ := GetObserverPosition(datetime, observer)
+
+	if math.Abs(b.X-a.X-4.205*vertical.X) > 1e-9 || math.Abs(b.Y-a.Y-4.205*vertical.Y) > 1e-9 || math.Abs(b.Z-a.Z-4.205*vertical.Z) > 1e-9 {
+		t.Errorf("got %+v, wanted %+v raised by 4.205 km", b, a)
+	}
+}
+
+/*****************************************************************************************************************/
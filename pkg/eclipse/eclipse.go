@@ -0,0 +1,180 @@
+/*****************************************************************************************************************/
+
+//	@author		Michael Roberts <michael@observerly.com>
+//	@package	@observerly/sidera
+//	@license	Copyright © 2021-2024 observerly
+
+/*****************************************************************************************************************/
+
+package eclipse
+
+/*****************************************************************************************************************/
+
+import (
+	"math"
+	"time"
+
+	"github.com/observerly/sidera/pkg/common"
+	"github.com/observerly/sidera/pkg/coordinates"
+	"github.com/observerly/sidera/pkg/epoch"
+	"github.com/observerly/sidera/pkg/internal/search"
+	moon "github.com/observerly/sidera/pkg/lunar"
+	sun "github.com/observerly/sidera/pkg/solar"
+)
+
+/*****************************************************************************************************************/
+
+// the radius of the Sun, in kilometres, corresponding to a semi-diameter of 959.63 arcseconds at 1 AU:
+const SUN_RADIUS float64 = 696000
+
+/*****************************************************************************************************************/
+
+/*
+the ratio of the radius of the Moon to the equatorial radius of the Earth, used for the penumbral (partial)
+contacts of a solar eclipse, i.e., the mean radius of the Moon including its highest limb features
+*/
+const MOON_PENUMBRAL_RADIUS_RATIO float64 = 0.2725076
+
+/*****************************************************************************************************************/
+
+/*
+the ratio of the radius of the Moon to the equatorial radius of the Earth, used for the umbral (total or
+annular) contacts of a solar eclipse, i.e., the mean radius of the Moon to the bottom of its limb valleys
+*/
+const MOON_UMBRAL_RADIUS_RATIO float64 = 0.272281
+
+/*****************************************************************************************************************/
+
+/*
+the apparent angular radii of the Sun and the Moon, and the angular separation of their centres, as seen from
+a given position, all in degrees
+*/
+type disks struct {
+	Separation float64
+	Sun        float64
+	Moon       float64
+	UmbralMoon float64
+}
+
+/*****************************************************************************************************************/
+
+func subtract(a common.CartesianCoordinate, b common.CartesianCoordinate) common.CartesianCoordinate {
+	return common.CartesianCoordinate{X: a.X - b.X, Y: a.Y - b.Y, Z: a.Z - b.Z}
+}
+
+/*****************************************************************************************************************/
+
+func dot(a common.CartesianCoordinate, b common.CartesianCoordinate) float64 {
+	return a.X*b.X + a.Y*b.Y + a.Z*b.Z
+}
+
+/*****************************************************************************************************************/
+
+func norm(a common.CartesianCoordinate) float64 {
+	return math.Sqrt(dot(a, a))
+}
+
+/*****************************************************************************************************************/
+
+func scale(a common.CartesianCoordinate, k float64) common.CartesianCoordinate {
+	return common.CartesianCoordinate{X: a.X * k, Y: a.Y * k, Z: a.Z * k}
+}
+
+/*****************************************************************************************************************/
+
+/*
+converts an equatorial coordinate and distance to a position in the equatorial frame of date
+*/
+func convertEquatorialToCartesianCoordinate(eq common.EquatorialCoordinate, r float64) common.CartesianCoordinate {
+	α := common.Radians(eq.RightAscension)
+
+	δ := common.Radians(eq.Declination)
+
+	return common.CartesianCoordinate{
+		X: r * math.Cos(δ) * math.Cos(α),
+		Y: r * math.Cos(δ) * math.Sin(α),
+		Z: r * math.Sin(δ),
+	}
+}
+
+/*****************************************************************************************************************/
+
+/*
+the apparent geocentric equatorial coordinate of the Sun for a given datetime, referred to the mean equator
+and equinox of date, corrected for the annual aberration of -20.4898" / R
+*/
+func getSolarEquatorialCoordinate(datetime time.Time) common.EquatorialCoordinate {
+	λ := sun.GetTrueEclipticLongitude(datetime) - 20.4898/3600/sun.GetDistance(datetime)
+
+	return coordinates.ConvertEclipticToEquatorialCoordinate(datetime, common.EclipticCoordinate{
+		Longitude: λ,
+		Latitude:  0,
+	})
+}
+
+/*****************************************************************************************************************/
+
+/*
+the geocentric positions of the Sun and the Moon for a given datetime, in kilometres, in the equatorial frame
+of date
+*/
+func getPositions(datetime time.Time) (common.CartesianCoordinate, common.CartesianCoordinate) {
+	S := convertEquatorialToCartesianCoordinate(getSolarEquatorialCoordinate(datetime), sun.GetDistance(datetime)*common.AU)
+
+	M := convertEquatorialToCartesianCoordinate(moon.GetEquatorialCoordinate(datetime), moon.GetDistance(datetime))
+
+	return S, M
+}
+
+/*****************************************************************************************************************/
+
+/*
+converts a geocentric position on the surface of the Earth, in the equatorial frame of date, to the geographic
+coordinate of that point for a given datetime
+*/
+func convertCartesianToGeographicCoordinate(datetime time.Time, position common.CartesianCoordinate) common.GeographicCoordinate {
+	λ := math.Remainder(common.Degrees(math.Atan2(position.Y, position.X))-epoch.GetGreenwichSiderealTime(datetime)*15, 360)
+
+	φ := math.Atan2(position.Z, math.Pow(1-common.EARTH_FLATTENING, 2)*math.Hypot(position.X, position.Y))
+
+	return common.GeographicCoordinate{
+		Latitude:  common.Degrees(φ),
+		Longitude: λ,
+		Elevation: 0,
+	}
+}
+
+/*****************************************************************************************************************/
+
+/*
+the apparent angular radii of the Sun and the Moon, and the angular separation of their centres, as seen from
+a given geocentric position (in kilometres, in the equatorial frame of date) at a given datetime
+*/
+func getDisks(datetime time.Time, position common.CartesianCoordinate) disks {
+	S, M := getPositions(datetime)
+
+	s := subtract(S, position)
+
+	m := subtract(M, position)
+
+	rs, rm := norm(s), norm(m)
+
+	return disks{
+		Separation: common.Degrees(math.Acos(math.Max(-1, math.Min(1, dot(s, m)/(rs*rm))))),
+		Sun:        common.Degrees(math.Asin(SUN_RADIUS / rs)),
+		Moon:       common.Degrees(math.Asin(MOON_PENUMBRAL_RADIUS_RATIO * common.EARTH_EQUATORIAL_RADIUS / rm)),
+		UmbralMoon: common.Degrees(math.Asin(MOON_UMBRAL_RADIUS_RATIO * common.EARTH_EQUATORIAL_RADIUS / rm)),
+	}
+}
+
+/*****************************************************************************************************************/
+
+/*
+finds the datetimes at which a function, which is negative at a given datetime and positive at the given
+window either side of it, changes sign before and after that datetime
+*/
+func getContacts(datetime time.Time, window time.Duration, f func(datetime time.Time) float64) (time.Time, time.Time) {
+	return search.Bisect(datetime.Add(-window), datetime, f), search.Bisect(datetime, datetime.Add(window), f)
+}
+
+/*****************************************************************************************************************/
@@ -0,0 +1,89 @@
+/*****************************************************************************************************************/
+
+//	@author		Michael Roberts <michael@observerly.com>
+//	@package	@observerly/sidera
+//	@license	Copyright © 2021-2024 observerly
+
+/*****************************************************************************************************************/
+
+package eclipse
+
+/*****************************************************************************************************************/
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/observerly/sidera/pkg/common"
+	"github.com/observerly/sidera/pkg/coordinates"
+	moon "github.com/observerly/sidera/pkg/lunar"
+	sun "github.com/observerly/sidera/pkg/solar"
+)
+
+/*****************************************************************************************************************/
+
+// We define a datetime as some arbitrary date and time for testing purposes:
+var datetime time.Time = time.Date(2024, 4, 8, 18, 0, 0, 0, time.UTC)
+
+/*****************************************************************************************************************/
+
+func TestConvertCartesianToGeographicCoordinate(t *testing.T) {
+	observer := common.GeographicCoordinate{Latitude: 19.82067, Longitude: -155.468094, Elevation: 0}
+
+	position, vertical := coordinates.GetObserverPosition(datetime, observer)
+
+	got := convertCartesianToGeographicCoordinate(datetime, position)
+
+	if math.Abs(got.Latitude-observer.Latitude) > 1e-9 || math.Abs(got.Longitude-observer.Longitude) > 1e-9 {
+		t.Errorf("got %+v, wanted %+v", got, observer)
+	}
+
+	if math.Abs(norm(vertical)-1) > 1e-12 {
+		t.Errorf("got %f, wanted a unit vertical", norm(vertical))
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetDisks(t *testing.T) {
+	d := getDisks(datetime, common.CartesianCoordinate{})
+
+	if math.Abs(d.Sun-sun.GetSemiDiameter(datetime)) > 0.0001 {
+		t.Errorf("got %f, wanted %f", d.Sun, sun.GetSemiDiameter(datetime))
+	}
+
+	if math.Abs(d.Moon-moon.GetSemiDiameter(datetime)) > 0.0001 {
+		t.Errorf("got %f, wanted %f", d.Moon, moon.GetSemiDiameter(datetime))
+	}
+
+	if d.UmbralMoon >= d.Moon {
+		t.Errorf("got %f, wanted less than %f", d.UmbralMoon, d.Moon)
+	}
+
+	// the Moon is close to the Sun, shortly before the greatest eclipse:
+	if d.Separation > 1 {
+		t.Errorf("got %f, wanted less than 1 degree", d.Separation)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetContacts(t *testing.T) {
+	// a function which is negative within ten minutes of the datetime:
+	f := func(d time.Time) float64 {
+		return math.Abs(d.Sub(datetime).Minutes()) - 10
+	}
+
+	a, b := getContacts(datetime, time.Hour, f)
+
+	if math.Abs(a.Sub(datetime.Add(-10*time.Minute)).Seconds()) > 0.1 {
+		t.Errorf("got %v, wanted %v", a, datetime.Add(-10*time.Minute))
+	}
+
+	if math.Abs(b.Sub(datetime.Add(10*time.Minute)).Seconds()) > 0.1 {
+		t.Errorf("got %v, wanted %v", b, datetime.Add(10*time.Minute))
+	}
+}
+
+/*****************************************************************************************************************/
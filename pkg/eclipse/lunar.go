@@ -0,0 +1,174 @@
+/*****************************************************************************************************************/
+
+//	@author		Michael Roberts <michael@observerly.com>
+//	@package	@observerly/sidera
+//	@license	Copyright © 2021-2024 observerly
+
+/*****************************************************************************************************************/
+
+package eclipse
+
+/*****************************************************************************************************************/
+
+import (
+	"math"
+	"time"
+
+	"github.com/observerly/sidera/pkg/common"
+	"github.com/observerly/sidera/pkg/events"
+	"github.com/observerly/sidera/pkg/internal/search"
+	moon "github.com/observerly/sidera/pkg/lunar"
+	sun "github.com/observerly/sidera/pkg/solar"
+)
+
+/*****************************************************************************************************************/
+
+type LunarEclipseType int
+
+/*****************************************************************************************************************/
+
+const (
+	// the Moon passes through the Earth's penumbra only:
+	PenumbralLunarEclipse LunarEclipseType = iota
+	// the Moon passes partly through the Earth's umbra:
+	PartialLunarEclipse
+	// the Moon passes completely into the Earth's umbra:
+	TotalLunarEclipse
+)
+
+/*****************************************************************************************************************/
+
+/*
+a lunar eclipse
+
+The greatest eclipse is the instant at which the centre of the Moon passes closest to the axis of the Earth's
+shadow. Gamma is that closest distance, in equatorial radii of the Earth, and is positive when the Moon passes
+north of the axis. The penumbral and umbral magnitudes are the fractions of the Moon's diameter immersed in the
+penumbra and umbra at greatest eclipse; the umbral magnitude is negative for a penumbral eclipse.
+
+The contacts are the beginning and end of the penumbral (P1, P4), partial (U1, U4) and total (U2, U3) phases,
+and are the zero time for phases which do not occur.
+*/
+type LunarEclipse struct {
+	Type               LunarEclipseType
+	GreatestEclipse    time.Time
+	Gamma              float64
+	PenumbralMagnitude float64
+	UmbralMagnitude    float64
+	PenumbralBegin     time.Time
+	PartialBegin       time.Time
+	TotalBegin         time.Time
+	TotalEnd           time.Time
+	PartialEnd         time.Time
+	PenumbralEnd       time.Time
+}
+
+/*****************************************************************************************************************/
+
+/*
+the angular separation of the Moon from the axis of the Earth's shadow, the angular radii of the penumbra and
+umbra at the distance of the Moon, and the semi-diameter of the Moon, for a given datetime, all in degrees
+
+The radii of the shadow follow Danjon's rule, in which the radius of the Earth is enlarged by 1/85 to allow for
+the opacity of its atmosphere, with an equivalent radius of 0.99834 equatorial radii for the oblate Earth.
+*/
+func getShadow(datetime time.Time) (separation float64, penumbra float64, umbra float64, semidiameter float64) {
+	S, M := getPositions(datetime)
+
+	// the separation of the Moon from the anti-solar point:
+	separation = 180 - common.Degrees(math.Acos(math.Max(-1, math.Min(1, dot(S, M)/(norm(S)*norm(M))))))
+
+	// the horizontal parallaxes of the Moon and the Sun:
+	πm := moon.GetHorizontalParallax(datetime)
+
+	πs := 8.794143 / 3600 / sun.GetDistance(datetime)
+
+	ρ := (1 + 1.0/85) * (0.99834*πm + πs)
+
+	s := sun.GetSemiDiameter(datetime)
+
+	return separation, ρ + s, ρ - s, moon.GetSemiDiameter(datetime)
+}
+
+/*****************************************************************************************************************/
+
+/*
+finds the lunar eclipses within a date range
+
+Each full moon within the date range is examined, and the instant of greatest eclipse is found as the instant
+at which the Moon passes closest to the axis of the Earth's shadow. The contacts are found by bisection to within
+a tenth of a second, but as the edge of the Earth's shadow is diffuse, they are in practice uncertain by around
+a minute of time.
+*/
+func GetLunarEclipses(start time.Time, end time.Time) []LunarEclipse {
+	eclipses := []LunarEclipse{}
+
+	// the contacts of the penumbral phase are within around three and a half hours of greatest eclipse:
+	window := 4 * time.Hour
+
+	for _, opposition := range events.GetOppositions(start.Add(-24*time.Hour), end.Add(24*time.Hour), events.Moon) {
+		datetime := search.Minimise(opposition.Datetime.Add(-3*time.Hour), opposition.Datetime.Add(3*time.Hour), func(datetime time.Time) float64 {
+			separation, _, _, _ := getShadow(datetime)
+			return separation
+		})
+
+		if datetime.Before(start) || datetime.After(end) {
+			continue
+		}
+
+		separation, penumbra, umbra, semidiameter := getShadow(datetime)
+
+		if separation >= penumbra+semidiameter {
+			continue
+		}
+
+		S, M := getPositions(datetime)
+
+		// the offset of the Moon from the axis of the shadow, perpendicular to the axis:
+		n := scale(S, 1/norm(S))
+
+		offset := subtract(M, scale(n, dot(M, n)))
+
+		eclipse := LunarEclipse{
+			Type:               PenumbralLunarEclipse,
+			GreatestEclipse:    datetime,
+			Gamma:              math.Copysign(norm(offset)/common.EARTH_EQUATORIAL_RADIUS, offset.Z),
+			PenumbralMagnitude: (penumbra + semidiameter - separation) / (2 * semidiameter),
+			UmbralMagnitude:    (umbra + semidiameter - separation) / (2 * semidiameter),
+		}
+
+		// the contact functions, which are negative during each phase of the eclipse:
+		contact := func(radius func(penumbra float64, umbra float64, semidiameter float64) float64) func(datetime time.Time) float64 {
+			return func(datetime time.Time) float64 {
+				separation, penumbra, umbra, semidiameter := getShadow(datetime)
+				return separation - radius(penumbra, umbra, semidiameter)
+			}
+		}
+
+		eclipse.PenumbralBegin, eclipse.PenumbralEnd = getContacts(datetime, window, contact(func(p, u, s float64) float64 {
+			return p + s
+		}))
+
+		if eclipse.UmbralMagnitude > 0 {
+			eclipse.Type = PartialLunarEclipse
+
+			eclipse.PartialBegin, eclipse.PartialEnd = getContacts(datetime, window, contact(func(p, u, s float64) float64 {
+				return u + s
+			}))
+		}
+
+		if eclipse.UmbralMagnitude >= 1 {
+			eclipse.Type = TotalLunarEclipse
+
+			eclipse.TotalBegin, eclipse.TotalEnd = getContacts(datetime, window, contact(func(p, u, s float64) float64 {
+				return u - s
+			}))
+		}
+
+		eclipses = append(eclipses, eclipse)
+	}
+
+	return eclipses
+}
+
+/*****************************************************************************************************************/
@@ -0,0 +1,118 @@
+/*****************************************************************************************************************/
+
+//	@author		Michael Roberts <michael@observerly.com>
+//	@package	@observerly/sidera
+//	@license	Copyright © 2021-2024 observerly
+
+/*****************************************************************************************************************/
+
+package eclipse
+
+/*****************************************************************************************************************/
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+/*****************************************************************************************************************/
+
+func TestGetLunarEclipses(t *testing.T) {
+	got := GetLunarEclipses(time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	// the lunar eclipses of 2022-2024, from the NASA Five Millennium Canon of Lunar Eclipses (Espenak & Meeus):
+	want := []struct {
+		Type      LunarEclipseType
+		Gamma     float64
+		Penumbral float64
+		Umbral    float64
+	}{
+		{TotalLunarEclipse, -0.2532, 2.3726, 1.4137},
+		{TotalLunarEclipse, 0.2570, 2.4152, 1.3589},
+		{PenumbralLunarEclipse, -1.0350, 0.9638, -0.0464},
+		{PartialLunarEclipse, 0.9472, 1.1213, 0.1224},
+		{PenumbralLunarEclipse, 1.0610, 0.9577, -0.1322},
+		{PartialLunarEclipse, -0.9792, 1.0379, 0.0848},
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("got %d eclipses, wanted %d", len(got), len(want))
+	}
+
+	for i, w := range want {
+		if got[i].Type != w.Type {
+			t.Errorf("got type %d, wanted %d", got[i].Type, w.Type)
+		}
+
+		if math.Abs(got[i].Gamma-w.Gamma) > 0.002 {
+			t.Errorf("got %f, wanted %f", got[i].Gamma, w.Gamma)
+		}
+
+		if math.Abs(got[i].PenumbralMagnitude-w.Penumbral) > 0.005 {
+			t.Errorf("got %f, wanted %f", got[i].PenumbralMagnitude, w.Penumbral)
+		}
+
+		if math.Abs(got[i].UmbralMagnitude-w.Umbral) > 0.005 {
+			t.Errorf("got %f, wanted %f", got[i].UmbralMagnitude, w.Umbral)
+		}
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetLunarEclipseContacts(t *testing.T) {
+	got := GetLunarEclipses(time.Date(2022, 11, 1, 0, 0, 0, 0, time.UTC), time.Date(2022, 12, 1, 0, 0, 0, 0, time.UTC))
+
+	if len(got) != 1 {
+		t.Fatalf("got %d eclipses, wanted 1", len(got))
+	}
+
+	// the total lunar eclipse of 8 November 2022:
+	e := got[0]
+
+	assertDatetime(t, e.GreatestEclipse, time.Date(2022, 11, 8, 10, 59, 11, 0, time.UTC), time.Minute)
+
+	assertDatetime(t, e.PenumbralBegin, time.Date(2022, 11, 8, 8, 2, 17, 0, time.UTC), time.Minute)
+
+	assertDatetime(t, e.PartialBegin, time.Date(2022, 11, 8, 9, 9, 12, 0, time.UTC), time.Minute)
+
+	assertDatetime(t, e.TotalBegin, time.Date(2022, 11, 8, 10, 16, 39, 0, time.UTC), time.Minute)
+
+	assertDatetime(t, e.TotalEnd, time.Date(2022, 11, 8, 11, 41, 35, 0, time.UTC), time.Minute)
+
+	assertDatetime(t, e.PartialEnd, time.Date(2022, 11, 8, 12, 49, 3, 0, time.UTC), time.Minute)
+
+	assertDatetime(t, e.PenumbralEnd, time.Date(2022, 11, 8, 13, 56, 9, 0, time.UTC), time.Minute)
+}
+
+/*****************************************************************************************************************/
+
+func TestGetLunarEclipsePenumbral(t *testing.T) {
+	got := GetLunarEclipses(time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC), time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC))
+
+	if len(got) != 1 {
+		t.Fatalf("got %d eclipses, wanted 1", len(got))
+	}
+
+	e := got[0]
+
+	if e.PenumbralBegin.IsZero() || e.PenumbralEnd.IsZero() {
+		t.Errorf("got %v, %v, wanted penumbral contacts", e.PenumbralBegin, e.PenumbralEnd)
+	}
+
+	if !e.PartialBegin.IsZero() || !e.TotalBegin.IsZero() || !e.TotalEnd.IsZero() || !e.PartialEnd.IsZero() {
+		t.Errorf("got %+v, wanted no partial or total contacts", e)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetLunarEclipsesNone(t *testing.T) {
+	// there were no lunar eclipses in the first four months of 2022:
+	if got := GetLunarEclipses(time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2022, 5, 1, 0, 0, 0, 0, time.UTC)); len(got) != 0 {
+		t.Errorf("got %+v, wanted no eclipses", got)
+	}
+}
+
+/*****************************************************************************************************************/
@@ -0,0 +1,357 @@
+/*****************************************************************************************************************/
+
+//	@author		Michael Roberts <michael@observerly.com>
+//	@package	@observerly/sidera
+//	@license	Copyright © 2021-2024 observerly
+
+/*****************************************************************************************************************/
+
+package eclipse
+
+/*****************************************************************************************************************/
+
+import (
+	"math"
+	"time"
+
+	"github.com/observerly/sidera/pkg/common"
+	"github.com/observerly/sidera/pkg/coordinates"
+	"github.com/observerly/sidera/pkg/events"
+	"github.com/observerly/sidera/pkg/internal/search"
+)
+
+/*****************************************************************************************************************/
+
+type SolarEclipseType int
+
+/*****************************************************************************************************************/
+
+const (
+	// only the penumbra of the Moon's shadow falls on the Earth, or the observer lies outside of the umbra:
+	PartialSolarEclipse SolarEclipseType = iota
+	// the antumbra of the Moon's shadow falls on the Earth, leaving a ring of the Sun's disk visible:
+	AnnularSolarEclipse
+	// the umbra of the Moon's shadow falls on the Earth, and the Sun's disk is completely covered:
+	TotalSolarEclipse
+	// the eclipse is total over part of its central path, and annular over the remainder:
+	HybridSolarEclipse
+)
+
+/*****************************************************************************************************************/
+
+/*
+a solar eclipse, as seen from the Earth as a whole
+
+The greatest eclipse is the instant at which the axis of the Moon's shadow passes closest to the centre of the
+Earth. Gamma is that closest distance, in equatorial radii of the Earth, and is positive when the axis passes
+north of the centre of the Earth. The eclipse is central when the axis of the shadow meets the surface of the
+Earth, and the location of greatest eclipse is then the point at which it does so; otherwise, it is the point
+on the Earth's limb closest to the axis. The magnitude is the fraction of the Sun's diameter covered by the Moon
+at the location of greatest eclipse, or, for a central eclipse, the ratio of the apparent diameters of the
+Moon and the Sun there.
+*/
+type SolarEclipse struct {
+	Type            SolarEclipseType
+	Central         bool
+	GreatestEclipse time.Time
+	Gamma           float64
+	Magnitude       float64
+	Location        common.GeographicCoordinate
+}
+
+/*****************************************************************************************************************/
+
+/*
+the local circumstances of a solar eclipse for an observer
+
+The first and fourth contacts are the beginning and end of the partial phase, and the second and third contacts
+are the beginning and end of the total or annular phase (and are the zero time for a partial eclipse). The
+magnitude is the fraction of the Sun's diameter covered by the Moon at maximum eclipse, and the obscuration is
+the fraction of the Sun's disk covered by the Moon. The altitude is the geometric altitude of the Sun at maximum
+eclipse, in degrees; some of the contacts may occur while the Sun is below the observer's horizon.
+*/
+type SolarEclipseLocalCircumstances struct {
+	Type           SolarEclipseType
+	FirstContact   time.Time
+	SecondContact  time.Time
+	MaximumEclipse time.Time
+	ThirdContact   time.Time
+	FourthContact  time.Time
+	Magnitude      float64
+	Obscuration    float64
+	Altitude       float64
+}
+
+/*****************************************************************************************************************/
+
+/*
+the axis of the Moon's shadow for a given datetime, as the position of the Moon and the unit vector from the
+Moon towards the Sun, and the distance of the Sun from the Moon, in kilometres
+*/
+func getShadowAxis(datetime time.Time) (common.CartesianCoordinate, common.CartesianCoordinate, float64) {
+	S, M := getPositions(datetime)
+
+	d := subtract(S, M)
+
+	r := norm(d)
+
+	return M, scale(d, 1/r), r
+}
+
+/*****************************************************************************************************************/
+
+/*
+the signed distance of the axis of the Moon's shadow from the centre of the Earth, in equatorial radii of the
+Earth, for a given datetime
+*/
+func getGamma(datetime time.Time) float64 {
+	M, g, _ := getShadowAxis(datetime)
+
+	// the point on the axis closest to the centre of the Earth, i.e., in the fundamental plane:
+	Q := subtract(M, scale(g, dot(M, g)))
+
+	return math.Copysign(norm(Q)/common.EARTH_EQUATORIAL_RADIUS, Q.Z)
+}
+
+/*****************************************************************************************************************/
+
+/*
+the obscuration of the Sun's disk by the Moon's disk, i.e., the fraction of the area of the Sun's disk which is
+covered by the Moon, for the given apparent disks
+*/
+func getObscuration(d disks) float64 {
+	s, m, r := d.Sun, d.Moon, d.Separation
+
+	if r >= s+m {
+		return 0
+	}
+
+	if r <= math.Abs(m-s) {
+		return math.Min(1, math.Pow(m/s, 2))
+	}
+
+	// the area of the lens-shaped intersection of the two disks:
+	A := s*s*math.Acos((r*r+s*s-m*m)/(2*r*s)) + m*m*math.Acos((r*r+m*m-s*s)/(2*r*m)) -
+		0.5*math.Sqrt((-r+s+m)*(r+s-m)*(r-s+m)*(r+s+m))
+
+	return A / (math.Pi * s * s)
+}
+
+/*****************************************************************************************************************/
+
+/*
+the global circumstances of a possible solar eclipse at a given datetime of greatest eclipse, and whether the
+penumbra of the Moon's shadow falls on the Earth at all
+*/
+func getSolarEclipse(datetime time.Time) (SolarEclipse, bool) {
+	M, g, r := getShadowAxis(datetime)
+
+	// the distance of the Moon above the fundamental plane, through the centre of the Earth:
+	z := dot(M, g)
+
+	// the semi-vertex angles of the penumbral and umbral cones:
+	f1 := math.Asin((SUN_RADIUS + MOON_PENUMBRAL_RADIUS_RATIO*common.EARTH_EQUATORIAL_RADIUS) / r)
+
+	f2 := math.Asin((SUN_RADIUS - MOON_UMBRAL_RADIUS_RATIO*common.EARTH_EQUATORIAL_RADIUS) / r)
+
+	// the radii of the penumbra and umbra in the fundamental plane, in equatorial radii of the Earth, where the
+	// radius of the umbra is negative when the vertex of the umbral cone lies above the plane:
+	l1 := (MOON_PENUMBRAL_RADIUS_RATIO*common.EARTH_EQUATORIAL_RADIUS/math.Cos(f1) + z*math.Tan(f1)) / common.EARTH_EQUATORIAL_RADIUS
+
+	l2 := (MOON_UMBRAL_RADIUS_RATIO*common.EARTH_EQUATORIAL_RADIUS/math.Cos(f2) - z*math.Tan(f2)) / common.EARTH_EQUATORIAL_RADIUS
+
+	// the positions are scaled along the polar axis, in which the Earth's ellipsoid is a sphere of unit radius:
+	k := 1 / (1 - common.EARTH_FLATTENING)
+
+	m := common.CartesianCoordinate{X: M.X / common.EARTH_EQUATORIAL_RADIUS, Y: M.Y / common.EARTH_EQUATORIAL_RADIUS, Z: M.Z * k / common.EARTH_EQUATORIAL_RADIUS}
+
+	u := common.CartesianCoordinate{X: g.X, Y: g.Y, Z: g.Z * k}
+
+	// the closest approach of the axis to the centre of the Earth, in the scaled space:
+	t := dot(m, u) / dot(u, u)
+
+	q := subtract(m, scale(u, t))
+
+	γ := norm(q)
+
+	if γ >= 1+l1 {
+		return SolarEclipse{}, false
+	}
+
+	eclipse := SolarEclipse{
+		Type:            PartialSolarEclipse,
+		Central:         γ < 1,
+		GreatestEclipse: datetime,
+		Gamma:           getGamma(datetime),
+	}
+
+	var p common.CartesianCoordinate
+
+	if eclipse.Central {
+		// the intersection of the axis with the surface of the Earth, on the side facing the Sun:
+		t -= math.Sqrt(1-γ*γ) / norm(u)
+
+		p = subtract(m, scale(u, t))
+	} else {
+		// the point on the limb of the Earth closest to the axis:
+		p = scale(q, 1/γ)
+	}
+
+	// the location of greatest eclipse, on the surface of the Earth:
+	P := common.CartesianCoordinate{X: p.X * common.EARTH_EQUATORIAL_RADIUS, Y: p.Y * common.EARTH_EQUATORIAL_RADIUS, Z: p.Z / k * common.EARTH_EQUATORIAL_RADIUS}
+
+	eclipse.Location = convertCartesianToGeographicCoordinate(datetime, P)
+
+	d := getDisks(datetime, P)
+
+	switch {
+	case eclipse.Central && d.UmbralMoon > d.Sun && l2 < 0:
+		eclipse.Type = HybridSolarEclipse
+	case eclipse.Central && d.UmbralMoon > d.Sun:
+		eclipse.Type = TotalSolarEclipse
+	case eclipse.Central:
+		eclipse.Type = AnnularSolarEclipse
+	case γ < 1+math.Abs(l2) && l2 > 0:
+		eclipse.Type = TotalSolarEclipse
+	case γ < 1+math.Abs(l2):
+		eclipse.Type = AnnularSolarEclipse
+	}
+
+	if eclipse.Central {
+		eclipse.Magnitude = d.UmbralMoon / d.Sun
+	} else {
+		eclipse.Magnitude = (d.Sun + d.Moon - d.Separation) / (2 * d.Sun)
+	}
+
+	return eclipse, true
+}
+
+/*****************************************************************************************************************/
+
+/*
+finds the solar eclipses within a date range
+
+Each new moon within the date range is examined, and the instant of greatest eclipse is found as the instant
+at which the axis of the Moon's shadow passes closest to the centre of the Earth. An eclipse occurs when the
+penumbra of the Moon's shadow falls on the Earth, allowing for the flattening of the Earth. The type of a
+central eclipse is found from the apparent diameters of the Sun and the Moon at the location of greatest
+eclipse; the eclipse is hybrid if it is total there but the vertex of the umbral cone does not reach the
+fundamental plane, i.e., if the eclipse is annular towards the ends of its central path.
+*/
+func GetSolarEclipses(start time.Time, end time.Time) []SolarEclipse {
+	eclipses := []SolarEclipse{}
+
+	for _, conjunction := range events.GetConjunctions(start.Add(-24*time.Hour), end.Add(24*time.Hour), events.Moon, events.Sun) {
+		// the greatest eclipse is within a few hours of the conjunction in ecliptic longitude:
+		datetime := search.Minimise(conjunction.Datetime.Add(-3*time.Hour), conjunction.Datetime.Add(3*time.Hour), func(datetime time.Time) float64 {
+			return math.Abs(getGamma(datetime))
+		})
+
+		if datetime.Before(start) || datetime.After(end) {
+			continue
+		}
+
+		if eclipse, ok := getSolarEclipse(datetime); ok {
+			eclipses = append(eclipses, eclipse)
+		}
+	}
+
+	return eclipses
+}
+
+/*****************************************************************************************************************/
+
+/*
+the local circumstances of a solar eclipse for an observer, and whether the eclipse is visible from the
+observer's location at all, i.e., whether the Moon covers part of the Sun while the Sun is above the horizon
+
+The apparent (topocentric) disks of the Sun and the Moon are found from the observer's position on the WGS-84
+ellipsoid. The maximum eclipse is the instant of the least separation of their centres, and the contacts are the
+instants at which their limbs touch. The partial contacts use a lunar radius of k = 0.2725076, and the total or
+annular contacts a radius of k = 0.272281, following the convention of the NASA eclipse predictions. The
+irregular profile of the Moon's limb is not taken into account, and the contacts are accurate to within around
+half a minute of time, as limited by the accuracy of the solar and lunar ephemerides.
+*/
+func GetSolarEclipseLocalCircumstances(
+	eclipse SolarEclipse,
+	observer common.GeographicCoordinate,
+) (SolarEclipseLocalCircumstances, bool) {
+	getDisksForObserver := func(datetime time.Time) disks {
+		position, _ := coordinates.GetObserverPosition(datetime, observer)
+		return getDisks(datetime, position)
+	}
+
+	separation := func(datetime time.Time) float64 {
+		return getDisksForObserver(datetime).Separation
+	}
+
+	// the Moon's shadow crosses the Earth in at most about six hours, so the local maximum lies within a few hours
+	// of the greatest eclipse:
+	window := 4 * time.Hour
+
+	start := eclipse.GreatestEclipse.Add(-window)
+
+	// locate the least separation to within a minute, before refining it:
+	maximum, least := start, math.Inf(1)
+
+	for datetime := start; !datetime.After(eclipse.GreatestEclipse.Add(window)); datetime = datetime.Add(time.Minute) {
+		if d := separation(datetime); d < least {
+			maximum, least = datetime, d
+		}
+	}
+
+	maximum = search.Minimise(maximum.Add(-time.Minute), maximum.Add(time.Minute), separation)
+
+	d := getDisksForObserver(maximum)
+
+	if d.Separation >= d.Sun+d.Moon {
+		return SolarEclipseLocalCircumstances{}, false
+	}
+
+	// the geometric altitude of the Sun:
+	altitude := func(datetime time.Time) float64 {
+		position, vertical := coordinates.GetObserverPosition(datetime, observer)
+
+		S, _ := getPositions(datetime)
+
+		s := subtract(S, position)
+
+		return common.Degrees(math.Asin(dot(s, vertical) / norm(s)))
+	}
+
+	local := SolarEclipseLocalCircumstances{
+		Type:           PartialSolarEclipse,
+		MaximumEclipse: maximum,
+		Magnitude:      (d.Sun + d.Moon - d.Separation) / (2 * d.Sun),
+		Obscuration:    getObscuration(d),
+		Altitude:       altitude(maximum),
+	}
+
+	local.FirstContact, local.FourthContact = getContacts(maximum, window, func(datetime time.Time) float64 {
+		d := getDisksForObserver(datetime)
+		return d.Separation - (d.Sun + d.Moon)
+	})
+
+	// the eclipse is not visible if the Sun is below the horizon throughout, allowing for its semi-diameter and
+	// the refraction at the horizon, as at sunrise and sunset:
+	if math.Max(local.Altitude, math.Max(altitude(local.FirstContact), altitude(local.FourthContact))) < -0.8333 {
+		return SolarEclipseLocalCircumstances{}, false
+	}
+
+	if d.Separation < math.Abs(d.UmbralMoon-d.Sun) {
+		local.Type = AnnularSolarEclipse
+
+		if d.UmbralMoon > d.Sun {
+			local.Type = TotalSolarEclipse
+		}
+
+		local.SecondContact, local.ThirdContact = getContacts(maximum, window, func(datetime time.Time) float64 {
+			d := getDisksForObserver(datetime)
+			return d.Separation - math.Abs(d.UmbralMoon-d.Sun)
+		})
+	}
+
+	return local, true
+}
+
+/*****************************************************************************************************************/
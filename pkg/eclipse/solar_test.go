@@ -0,0 +1,218 @@
+/*****************************************************************************************************************/
+
+//	@author		Michael Roberts <michael@observerly.com>
+//	@package	@observerly/sidera
+//	@license	Copyright © 2021-2024 observerly
+
+/*****************************************************************************************************************/
+
+package eclipse
+
+/*****************************************************************************************************************/
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/observerly/sidera/pkg/common"
+)
+
+/*****************************************************************************************************************/
+
+func assertDatetime(t *testing.T, got time.Time, want time.Time, tolerance time.Duration) {
+	t.Helper()
+
+	if d := got.Sub(want); d > tolerance || d < -tolerance {
+		t.Errorf("got %v, wanted %v", got, want)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetSolarEclipses(t *testing.T) {
+	got := GetSolarEclipses(time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	// the solar eclipses of 2022-2024, from the NASA Five Millennium Canon of Solar Eclipses (Espenak & Meeus), with
+	// the time of greatest eclipse in UT:
+	want := []struct {
+		Type      SolarEclipseType
+		Central   bool
+		Greatest  time.Time
+		Gamma     float64
+		Magnitude float64
+	}{
+		{PartialSolarEclipse, false, time.Date(2022, 4, 30, 20, 40, 40, 0, time.UTC), -1.1901, 0.6396},
+		{PartialSolarEclipse, false, time.Date(2022, 10, 25, 11, 0, 11, 0, time.UTC), 1.0701, 0.8619},
+		{HybridSolarEclipse, true, time.Date(2023, 4, 20, 4, 16, 47, 0, time.UTC), -0.3952, 1.0132},
+		{AnnularSolarEclipse, true, time.Date(2023, 10, 14, 17, 59, 32, 0, time.UTC), 0.3753, 0.9520},
+		{TotalSolarEclipse, true, time.Date(2024, 4, 8, 18, 17, 16, 0, time.UTC), 0.3431, 1.0566},
+		{AnnularSolarEclipse, true, time.Date(2024, 10, 2, 18, 45, 4, 0, time.UTC), -0.3509, 0.9326},
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("got %d eclipses, wanted %d", len(got), len(want))
+	}
+
+	for i, w := range want {
+		if got[i].Type != w.Type || got[i].Central != w.Central {
+			t.Errorf("got type %d (central %t), wanted %d (central %t)", got[i].Type, got[i].Central, w.Type, w.Central)
+		}
+
+		assertDatetime(t, got[i].GreatestEclipse, w.Greatest, time.Minute)
+
+		if math.Abs(got[i].Gamma-w.Gamma) > 0.002 {
+			t.Errorf("got %f, wanted %f", got[i].Gamma, w.Gamma)
+		}
+
+		if math.Abs(got[i].Magnitude-w.Magnitude) > 0.002 {
+			t.Errorf("got %f, wanted %f", got[i].Magnitude, w.Magnitude)
+		}
+	}
+
+	// the greatest eclipse of 8 April 2024 was at 25°17'N, 104°08'W, near Nazas, Mexico:
+	if location := got[4].Location; math.Abs(location.Latitude-25.29) > 0.2 || math.Abs(location.Longitude+104.14) > 0.2 {
+		t.Errorf("got %+v, wanted 25.29°N, 104.14°W", location)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetSolarEclipseLocalCircumstancesTotal(t *testing.T) {
+	eclipses := GetSolarEclipses(time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC))
+
+	if len(eclipses) != 1 {
+		t.Fatalf("got %d eclipses, wanted 1", len(eclipses))
+	}
+
+	// Dallas, Texas:
+	got, ok := GetSolarEclipseLocalCircumstances(eclipses[0], common.GeographicCoordinate{
+		Latitude:  32.7767,
+		Longitude: -96.797,
+		Elevation: 140,
+	})
+
+	if !ok || got.Type != TotalSolarEclipse {
+		t.Fatalf("got %+v, wanted a total eclipse", got)
+	}
+
+	assertDatetime(t, got.FirstContact, time.Date(2024, 4, 8, 17, 23, 20, 0, time.UTC), time.Minute)
+
+	assertDatetime(t, got.SecondContact, time.Date(2024, 4, 8, 18, 40, 43, 0, time.UTC), time.Minute)
+
+	assertDatetime(t, got.ThirdContact, time.Date(2024, 4, 8, 18, 44, 35, 0, time.UTC), time.Minute)
+
+	assertDatetime(t, got.FourthContact, time.Date(2024, 4, 8, 20, 2, 48, 0, time.UTC), time.Minute)
+
+	if !got.FirstContact.Before(got.SecondContact) || !got.SecondContact.Before(got.MaximumEclipse) ||
+		!got.MaximumEclipse.Before(got.ThirdContact) || !got.ThirdContact.Before(got.FourthContact) {
+		t.Errorf("got contacts out of order: %+v", got)
+	}
+
+	if got.Magnitude <= 1 || got.Obscuration != 1 {
+		t.Errorf("got %f, %f, wanted a magnitude greater than 1 and an obscuration of 1", got.Magnitude, got.Obscuration)
+	}
+
+	if got.Altitude < 60 || got.Altitude > 70 {
+		t.Errorf("got %f, wanted an altitude of ~65 degrees", got.Altitude)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetSolarEclipseLocalCircumstancesPartial(t *testing.T) {
+	eclipses := GetSolarEclipses(time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC))
+
+	// New York City, which saw a partial eclipse of around 90%:
+	got, ok := GetSolarEclipseLocalCircumstances(eclipses[0], common.GeographicCoordinate{
+		Latitude:  40.7128,
+		Longitude: -74.006,
+		Elevation: 10,
+	})
+
+	if !ok || got.Type != PartialSolarEclipse {
+		t.Fatalf("got %+v, wanted a partial eclipse", got)
+	}
+
+	if !got.SecondContact.IsZero() || !got.ThirdContact.IsZero() {
+		t.Errorf("got %v, %v, wanted no second or third contacts", got.SecondContact, got.ThirdContact)
+	}
+
+	assertDatetime(t, got.MaximumEclipse, time.Date(2024, 4, 8, 19, 25, 30, 0, time.UTC), 2*time.Minute)
+
+	if math.Abs(got.Obscuration-0.896) > 0.01 {
+		t.Errorf("got %f, wanted %f", got.Obscuration, 0.896)
+	}
+
+	if got.Magnitude <= got.Obscuration || got.Magnitude >= 1 {
+		t.Errorf("got %f, wanted a magnitude between the obscuration and 1", got.Magnitude)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetSolarEclipseLocalCircumstancesAnnular(t *testing.T) {
+	eclipses := GetSolarEclipses(time.Date(2023, 10, 1, 0, 0, 0, 0, time.UTC), time.Date(2023, 11, 1, 0, 0, 0, 0, time.UTC))
+
+	if len(eclipses) != 1 {
+		t.Fatalf("got %d eclipses, wanted 1", len(eclipses))
+	}
+
+	// San Antonio, Texas:
+	got, ok := GetSolarEclipseLocalCircumstances(eclipses[0], common.GeographicCoordinate{
+		Latitude:  29.4241,
+		Longitude: -98.4936,
+		Elevation: 200,
+	})
+
+	if !ok || got.Type != AnnularSolarEclipse {
+		t.Fatalf("got %+v, wanted an annular eclipse", got)
+	}
+
+	if got.Obscuration >= 1 || got.Obscuration < 0.85 {
+		t.Errorf("got %f, wanted an obscuration of ~0.9", got.Obscuration)
+	}
+
+	// annularity lasted a little over four minutes:
+	if d := got.ThirdContact.Sub(got.SecondContact); d < 3*time.Minute || d > 5*time.Minute {
+		t.Errorf("got %v, wanted ~4 minutes of annularity", d)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetSolarEclipseLocalCircumstancesNotVisible(t *testing.T) {
+	eclipses := GetSolarEclipses(time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC))
+
+	// Sydney, Australia, which was on the night side of the Earth:
+	if got, ok := GetSolarEclipseLocalCircumstances(eclipses[0], common.GeographicCoordinate{
+		Latitude:  -33.8688,
+		Longitude: 151.2093,
+		Elevation: 0,
+	}); ok {
+		t.Errorf("got %+v, wanted no eclipse", got)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetObscuration(t *testing.T) {
+	if got := getObscuration(disks{Separation: 1, Sun: 0.25, Moon: 0.25}); got != 0 {
+		t.Errorf("got %f, wanted 0", got)
+	}
+
+	if got := getObscuration(disks{Separation: 0, Sun: 0.25, Moon: 0.26}); got != 1 {
+		t.Errorf("got %f, wanted 1", got)
+	}
+
+	if got := getObscuration(disks{Separation: 0, Sun: 0.25, Moon: 0.2}); math.Abs(got-0.64) > 1e-12 {
+		t.Errorf("got %f, wanted 0.64", got)
+	}
+
+	// two equal disks, with the limb of each passing through the centre of the other:
+	if got, want := getObscuration(disks{Separation: 0.25, Sun: 0.25, Moon: 0.25}), 2.0/3-math.Sqrt(3)/(2*math.Pi); math.Abs(got-want) > 1e-12 {
+		t.Errorf("got %f, wanted %f", got, want)
+	}
+}
+
+/*****************************************************************************************************************/
@@ -11,7 +11,10 @@ package epoch
 /*****************************************************************************************************************/
 
 import (
+	"fmt"
 	"math"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/observerly/sidera/pkg/common"
@@ -73,6 +76,79 @@ const J2000 float64 = 2451545.0
 
 /*****************************************************************************************************************/
 
+/*
+the epoch of J1900.0 i.e., 31 December 1899 12:00:00 TT.
+
+The Julian epoch J1900.0 precedes J2000.0 by exactly one Julian century of 36,525 days, and is
+the reference epoch of many of the older analytical theories of the motions of the Sun, Moon
+and planets, e.g., Newcomb's theory of the Sun.
+*/
+const J1900 float64 = 2415020.0
+
+/*****************************************************************************************************************/
+
+/*
+the epoch of J2015.5 i.e., 2 July 2015 21:00:00 TT.
+
+The Julian epoch J2015.5 is the reference epoch of the Gaia Data Release 2 (DR2) catalogue, and is
+exactly 15.5 Julian years of 365.25 days after J2000.0.
+*/
+const J2015_5 float64 = 2457206.375
+
+/*****************************************************************************************************************/
+
+/*
+the epoch of J2016.0 i.e., 1 January 2016 12:00:00 TT.
+
+The Julian epoch J2016.0 is the reference epoch of the Gaia Early Data Release 3 (EDR3) and Data
+Release 3 (DR3) catalogues, and is exactly 16 Julian years of 365.25 days after J2000.0.
+*/
+const J2016 float64 = 2457389.0
+
+/*****************************************************************************************************************/
+
+/*
+the epoch of B1900.0 i.e., 31 December 1899 19:31:28 TT.
+
+Besselian epochs are measured in tropical years from the instant at which the mean longitude of the
+Sun, affected by aberration, was exactly 280°. They were in common use before the adoption of the
+IAU 1976 system of astronomical constants, and older catalogues still quote their positions in
+them, e.g., the Henry Draper catalogue.
+*/
+const B1900 float64 = 2415020.31352
+
+/*****************************************************************************************************************/
+
+/*
+the epoch of B1950.0 i.e., 31 December 1949 22:09:46.9 TT.
+
+The Besselian epoch B1950.0 is the reference epoch of the FK4 fundamental catalogue, and of many
+catalogues derived from it, e.g., the Smithsonian Astrophysical Observatory (SAO) star catalogue.
+*/
+const B1950 float64 = 2433282.4235
+
+/*****************************************************************************************************************/
+
+/*
+the length of the Julian year in days.
+
+The Julian year is a unit of time defined as exactly 365.25 days of 86,400 SI seconds each. It is
+the unit of time used to express Julian epochs, e.g., J2000.0, J2015.5 and J2016.0.
+*/
+const JULIAN_YEAR float64 = 365.25
+
+/*****************************************************************************************************************/
+
+/*
+the length of the Besselian (tropical) year in days.
+
+The Besselian year is the tropical year at B1900.0, as adopted by Bessel, and is the unit of time
+used to express Besselian epochs, e.g., B1900.0 and B1950.0.
+*/
+const BESSELIAN_YEAR float64 = 365.242198781
+
+/*****************************************************************************************************************/
+
 /*
 the Julian Date (JD) for a given date and time.
 
@@ -190,3 +266,225 @@ func GetLocalSiderealTime(datetime time.Time, observer common.GeographicCoordina
 }
 
 /*****************************************************************************************************************/
+
+/*
+the date and time for a given Julian Date (JD).
+
+The inverse of GetJulianDate, returning the UTC date and time which corresponds to the given Julian
+Date to a precision of one millisecond.
+*/
+func GetDatetimeFromJulianDate(JD float64) time.Time {
+	// milliseconds elapsed since 1 January 1970 00:00:00 UTC for the given Julian Date:
+	ms := math.Round((JD - J1970) * 86400000.0)
+
+	// return the UTC date and time:
+	return time.UnixMilli(int64(ms)).UTC()
+}
+
+/*****************************************************************************************************************/
+
+/*
+the Julian epoch for a given date and time.
+
+The Julian epoch expresses a date and time as a fractional year, counted in Julian years of exactly
+365.25 days from J2000.0, e.g., 1 January 2016 12:00:00 is J2016.0. Julian epochs are the standard
+way of expressing epochs since the adoption of the IAU 1976 system of astronomical constants.
+*/
+func GetJulianEpoch(datetime time.Time) float64 {
+	// get the Julian Date for the given datetime:
+	JD := GetJulianDate(datetime)
+
+	// return the Julian epoch:
+	return 2000.0 + (JD-J2000)/JULIAN_YEAR
+}
+
+/*****************************************************************************************************************/
+
+/*
+the Besselian epoch for a given date and time.
+
+The Besselian epoch expresses a date and time as a fractional year, counted in tropical years from
+B1900.0. Besselian epochs were in use before 1984, and positions in older catalogues, e.g., FK4 and
+the SAO star catalogue, are quoted at Besselian epochs such as B1950.0.
+*/
+func GetBesselianEpoch(datetime time.Time) float64 {
+	// get the Julian Date for the given datetime:
+	JD := GetJulianDate(datetime)
+
+	// return the Besselian epoch:
+	return 1900.0 + (JD-B1900)/BESSELIAN_YEAR
+}
+
+/*****************************************************************************************************************/
+
+/*
+the Julian Date (JD) for a given Julian epoch, e.g., 2016.0 for J2016.0.
+*/
+func GetJulianDateFromJulianEpoch(epoch float64) float64 {
+	return J2000 + (epoch-2000.0)*JULIAN_YEAR
+}
+
+/*****************************************************************************************************************/
+
+/*
+the Julian Date (JD) for a given Besselian epoch, e.g., 1950.0 for B1950.0.
+*/
+func GetJulianDateFromBesselianEpoch(epoch float64) float64 {
+	return B1900 + (epoch-1900.0)*BESSELIAN_YEAR
+}
+
+/*****************************************************************************************************************/
+
+/*
+the Julian Date (JD) for a given epoch string, e.g., "J2016.0", "J2015.5" or "B1950".
+
+An epoch string is a fractional year prefixed by "J" for a Julian epoch, or "B" for a Besselian
+epoch. Where the prefix is omitted, the IAU convention is followed: epochs before 1984.0 are taken
+to be Besselian, and epochs from 1984.0 onwards are taken to be Julian.
+*/
+func ParseEpoch(value string) (float64, error) {
+	// normalise the epoch string, e.g., " j2016.0 " becomes "J2016.0":
+	s := strings.ToUpper(strings.TrimSpace(value))
+
+	if s == "" {
+		return math.NaN(), fmt.Errorf("invalid epoch: %q", value)
+	}
+
+	// determine the epoch system from the prefix, if any:
+	system := s[0]
+
+	if system == 'J' || system == 'B' {
+		s = s[1:]
+	}
+
+	year, err := strconv.ParseFloat(s, 64)
+
+	if err != nil || math.IsNaN(year) || math.IsInf(year, 0) {
+		return math.NaN(), fmt.Errorf("invalid epoch: %q", value)
+	}
+
+	switch system {
+	case 'J':
+		return GetJulianDateFromJulianEpoch(year), nil
+	case 'B':
+		return GetJulianDateFromBesselianEpoch(year), nil
+	}
+
+	// epochs without a prefix are Besselian before 1984.0, and Julian thereafter:
+	if year < 1984.0 {
+		return GetJulianDateFromBesselianEpoch(year), nil
+	}
+
+	return GetJulianDateFromJulianEpoch(year), nil
+}
+
+/*****************************************************************************************************************/
+
+/*
+the leap second table, i.e., the dates from which TAI-UTC took each successive value in seconds.
+
+Since 1 January 1972, UTC has been kept within 0.9 seconds of UT1 by the insertion of leap seconds,
+as announced by the International Earth Rotation and Reference Systems Service (IERS).
+*/
+var leapSeconds = []struct {
+	datetime time.Time
+	seconds  float64
+}{
+	{time.Date(1972, 1, 1, 0, 0, 0, 0, time.UTC), 10},
+	{time.Date(1972, 7, 1, 0, 0, 0, 0, time.UTC), 11},
+	{time.Date(1973, 1, 1, 0, 0, 0, 0, time.UTC), 12},
+	{time.Date(1974, 1, 1, 0, 0, 0, 0, time.UTC), 13},
+	{time.Date(1975, 1, 1, 0, 0, 0, 0, time.UTC), 14},
+	{time.Date(1976, 1, 1, 0, 0, 0, 0, time.UTC), 15},
+	{time.Date(1977, 1, 1, 0, 0, 0, 0, time.UTC), 16},
+	{time.Date(1978, 1, 1, 0, 0, 0, 0, time.UTC), 17},
+	{time.Date(1979, 1, 1, 0, 0, 0, 0, time.UTC), 18},
+	{time.Date(1980, 1, 1, 0, 0, 0, 0, time.UTC), 19},
+	{time.Date(1981, 7, 1, 0, 0, 0, 0, time.UTC), 20},
+	{time.Date(1982, 7, 1, 0, 0, 0, 0, time.UTC), 21},
+	{time.Date(1983, 7, 1, 0, 0, 0, 0, time.UTC), 22},
+	{time.Date(1985, 7, 1, 0, 0, 0, 0, time.UTC), 23},
+	{time.Date(1988, 1, 1, 0, 0, 0, 0, time.UTC), 24},
+	{time.Date(1990, 1, 1, 0, 0, 0, 0, time.UTC), 25},
+	{time.Date(1991, 1, 1, 0, 0, 0, 0, time.UTC), 26},
+	{time.Date(1992, 7, 1, 0, 0, 0, 0, time.UTC), 27},
+	{time.Date(1993, 7, 1, 0, 0, 0, 0, time.UTC), 28},
+	{time.Date(1994, 7, 1, 0, 0, 0, 0, time.UTC), 29},
+	{time.Date(1996, 1, 1, 0, 0, 0, 0, time.UTC), 30},
+	{time.Date(1997, 7, 1, 0, 0, 0, 0, time.UTC), 31},
+	{time.Date(1999, 1, 1, 0, 0, 0, 0, time.UTC), 32},
+	{time.Date(2006, 1, 1, 0, 0, 0, 0, time.UTC), 33},
+	{time.Date(2009, 1, 1, 0, 0, 0, 0, time.UTC), 34},
+	{time.Date(2012, 7, 1, 0, 0, 0, 0, time.UTC), 35},
+	{time.Date(2015, 7, 1, 0, 0, 0, 0, time.UTC), 36},
+	{time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC), 37},
+}
+
+/*****************************************************************************************************************/
+
+/*
+the number of leap seconds, i.e., TAI-UTC in seconds, for a given date and time.
+
+International Atomic Time (TAI) is a continuous time scale, whereas Coordinated Universal Time (UTC)
+is kept close to the rotation of the Earth by the occasional insertion of leap seconds. Dates before
+the introduction of leap seconds in 1972 are given the initial value of 10 seconds.
+*/
+func GetLeapSeconds(datetime time.Time) float64 {
+	// the initial offset between TAI and UTC at the introduction of leap seconds:
+	seconds := leapSeconds[0].seconds
+
+	for _, leap := range leapSeconds {
+		if datetime.UTC().Before(leap.datetime) {
+			break
+		}
+
+		seconds = leap.seconds
+	}
+
+	return seconds
+}
+
+/*****************************************************************************************************************/
+
+/*
+the Julian Date (JD) in Terrestrial Time (TT) for a given date and time.
+
+Terrestrial Time (TT) is the uniform time scale used for geocentric ephemerides. It runs ahead of
+International Atomic Time (TAI) by exactly 32.184 seconds, such that TT = UTC + (TAI-UTC) + 32.184s.
+*/
+func GetTerrestrialTimeJulianDate(datetime time.Time) float64 {
+	// get the Julian Date for the given datetime:
+	JD := GetJulianDate(datetime)
+
+	// TT-UTC in seconds, i.e., the number of leap seconds plus the fixed TT-TAI offset:
+	ΔT := GetLeapSeconds(datetime) + 32.184
+
+	// return the Julian Date in Terrestrial Time:
+	return JD + ΔT/86400.0
+}
+
+/*****************************************************************************************************************/
+
+/*
+the Julian Date (JD) in Barycentric Dynamical Time (TDB) for a given date and time.
+
+Barycentric Dynamical Time (TDB) is the time scale of ephemerides referred to the barycentre of the
+solar system. It differs from Terrestrial Time (TT) by periodic terms, the largest of which has an
+amplitude of 1.657 milliseconds and a period of one anomalistic year, arising from the eccentricity
+of the Earth's orbit.
+*/
+func GetBarycentricDynamicalTimeJulianDate(datetime time.Time) float64 {
+	// get the Julian Date in Terrestrial Time:
+	JD := GetTerrestrialTimeJulianDate(datetime)
+
+	// the mean anomaly of the Earth, in radians:
+	g := common.Radians(357.53 + 0.98560028*(JD-J2000))
+
+	// TDB-TT in seconds:
+	Δ := 0.001657*math.Sin(g) + 0.000014*math.Sin(2*g)
+
+	// return the Julian Date in Barycentric Dynamical Time:
+	return JD + Δ/86400.0
+}
+
+/*****************************************************************************************************************/
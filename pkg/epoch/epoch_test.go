@@ -103,3 +103,147 @@ func TestLocalSiderealTime(t *testing.T) {
 }
 
 /*****************************************************************************************************************/
+
+func TestGetJ2016(t *testing.T) {
+	// Test the Julian Date for the J2016.0 epoch is exactly 16 Julian years after J2000.0:
+	assert.Equal(t, J2016, J2000+16*JULIAN_YEAR)
+}
+
+/*****************************************************************************************************************/
+
+func TestGetJ2015_5(t *testing.T) {
+	// Test the Julian Date for the J2015.5 epoch is exactly 15.5 Julian years after J2000.0:
+	assert.Equal(t, J2015_5, J2000+15.5*JULIAN_YEAR)
+}
+
+/*****************************************************************************************************************/
+
+func TestGetDatetimeFromJulianDate(t *testing.T) {
+	var got time.Time = GetDatetimeFromJulianDate(2459348.5)
+
+	var want time.Time = time.Date(2021, 5, 14, 0, 0, 0, 0, time.UTC)
+
+	if !got.Equal(want) {
+		t.Errorf("got %q, wanted %q", got, want)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetJulianEpoch(t *testing.T) {
+	var got float64 = GetJulianEpoch(time.Date(2000, 1, 1, 12, 0, 0, 0, time.UTC))
+
+	var want float64 = 2000.0
+
+	if math.Abs(got-want) > 0.00001 {
+		t.Errorf("got %f, wanted %f", got, want)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetBesselianEpoch(t *testing.T) {
+	var got float64 = GetBesselianEpoch(GetDatetimeFromJulianDate(B1950))
+
+	var want float64 = 1950.0
+
+	if math.Abs(got-want) > 0.00001 {
+		t.Errorf("got %f, wanted %f", got, want)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetJulianDateFromJulianEpoch(t *testing.T) {
+	var got float64 = GetJulianDateFromJulianEpoch(2016.0)
+
+	if math.Abs(got-J2016) > 0.00001 {
+		t.Errorf("got %f, wanted %f", got, J2016)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetJulianDateFromBesselianEpoch(t *testing.T) {
+	var got float64 = GetJulianDateFromBesselianEpoch(1950.0)
+
+	if math.Abs(got-B1950) > 0.0001 {
+		t.Errorf("got %f, wanted %f", got, B1950)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestParseEpoch(t *testing.T) {
+	tests := map[string]float64{
+		"J2000":    J2000,
+		"J2016.0":  J2016,
+		"j2015.5":  J2015_5,
+		"B1950":    B1950,
+		"B1900.0":  B1900,
+		" 1950.0 ": B1950,
+		"2016.0":   J2016,
+	}
+
+	for value, want := range tests {
+		got, err := ParseEpoch(value)
+
+		if err != nil {
+			t.Errorf("got error %v for %q", err, value)
+		}
+
+		if math.Abs(got-want) > 0.0001 {
+			t.Errorf("got %f, wanted %f for %q", got, want, value)
+		}
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestParseEpochInvalid(t *testing.T) {
+	for _, value := range []string{"", "J", "X2000", "J20x6", "BNaN"} {
+		if _, err := ParseEpoch(value); err == nil {
+			t.Errorf("expected an error for %q", value)
+		}
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetLeapSeconds(t *testing.T) {
+	assert.Equal(t, GetLeapSeconds(time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC)), 10.0)
+
+	assert.Equal(t, GetLeapSeconds(time.Date(2016, 12, 31, 23, 59, 59, 0, time.UTC)), 36.0)
+
+	assert.Equal(t, GetLeapSeconds(time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC)), 37.0)
+
+	assert.Equal(t, GetLeapSeconds(datetime), 37.0)
+}
+
+/*****************************************************************************************************************/
+
+func TestGetTerrestrialTimeJulianDate(t *testing.T) {
+	var got float64 = GetTerrestrialTimeJulianDate(datetime)
+
+	// TT-UTC is 69.184 seconds in 2021:
+	var want float64 = 2459348.5 + 69.184/86400.0
+
+	if math.Abs(got-want) > 0.0000001 {
+		t.Errorf("got %f, wanted %f", got, want)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetBarycentricDynamicalTimeJulianDate(t *testing.T) {
+	var got float64 = GetBarycentricDynamicalTimeJulianDate(datetime)
+
+	var want float64 = GetTerrestrialTimeJulianDate(datetime)
+
+	// TDB-TT is never more than about 2 milliseconds:
+	if math.Abs(got-want)*86400 > 0.002 {
+		t.Errorf("got %f, wanted %f", got, want)
+	}
+}
+
+/*****************************************************************************************************************/
@@ -0,0 +1,376 @@
+/*****************************************************************************************************************/
+
+//	@author		Michael Roberts <michael@observerly.com>
+//	@package	@observerly/sidera
+//	@license	Copyright © 2021-2024 observerly
+
+/*****************************************************************************************************************/
+
+package events
+
+/*****************************************************************************************************************/
+
+import (
+	"math"
+	"time"
+
+	"github.com/observerly/sidera/pkg/astrometry"
+	"github.com/observerly/sidera/pkg/common"
+	"github.com/observerly/sidera/pkg/epoch"
+	moon "github.com/observerly/sidera/pkg/lunar"
+	"github.com/observerly/sidera/pkg/planets"
+	sun "github.com/observerly/sidera/pkg/solar"
+)
+
+/*****************************************************************************************************************/
+
+type EventType int
+
+/*****************************************************************************************************************/
+
+const (
+	// two bodies share the same geocentric ecliptic longitude:
+	Conjunction EventType = iota
+	// a body is opposite the Sun in geocentric ecliptic longitude:
+	Opposition
+	// a body is at its greatest angular distance east of the Sun, i.e., in the evening sky:
+	GreatestEasternElongation
+	// a body is at its greatest angular distance west of the Sun, i.e., in the morning sky:
+	GreatestWesternElongation
+	// a body is stationary in ecliptic longitude, and is about to begin retrograde (westward) motion:
+	StationaryRetrograde
+	// a body is stationary in ecliptic longitude, and is about to resume direct (eastward) motion:
+	StationaryDirect
+)
+
+/*****************************************************************************************************************/
+
+/*
+a body for which events may be found, i.e., the Sun, the Moon or one of the major planets
+
+The ecliptic coordinate of the body is its geocentric position referred to the mean ecliptic and equinox
+of date.
+*/
+type Body struct {
+	Name                  string
+	GetEclipticCoordinate func(datetime time.Time) common.EclipticCoordinate
+}
+
+/*****************************************************************************************************************/
+
+/*
+an event found within a date range
+
+The angle depends upon the type of the event: for a conjunction it is the angular separation between the
+two bodies, for an opposition or greatest elongation it is the elongation of the body from the Sun, and for
+a stationary point it is the ecliptic longitude of the body, all in degrees.
+*/
+type Event struct {
+	Type     EventType
+	Datetime time.Time
+	Bodies   []string
+	Angle    float64
+}
+
+/*****************************************************************************************************************/
+
+var Sun = Body{
+	Name: "Sun",
+	GetEclipticCoordinate: func(datetime time.Time) common.EclipticCoordinate {
+		return common.EclipticCoordinate{
+			Longitude: sun.GetTrueEclipticLongitude(datetime),
+			Latitude:  0,
+		}
+	},
+}
+
+/*****************************************************************************************************************/
+
+var Moon = Body{
+	Name:                  "Moon",
+	GetEclipticCoordinate: moon.GetEclipticCoordinate,
+}
+
+/*****************************************************************************************************************/
+
+var Mercury = getPlanetBody(planets.Mercury)
+
+var Venus = getPlanetBody(planets.Venus)
+
+var Mars = getPlanetBody(planets.Mars)
+
+var Jupiter = getPlanetBody(planets.Jupiter)
+
+var Saturn = getPlanetBody(planets.Saturn)
+
+var Uranus = getPlanetBody(planets.Uranus)
+
+var Neptune = getPlanetBody(planets.Neptune)
+
+/*****************************************************************************************************************/
+
+/*
+the body for a major planet, using its geocentric ecliptic coordinate corrected for light travel time
+*/
+func getPlanetBody(planet planets.Planet) Body {
+	return Body{
+		Name: planet.Name,
+		GetEclipticCoordinate: func(datetime time.Time) common.EclipticCoordinate {
+			ec, _ := planets.GetGeocentricEclipticCoordinate(datetime, planet)
+			return ec
+		},
+	}
+}
+
+/*****************************************************************************************************************/
+
+/*
+the ecliptic coordinate of a body at a given Julian Date
+*/
+func getEclipticCoordinate(JD float64, body Body) common.EclipticCoordinate {
+	return body.GetEclipticCoordinate(epoch.GetDatetimeFromJulianDate(JD))
+}
+
+/*****************************************************************************************************************/
+
+/*
+the angular separation between two ecliptic coordinates, in degrees
+*/
+func getAngularSeparation(a common.EclipticCoordinate, b common.EclipticCoordinate) float64 {
+	// the angular separation is independent of the coordinate system, so long as both positions share it:
+	return astrometry.GetAngularSeparation(
+		common.EquatorialCoordinate{RightAscension: a.Longitude, Declination: a.Latitude},
+		common.EquatorialCoordinate{RightAscension: b.Longitude, Declination: b.Latitude},
+	)
+}
+
+/*****************************************************************************************************************/
+
+/*
+whether a body is an inferior planet, i.e., Mercury or Venus, whose orbit lies within that of the Earth, so that
+its elongation from the Sun is never more than around 28 and 47 degrees respectively
+*/
+func isInferior(body Body) bool {
+	return body.Name == Mercury.Name || body.Name == Venus.Name
+}
+
+/*****************************************************************************************************************/
+
+/*
+the scan step in days for a set of bodies, which must be short enough that no two roots are bracketed by
+a single step: the Moon moves by around 13 degrees per day, and so requires a much finer step than the
+planets.
+*/
+func getStep(bodies ...Body) float64 {
+	for _, body := range bodies {
+		if body.Name == Moon.Name {
+			return 0.25
+		}
+	}
+
+	return 1
+}
+
+/*****************************************************************************************************************/
+
+/*
+finds the roots of a function of the Julian Date between start and end, by scanning for changes of sign in
+steps of the given size and refining each by bisection to a precision of around 0.1 seconds
+
+Changes of sign where the function is greater than 90 in magnitude on either side are rejected, as these
+arise from the wrapping of an angle from +180 to -180 degrees rather than from a true root. The returned
+sign is that of the function before the root.
+*/
+func findRoots(start float64, end float64, step float64, f func(JD float64) float64) ([]float64, []float64) {
+	roots := []float64{}
+
+	signs := []float64{}
+
+	a := start
+
+	fa := f(a)
+
+	for a < end {
+		b := math.Min(a+step, end)
+
+		fb := f(b)
+
+		if fa*fb < 0 && math.Abs(fa) < 90 && math.Abs(fb) < 90 {
+			lo, hi, flo := a, b, fa
+
+			for hi-lo > 1e-6 {
+				mid := (lo + hi) / 2
+
+				fmid := f(mid)
+
+				if flo*fmid <= 0 {
+					hi = mid
+				} else {
+					lo, flo = mid, fmid
+				}
+			}
+
+			roots = append(roots, (lo+hi)/2)
+
+			signs = append(signs, math.Copysign(1, fa))
+		}
+
+		a, fa = b, fb
+	}
+
+	return roots, signs
+}
+
+/*****************************************************************************************************************/
+
+/*
+finds the conjunctions in ecliptic longitude between two bodies within a date range
+
+A conjunction occurs when two bodies share the same geocentric ecliptic longitude. The angle of each event
+is the angular separation between the two bodies at the instant of conjunction. The conjunctions of the
+Moon and the Sun are the new moons, and the conjunctions of the inferior planets and the Sun are their
+inferior and superior conjunctions.
+*/
+func GetConjunctions(start time.Time, end time.Time, a Body, b Body) []Event {
+	events := []Event{}
+
+	roots, _ := findRoots(epoch.GetJulianDate(start), epoch.GetJulianDate(end), getStep(a, b), func(JD float64) float64 {
+		return math.Remainder(getEclipticCoordinate(JD, a).Longitude-getEclipticCoordinate(JD, b).Longitude, 360)
+	})
+
+	for _, JD := range roots {
+		events = append(events, Event{
+			Type:     Conjunction,
+			Datetime: epoch.GetDatetimeFromJulianDate(JD),
+			Bodies:   []string{a.Name, b.Name},
+			Angle:    getAngularSeparation(getEclipticCoordinate(JD, a), getEclipticCoordinate(JD, b)),
+		})
+	}
+
+	return events
+}
+
+/*****************************************************************************************************************/
+
+/*
+finds the oppositions of a body within a date range
+
+An opposition occurs when the geocentric ecliptic longitude of a body differs from that of the Sun by 180
+degrees, i.e., when the body is opposite the Sun in the sky and is visible all night long. Only the Moon and
+the superior planets, Mars to Neptune, can come to opposition. The angle of each event is the elongation of
+the body from the Sun.
+*/
+func GetOppositions(start time.Time, end time.Time, body Body) []Event {
+	events := []Event{}
+
+	roots, _ := findRoots(epoch.GetJulianDate(start), epoch.GetJulianDate(end), getStep(body), func(JD float64) float64 {
+		return math.Remainder(getEclipticCoordinate(JD, body).Longitude-getEclipticCoordinate(JD, Sun).Longitude-180, 360)
+	})
+
+	for _, JD := range roots {
+		events = append(events, Event{
+			Type:     Opposition,
+			Datetime: epoch.GetDatetimeFromJulianDate(JD),
+			Bodies:   []string{body.Name},
+			Angle:    getAngularSeparation(getEclipticCoordinate(JD, body), getEclipticCoordinate(JD, Sun)),
+		})
+	}
+
+	return events
+}
+
+/*****************************************************************************************************************/
+
+/*
+finds the greatest eastern and western elongations of a body within a date range
+
+The elongation of an inferior planet, Mercury or Venus, from the Sun reaches a maximum (greatest eastern
+elongation) when the planet is best placed in the evening sky, and a maximum (greatest western elongation)
+when it is best placed in the morning sky. Greatest elongations are found as the roots of the rate of change
+of the elongation, at which the rate changes from increasing to decreasing. The angle of each event is the
+elongation of the body from the Sun.
+
+Only the inferior planets have greatest elongations: the elongation of any other body, e.g., a superior planet
+or the Moon, reaches its maximum at opposition, and so no events are returned for it.
+*/
+func GetGreatestElongations(start time.Time, end time.Time, body Body) []Event {
+	events := []Event{}
+
+	if !isInferior(body) {
+		return events
+	}
+
+	// the interval over which the rate of change of the elongation is found, in days:
+	h := 1.0 / 24
+
+	elongation := func(JD float64) float64 {
+		return getAngularSeparation(getEclipticCoordinate(JD, body), getEclipticCoordinate(JD, Sun))
+	}
+
+	roots, signs := findRoots(epoch.GetJulianDate(start), epoch.GetJulianDate(end), getStep(body), func(JD float64) float64 {
+		return (elongation(JD+h) - elongation(JD-h)) / (2 * h)
+	})
+
+	for i, JD := range roots {
+		// a root at which the elongation changes from decreasing to increasing is a minimum, not a maximum:
+		if signs[i] < 0 {
+			continue
+		}
+
+		event := Event{
+			Type:     GreatestWesternElongation,
+			Datetime: epoch.GetDatetimeFromJulianDate(JD),
+			Bodies:   []string{body.Name},
+			Angle:    elongation(JD),
+		}
+
+		// the body is east of the Sun when its ecliptic longitude is greater than that of the Sun:
+		if math.Remainder(getEclipticCoordinate(JD, body).Longitude-getEclipticCoordinate(JD, Sun).Longitude, 360) > 0 {
+			event.Type = GreatestEasternElongation
+		}
+
+		events = append(events, event)
+	}
+
+	return events
+}
+
+/*****************************************************************************************************************/
+
+/*
+finds the stationary points of a body within a date range
+
+A planet is stationary when its geocentric ecliptic longitude momentarily ceases to change, at the beginning
+and end of each period of apparent retrograde (westward) motion. Stationary points are found as the roots of
+the rate of change of the ecliptic longitude. The angle of each event is the ecliptic longitude of the body.
+*/
+func GetStationaryPoints(start time.Time, end time.Time, body Body) []Event {
+	events := []Event{}
+
+	// the interval over which the rate of change of the ecliptic longitude is found, in days:
+	h := 1.0 / 24
+
+	roots, signs := findRoots(epoch.GetJulianDate(start), epoch.GetJulianDate(end), getStep(body), func(JD float64) float64 {
+		return math.Remainder(getEclipticCoordinate(JD+h, body).Longitude-getEclipticCoordinate(JD-h, body).Longitude, 360) / (2 * h)
+	})
+
+	for i, JD := range roots {
+		event := Event{
+			Type:     StationaryDirect,
+			Datetime: epoch.GetDatetimeFromJulianDate(JD),
+			Bodies:   []string{body.Name},
+			Angle:    getEclipticCoordinate(JD, body).Longitude,
+		}
+
+		// the body is about to begin retrograde motion when its longitude was previously increasing:
+		if signs[i] > 0 {
+			event.Type = StationaryRetrograde
+		}
+
+		events = append(events, event)
+	}
+
+	return events
+}
+
+/*****************************************************************************************************************/
@@ -0,0 +1,203 @@
+/*****************************************************************************************************************/
+
+//	@author		Michael Roberts <michael@observerly.com>
+//	@package	@observerly/sidera
+//	@license	Copyright © 2021-2024 observerly
+
+/*****************************************************************************************************************/
+
+package events
+
+/*****************************************************************************************************************/
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+/*****************************************************************************************************************/
+
+func TestGetConjunctionsOfJupiterAndSaturn(t *testing.T) {
+	// the great conjunction of Jupiter and Saturn of 21 December 2020:
+	events := GetConjunctions(
+		time.Date(2020, 12, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+		Jupiter,
+		Saturn,
+	)
+
+	if len(events) != 1 {
+		t.Fatalf("got %d conjunctions, wanted 1", len(events))
+	}
+
+	want := time.Date(2020, 12, 21, 18, 0, 0, 0, time.UTC)
+
+	if math.Abs(events[0].Datetime.Sub(want).Hours()) > 24 {
+		t.Errorf("got %q, wanted %q", events[0].Datetime, want)
+	}
+
+	if events[0].Type != Conjunction {
+		t.Errorf("got %d, wanted a conjunction", events[0].Type)
+	}
+
+	if math.Abs(events[0].Angle-0.1) > 0.05 {
+		t.Errorf("got %f, wanted a separation of around 0.1 degrees", events[0].Angle)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetConjunctionsOfTheMoonAndTheSun(t *testing.T) {
+	// the new moon of 8 April 2024, at the time of the total solar eclipse:
+	events := GetConjunctions(
+		time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 4, 15, 0, 0, 0, 0, time.UTC),
+		Moon,
+		Sun,
+	)
+
+	if len(events) != 1 {
+		t.Fatalf("got %d conjunctions, wanted 1", len(events))
+	}
+
+	want := time.Date(2024, 4, 8, 18, 21, 0, 0, time.UTC)
+
+	if math.Abs(events[0].Datetime.Sub(want).Minutes()) > 5 {
+		t.Errorf("got %q, wanted %q", events[0].Datetime, want)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetOppositions(t *testing.T) {
+	// the opposition of Jupiter of 3 November 2023:
+	events := GetOppositions(
+		time.Date(2023, 10, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2023, 12, 1, 0, 0, 0, 0, time.UTC),
+		Jupiter,
+	)
+
+	if len(events) != 1 {
+		t.Fatalf("got %d oppositions, wanted 1", len(events))
+	}
+
+	want := time.Date(2023, 11, 3, 5, 0, 0, 0, time.UTC)
+
+	if math.Abs(events[0].Datetime.Sub(want).Hours()) > 12 {
+		t.Errorf("got %q, wanted %q", events[0].Datetime, want)
+	}
+
+	if events[0].Type != Opposition {
+		t.Errorf("got %d, wanted an opposition", events[0].Type)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetGreatestElongationsOfVenus(t *testing.T) {
+	events := GetGreatestElongations(
+		time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		Venus,
+	)
+
+	if len(events) != 2 {
+		t.Fatalf("got %d greatest elongations, wanted 2", len(events))
+	}
+
+	tests := []struct {
+		Type       EventType
+		datetime   time.Time
+		elongation float64
+	}{
+		{GreatestEasternElongation, time.Date(2023, 6, 4, 11, 0, 0, 0, time.UTC), 45.4},
+		{GreatestWesternElongation, time.Date(2023, 10, 23, 20, 0, 0, 0, time.UTC), 46.4},
+	}
+
+	for i, test := range tests {
+		if events[i].Type != test.Type {
+			t.Errorf("got %d, wanted %d", events[i].Type, test.Type)
+		}
+
+		if math.Abs(events[i].Datetime.Sub(test.datetime).Hours()) > 24 {
+			t.Errorf("got %q, wanted %q", events[i].Datetime, test.datetime)
+		}
+
+		if math.Abs(events[i].Angle-test.elongation) > 0.1 {
+			t.Errorf("got %f, wanted %f", events[i].Angle, test.elongation)
+		}
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetGreatestElongationsOfMercury(t *testing.T) {
+	events := GetGreatestElongations(
+		time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+		Mercury,
+	)
+
+	if len(events) != 2 {
+		t.Fatalf("got %d greatest elongations, wanted 2", len(events))
+	}
+
+	if events[0].Type != GreatestWesternElongation || math.Abs(events[0].Angle-23.5) > 0.1 {
+		t.Errorf("got %d (%f), wanted a greatest western elongation of 23.5 degrees", events[0].Type, events[0].Angle)
+	}
+
+	if events[1].Type != GreatestEasternElongation || math.Abs(events[1].Angle-18.7) > 0.1 {
+		t.Errorf("got %d (%f), wanted a greatest eastern elongation of 18.7 degrees", events[1].Type, events[1].Angle)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetGreatestElongationsOfSuperiorPlanets(t *testing.T) {
+	// Mars was at opposition on 16 January 2025, which is the maximum of its elongation, not a greatest elongation:
+	for _, body := range []Body{Mars, Jupiter, Moon} {
+		events := GetGreatestElongations(
+			time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC),
+			time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC),
+			body,
+		)
+
+		if len(events) != 0 {
+			t.Errorf("%s: got %d greatest elongations, wanted none", body.Name, len(events))
+		}
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetStationaryPoints(t *testing.T) {
+	// Mars was retrograde from 7 December 2024 until 24 February 2025:
+	events := GetStationaryPoints(
+		time.Date(2024, 10, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC),
+		Mars,
+	)
+
+	if len(events) != 2 {
+		t.Fatalf("got %d stationary points, wanted 2", len(events))
+	}
+
+	if events[0].Type != StationaryRetrograde {
+		t.Errorf("got %d, wanted the beginning of retrograde motion", events[0].Type)
+	}
+
+	if want := time.Date(2024, 12, 7, 0, 0, 0, 0, time.UTC); math.Abs(events[0].Datetime.Sub(want).Hours()) > 48 {
+		t.Errorf("got %q, wanted %q", events[0].Datetime, want)
+	}
+
+	if events[1].Type != StationaryDirect {
+		t.Errorf("got %d, wanted the resumption of direct motion", events[1].Type)
+	}
+
+	if want := time.Date(2025, 2, 24, 0, 0, 0, 0, time.UTC); math.Abs(events[1].Datetime.Sub(want).Hours()) > 48 {
+		t.Errorf("got %q, wanted %q", events[1].Datetime, want)
+	}
+}
+
+/*****************************************************************************************************************/
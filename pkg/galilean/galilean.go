@@ -0,0 +1,338 @@
+/*****************************************************************************************************************/
+
+//	@author		Michael Roberts <michael@observerly.com>
+//	@package	@observerly/sidera
+//	@license	Copyright © 2021-2024 observerly
+
+/*****************************************************************************************************************/
+
+package galilean
+
+/*****************************************************************************************************************/
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/observerly/sidera/pkg/common"
+	"github.com/observerly/sidera/pkg/epoch"
+)
+
+/*****************************************************************************************************************/
+
+// the flattening of Jupiter, i.e., one minus the ratio of its polar to its equatorial radius:
+const JUPITER_FLATTENING float64 = 0.06487
+
+/*****************************************************************************************************************/
+
+type Satellite int
+
+/*****************************************************************************************************************/
+
+const (
+	// Jupiter I:
+	Io Satellite = iota
+	// Jupiter II:
+	Europa
+	// Jupiter III:
+	Ganymede
+	// Jupiter IV:
+	Callisto
+)
+
+/*****************************************************************************************************************/
+
+// the Galilean satellites of Jupiter, in order of increasing distance from the planet:
+var Satellites = []Satellite{Io, Europa, Ganymede, Callisto}
+
+/*****************************************************************************************************************/
+
+type EventType int
+
+/*****************************************************************************************************************/
+
+const (
+	// the satellite passes in front of the disk of Jupiter:
+	Transit EventType = iota
+	// the shadow of the satellite falls upon the disk of Jupiter:
+	ShadowTransit
+	// the satellite passes behind the disk of Jupiter:
+	Occultation
+	// the satellite passes through the shadow of Jupiter:
+	Eclipse
+)
+
+/*****************************************************************************************************************/
+
+/*
+a transit, shadow transit, occultation or eclipse of a Galilean satellite
+
+The beginning and end of the event are the instants at which the centre of the satellite (or of its shadow)
+crosses the limb of Jupiter (or the edge of its shadow), as seen from the Earth, i.e., including light time.
+*/
+type Event struct {
+	Type      EventType
+	Satellite Satellite
+	Begin     time.Time
+	End       time.Time
+}
+
+/*****************************************************************************************************************/
+
+/*
+the rectangular positions of the Galilean satellites relative to Jupiter, in equatorial radii of Jupiter, as seen
+from the Earth and from the Sun
+
+The X axis is positive towards the west, along Jupiter's equator, the Y axis is positive towards Jupiter's north
+pole, and the Z axis is positive away from the viewpoint, i.e., a satellite with a positive Z is behind Jupiter.
+
+See Meeus, "Astronomical Algorithms", Chapter 44, "Positions of the Satellites of Jupiter", lower accuracy method,
+which is sufficient to predict the events of the satellites to within a few minutes of time.
+*/
+func getConfiguration(datetime time.Time) (geocentric [4]common.CartesianCoordinate, heliocentric [4]common.CartesianCoordinate) {
+	// the number of days since J2000.0, in Terrestrial Time:
+	d := epoch.GetTerrestrialTimeJulianDate(datetime) - epoch.J2000
+
+	// the argument of the long-period term in the motion of Jupiter:
+	V := common.Radians(172.74 + 0.00111588*d)
+
+	// the mean anomalies of the Earth and Jupiter:
+	M := common.Radians(357.529 + 0.9856003*d)
+
+	N := common.Radians(20.020 + 0.0830853*d + 0.329*math.Sin(V))
+
+	// the difference between the mean heliocentric longitudes of the Earth and Jupiter:
+	J := 66.115 + 0.9025179*d - 0.329*math.Sin(V)
+
+	// the equations of centre of the Earth and Jupiter:
+	A := 1.915*math.Sin(M) + 0.020*math.Sin(2*M)
+
+	B := 5.555*math.Sin(N) + 0.168*math.Sin(2*N)
+
+	K := common.Radians(J + A - B)
+
+	// the radius vectors of the Earth and Jupiter, in astronomical units:
+	R := 1.00014 - 0.01671*math.Cos(M) - 0.00014*math.Cos(2*M)
+
+	r := 5.20872 - 0.25208*math.Cos(N) - 0.00611*math.Cos(2*N)
+
+	// the distance of Jupiter from the Earth, in astronomical units:
+	Δ := math.Sqrt(math.Pow(r, 2) + math.Pow(R, 2) - 2*r*R*math.Cos(K))
+
+	// the phase angle of Jupiter, i.e., the angle between the Sun and the Earth as seen from Jupiter:
+	ψ := common.Degrees(math.Asin(R / Δ * math.Sin(K)))
+
+	// the number of days since J2000.0, corrected for the light time from Jupiter:
+	t := d - Δ/173
+
+	// the angles of the satellites from the superior geocentric conjunction:
+	u := [4]float64{
+		163.8069 + 203.4058646*t + ψ - B,
+		358.4140 + 101.2916335*t + ψ - B,
+		5.7176 + 50.2345180*t + ψ - B,
+		224.8092 + 21.4879800*t + ψ - B,
+	}
+
+	G := common.Radians(331.18 + 50.310482*t)
+
+	H := common.Radians(87.45 + 21.569231*t)
+
+	// the mutual perturbations of the satellites:
+	e12 := common.Radians(2 * (u[0] - u[1]))
+
+	e23 := common.Radians(2 * (u[1] - u[2]))
+
+	u[0] += 0.473 * math.Sin(e12)
+	u[1] += 1.065 * math.Sin(e23)
+	u[2] += 0.165 * math.Sin(G)
+	u[3] += 0.843 * math.Sin(H)
+
+	// the distances of the satellites from the centre of Jupiter, in equatorial radii of Jupiter:
+	ρ := [4]float64{
+		5.9057 - 0.0244*math.Cos(e12),
+		9.3966 - 0.0882*math.Cos(e23),
+		14.9883 - 0.0216*math.Cos(G),
+		26.3627 - 0.1939*math.Cos(H),
+	}
+
+	// the heliocentric longitude of Jupiter:
+	λ := 34.35 + 0.083091*d + 0.329*math.Sin(V) + B
+
+	// the planetocentric declinations of the Sun and the Earth:
+	Ds := 3.12 * math.Sin(common.Radians(λ+42.8))
+
+	De := Ds - 2.22*math.Sin(common.Radians(ψ))*math.Cos(common.Radians(λ+22)) -
+		1.30*(r-Δ)/Δ*math.Sin(common.Radians(λ-100.5))
+
+	for i := range u {
+		ug := common.Radians(u[i])
+
+		geocentric[i] = common.CartesianCoordinate{
+			X: ρ[i] * math.Sin(ug),
+			Y: -ρ[i] * math.Cos(ug) * math.Sin(common.Radians(De)),
+			Z: -ρ[i] * math.Cos(ug),
+		}
+
+		// as seen from the Sun, the satellites are displaced by the phase angle of Jupiter:
+		uh := common.Radians(u[i] - ψ)
+
+		heliocentric[i] = common.CartesianCoordinate{
+			X: ρ[i] * math.Sin(uh),
+			Y: -ρ[i] * math.Cos(uh) * math.Sin(common.Radians(Ds)),
+			Z: -ρ[i] * math.Cos(uh),
+		}
+	}
+
+	return geocentric, heliocentric
+}
+
+/*****************************************************************************************************************/
+
+/*
+the apparent rectangular position of a Galilean satellite relative to Jupiter for a given datetime, in equatorial
+radii of Jupiter
+
+The X coordinate is positive towards the west, i.e., in the direction of Jupiter's apparent diurnal motion, and
+the Y coordinate is positive towards Jupiter's north pole, both measured along the planet's equator and axis as
+seen from the Earth. The Z coordinate is positive when the satellite is farther from the Earth than Jupiter.
+*/
+func GetPosition(datetime time.Time, satellite Satellite) common.CartesianCoordinate {
+	geocentric, _ := getConfiguration(datetime)
+
+	return geocentric[satellite]
+}
+
+/*****************************************************************************************************************/
+
+/*
+the distance of a position from the centre of the disk of Jupiter, in equatorial radii of Jupiter, scaled along
+Jupiter's axis to allow for the flattening of the planet, such that the limb is at a distance of one
+*/
+func getDiskDistance(position common.CartesianCoordinate) float64 {
+	return math.Hypot(position.X, position.Y/(1-JUPITER_FLATTENING))
+}
+
+/*****************************************************************************************************************/
+
+/*
+whether each type of event is in progress for each of the Galilean satellites for a given datetime, indexed by
+satellite and then by event type
+*/
+func getStates(datetime time.Time) (states [4][4]bool) {
+	geocentric, heliocentric := getConfiguration(datetime)
+
+	for i := range geocentric {
+		g, h := geocentric[i], heliocentric[i]
+
+		states[i][Transit] = g.Z < 0 && getDiskDistance(g) < 1
+		states[i][ShadowTransit] = h.Z < 0 && getDiskDistance(h) < 1
+		states[i][Occultation] = g.Z > 0 && getDiskDistance(g) < 1
+		states[i][Eclipse] = h.Z > 0 && getDiskDistance(h) < 1
+	}
+
+	return states
+}
+
+/*****************************************************************************************************************/
+
+/*
+finds the datetime at which an event changes state between two datetimes by bisection, to within a second, where
+the state is assumed to change exactly once in the interval
+*/
+func bisect(a time.Time, b time.Time, f func(datetime time.Time) bool) time.Time {
+	fa := f(a)
+
+	for b.Sub(a) > time.Second {
+		mid := a.Add(b.Sub(a) / 2)
+
+		if f(mid) == fa {
+			a = mid
+		} else {
+			b = mid
+		}
+	}
+
+	return a.Add(b.Sub(a) / 2)
+}
+
+/*****************************************************************************************************************/
+
+/*
+finds the transits, shadow transits, occultations and eclipses of the Galilean satellites which begin within a
+date range
+
+The events are geometric, i.e., an eclipse which occurs while the satellite is hidden behind the disk of Jupiter
+is included, although it cannot be observed. The configuration is sampled at intervals of one minute, so that
+only the briefest grazing events, lasting less than a minute, may be missed.
+*/
+func GetEvents(start time.Time, end time.Time) []Event {
+	events := []Event{}
+
+	// the longest events, those of Callisto, last for less than six hours:
+	margin := 6 * time.Hour
+
+	step := time.Minute
+
+	types := []EventType{Transit, ShadowTransit, Occultation, Eclipse}
+
+	// the datetimes at which the events currently in progress began, if any:
+	begin := make(map[Satellite]map[EventType]time.Time)
+
+	for _, satellite := range Satellites {
+		begin[satellite] = make(map[EventType]time.Time)
+	}
+
+	previous := start.Add(-margin)
+
+	for t := previous; !t.After(end.Add(margin)); t = t.Add(step) {
+		states := getStates(t)
+
+		for _, satellite := range Satellites {
+			for _, eventType := range types {
+				s, e := satellite, eventType
+
+				f := func(datetime time.Time) bool {
+					return getStates(datetime)[s][e]
+				}
+
+				b, ok := begin[s][e]
+
+				if states[s][e] && !ok {
+					// the event began during the previous step, unless it was already in progress at the outset:
+					if t.Equal(start.Add(-margin)) {
+						begin[s][e] = time.Time{}
+					} else {
+						begin[s][e] = bisect(previous, t, f)
+					}
+				}
+
+				if !states[s][e] && ok {
+					delete(begin[s], e)
+
+					if b.IsZero() || b.Before(start) || b.After(end) {
+						continue
+					}
+
+					events = append(events, Event{
+						Type:      e,
+						Satellite: s,
+						Begin:     b,
+						End:       bisect(previous, t, f),
+					})
+				}
+			}
+		}
+
+		previous = t
+	}
+
+	// the events are found in order of their ends, so are sorted into order of their beginnings:
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].Begin.Before(events[j].Begin)
+	})
+
+	return events
+}
+
+/*****************************************************************************************************************/
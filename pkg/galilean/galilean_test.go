@@ -0,0 +1,193 @@
+/*****************************************************************************************************************/
+
+//	@author		Michael Roberts <michael@observerly.com>
+//	@package	@observerly/sidera
+//	@license	Copyright © 2021-2024 observerly
+
+/*****************************************************************************************************************/
+
+package galilean
+
+/*****************************************************************************************************************/
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+/*****************************************************************************************************************/
+
+// Meeus, "Astronomical Algorithms", Example 44.a, 1992 December 16 at 0h UT:
+var datetime time.Time = time.Date(1992, 12, 16, 0, 0, 0, 0, time.UTC)
+
+/*****************************************************************************************************************/
+
+func TestGetPosition(t *testing.T) {
+	want := map[Satellite][2]float64{
+		Io:       {-3.44, 0.21},
+		Europa:   {7.44, 0.25},
+		Ganymede: {1.24, 0.65},
+		Callisto: {7.08, 1.10},
+	}
+
+	for satellite, w := range want {
+		got := GetPosition(datetime, satellite)
+
+		if math.Abs(got.X-w[0]) > 0.01 {
+			t.Errorf("satellite %d: got X %f, wanted %f", satellite, got.X, w[0])
+		}
+
+		if math.Abs(got.Y-w[1]) > 0.01 {
+			t.Errorf("satellite %d: got Y %f, wanted %f", satellite, got.Y, w[1])
+		}
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetPositionInFrontOfJupiter(t *testing.T) {
+	// Io, Europa, Ganymede and Callisto are all on the near side of Jupiter at the epoch of Example 44.a:
+	for _, satellite := range Satellites {
+		if got := GetPosition(datetime, satellite); got.Z >= 0 {
+			t.Errorf("satellite %d: got Z %f, wanted a negative value", satellite, got.Z)
+		}
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetPositionDirectionOfMotion(t *testing.T) {
+	// a satellite on the near side of Jupiter moves towards the west, and on the far side towards the east:
+	for _, satellite := range Satellites {
+		a := GetPosition(datetime, satellite)
+
+		b := GetPosition(datetime.Add(10*time.Minute), satellite)
+
+		if (a.Z < 0) != (b.X > a.X) {
+			t.Errorf("satellite %d: got Z %f moving from X %f to %f", satellite, a.Z, a.X, b.X)
+		}
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetEvents(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	end := time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC)
+
+	events := GetEvents(start, end)
+
+	counts := make(map[EventType]int)
+
+	for i, event := range events {
+		if event.Begin.Before(start) || event.Begin.After(end) {
+			t.Errorf("got event beginning at %v, outside of the date range", event.Begin)
+		}
+
+		if !event.End.After(event.Begin) {
+			t.Errorf("got event ending at %v, before it began at %v", event.End, event.Begin)
+		}
+
+		if i > 0 && event.Begin.Before(events[i-1].Begin) {
+			t.Errorf("got event beginning at %v, before the previous event at %v", event.Begin, events[i-1].Begin)
+		}
+
+		if event.Satellite == Io {
+			counts[event.Type]++
+
+			// Io crosses the disk of Jupiter, and its shadow, in a little over two hours:
+			if d := event.End.Sub(event.Begin); d < 2*time.Hour || d > 2*time.Hour+20*time.Minute {
+				t.Errorf("got Io event of type %d lasting %v, wanted around two hours", event.Type, d)
+			}
+		}
+	}
+
+	// Io orbits Jupiter every 1.77 days, so has four events of each type in a week:
+	for _, eventType := range []EventType{Transit, ShadowTransit, Occultation, Eclipse} {
+		if counts[eventType] != 4 {
+			t.Errorf("got %d Io events of type %d, wanted 4", counts[eventType], eventType)
+		}
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetEventsTransit(t *testing.T) {
+	events := GetEvents(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC))
+
+	for _, event := range events {
+		if event.Type != Transit {
+			continue
+		}
+
+		// at mid-transit, the satellite is in front of Jupiter and within its disk:
+		got := GetPosition(event.Begin.Add(event.End.Sub(event.Begin)/2), event.Satellite)
+
+		if got.Z >= 0 {
+			t.Errorf("got Z %f, wanted a negative value", got.Z)
+		}
+
+		if d := getDiskDistance(got); d >= 1 {
+			t.Errorf("got disk distance %f, wanted less than 1", d)
+		}
+
+		// at the beginning of the transit, the satellite is on the limb of Jupiter:
+		if d := getDiskDistance(GetPosition(event.Begin, event.Satellite)); math.Abs(d-1) > 0.001 {
+			t.Errorf("got disk distance %f, wanted 1", d)
+		}
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetEventsTripleShadowTransit(t *testing.T) {
+	// the triple shadow transit of Io, Europa and Callisto of 2015 January 24, see Sky & Telescope (2015):
+	events := GetEvents(time.Date(2015, 1, 24, 0, 0, 0, 0, time.UTC), time.Date(2015, 1, 24, 12, 0, 0, 0, time.UTC))
+
+	tests := []struct {
+		eventType EventType
+		satellite Satellite
+		begin     time.Time
+		end       time.Time
+	}{
+		{ShadowTransit, Callisto, time.Date(2015, 1, 24, 3, 11, 0, 0, time.UTC), time.Date(2015, 1, 24, 7, 58, 0, 0, time.UTC)},
+		{ShadowTransit, Io, time.Date(2015, 1, 24, 4, 35, 0, 0, time.UTC), time.Date(2015, 1, 24, 6, 52, 0, 0, time.UTC)},
+		{Transit, Io, time.Date(2015, 1, 24, 4, 54, 0, 0, time.UTC), time.Date(2015, 1, 24, 7, 11, 0, 0, time.UTC)},
+		{ShadowTransit, Europa, time.Date(2015, 1, 24, 6, 27, 0, 0, time.UTC), time.Time{}},
+	}
+
+	for _, test := range tests {
+		found := false
+
+		for _, event := range events {
+			if event.Type != test.eventType || event.Satellite != test.satellite {
+				continue
+			}
+
+			found = true
+
+			if d := event.Begin.Sub(test.begin); d.Abs() > 3*time.Minute {
+				t.Errorf("satellite %d, type %d: got a beginning at %v, wanted %v", test.satellite, test.eventType, event.Begin, test.begin)
+			}
+
+			if !test.end.IsZero() && event.End.Sub(test.end).Abs() > 3*time.Minute {
+				t.Errorf("satellite %d, type %d: got an end at %v, wanted %v", test.satellite, test.eventType, event.End, test.end)
+			}
+		}
+
+		if !found {
+			t.Errorf("satellite %d: got no event of type %d, wanted one beginning at %v", test.satellite, test.eventType, test.begin)
+		}
+	}
+
+	// no satellite is occulted or eclipsed while the shadows cross the disk:
+	for _, event := range events {
+		if (event.Type == Occultation || event.Type == Eclipse) && event.Begin.Before(time.Date(2015, 1, 24, 8, 0, 0, 0, time.UTC)) {
+			t.Errorf("satellite %d: got an event of type %d at %v, wanted none", event.Satellite, event.Type, event.Begin)
+		}
+	}
+}
+
+/*****************************************************************************************************************/
@@ -0,0 +1,348 @@
+/*****************************************************************************************************************/
+
+//	@author		Michael Roberts <michael@observerly.com>
+//	@package	@observerly/sidera
+//	@license	Copyright © 2021-2024 observerly
+
+/*****************************************************************************************************************/
+
+package horizon
+
+/*****************************************************************************************************************/
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/observerly/sidera/pkg/common"
+	"github.com/observerly/sidera/pkg/coordinates"
+)
+
+/*****************************************************************************************************************/
+
+/*
+a point on a local horizon profile, i.e., the minimum altitude, in degrees, at which a target is unobstructed at
+a given azimuth, in degrees, measured eastward from north
+*/
+type Point struct {
+	Azimuth  float64
+	Altitude float64
+}
+
+/*****************************************************************************************************************/
+
+/*
+a local horizon profile, i.e., the minimum altitude at which a target is unobstructed as a function of azimuth,
+e.g., as set by the trees and buildings around an observatory
+
+The profile is a set of points in order of increasing azimuth, between which the altitude of the horizon is
+linearly interpolated, wrapping around through north. An empty profile is the geometric horizon, at an altitude
+of zero at all azimuths.
+*/
+type Profile []Point
+
+/*****************************************************************************************************************/
+
+/*
+a window within which a target is above a local horizon profile, from its rise above the profile to its set below
+the profile
+*/
+type Window struct {
+	Rise time.Time
+	Set  time.Time
+}
+
+/*****************************************************************************************************************/
+
+/*
+creates a local horizon profile from a set of points in any order, normalising the azimuths to the range 0 to 360
+degrees and sorting the points into order of increasing azimuth
+*/
+func NewProfile(points []Point) Profile {
+	profile := make(Profile, len(points))
+
+	for i, point := range points {
+		profile[i] = Point{
+			Azimuth:  math.Mod(math.Mod(point.Azimuth, 360)+360, 360),
+			Altitude: point.Altitude,
+		}
+	}
+
+	sort.SliceStable(profile, func(i, j int) bool {
+		return profile[i].Azimuth < profile[j].Azimuth
+	})
+
+	return profile
+}
+
+/*****************************************************************************************************************/
+
+/*
+creates a flat local horizon profile at a given altitude, in degrees, at all azimuths, e.g., for a minimum
+altitude set by the limits of a telescope rather than by local obstructions
+*/
+func NewFlatProfile(altitude float64) Profile {
+	return Profile{{Azimuth: 0, Altitude: altitude}}
+}
+
+/*****************************************************************************************************************/
+
+/*
+reads a local horizon profile from a file of azimuth and altitude pairs, in degrees, one pair per line
+
+The pairs may be separated by commas, semicolons, tabs or spaces, so that CSV files, Stellarium polygonal
+landscape horizon files and N.I.N.A. horizon (.hrz) files may all be read. Blank lines and comments, beginning
+with a '#' or a ';', are skipped, as is a header line before the first pair, e.g., "azimuth,altitude". Any
+further columns are ignored.
+*/
+func ReadProfile(r io.Reader) (Profile, error) {
+	points := []Point{}
+
+	scanner := bufio.NewScanner(r)
+
+	separators := func(r rune) bool {
+		return r == ',' || r == ';' || r == '\t' || r == ' '
+	}
+
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+
+		fields := strings.FieldsFunc(line, separators)
+
+		if len(fields) < 2 {
+			return nil, fmt.Errorf("line %d: expected an azimuth and an altitude, got %q", n, line)
+		}
+
+		azimuth, err := strconv.ParseFloat(fields[0], 64)
+
+		// the first line may be a header, which is skipped:
+		if err != nil && len(points) == 0 {
+			if _, e := strconv.ParseFloat(fields[1], 64); e != nil {
+				continue
+			}
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid azimuth %q", n, fields[0])
+		}
+
+		altitude, err := strconv.ParseFloat(fields[1], 64)
+
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid altitude %q", n, fields[1])
+		}
+
+		points = append(points, Point{Azimuth: azimuth, Altitude: altitude})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(points) == 0 {
+		return nil, fmt.Errorf("no points in horizon profile")
+	}
+
+	return NewProfile(points), nil
+}
+
+/*****************************************************************************************************************/
+
+/*
+the altitude of a local horizon profile, in degrees, at a given azimuth, in degrees, by linear interpolation
+between the points on either side of the azimuth, wrapping around through north
+*/
+func GetAltitude(profile Profile, azimuth float64) float64 {
+	n := len(profile)
+
+	if n == 0 {
+		return 0
+	}
+
+	if n == 1 {
+		return profile[0].Altitude
+	}
+
+	A := math.Mod(math.Mod(azimuth, 360)+360, 360)
+
+	// the index of the first point at or beyond the azimuth:
+	i := sort.Search(n, func(i int) bool {
+		return profile[i].Azimuth >= A
+	})
+
+	// the points on either side of the azimuth, wrapping around through north:
+	a, b := profile[(i-1+n)%n], profile[i%n]
+
+	// the span of azimuth between the points, and the azimuth beyond the first point:
+	span := math.Mod(b.Azimuth-a.Azimuth+360, 360)
+
+	Δ := math.Mod(A-a.Azimuth+360, 360)
+
+	if span == 0 {
+		return math.Max(a.Altitude, b.Altitude)
+	}
+
+	return a.Altitude + (b.Altitude-a.Altitude)*Δ/span
+}
+
+/*****************************************************************************************************************/
+
+/*
+the clearance of a target above a local horizon profile, in degrees, i.e., the altitude of the target less the
+altitude of the profile at the azimuth of the target, which is negative when the target is obstructed
+*/
+func GetClearance(profile Profile, target common.HorizontalCoordinate) float64 {
+	return target.Altitude - GetAltitude(profile, target.Azimuth)
+}
+
+/*****************************************************************************************************************/
+
+/*
+whether a target is above a local horizon profile, i.e., unobstructed
+*/
+func IsAboveHorizon(profile Profile, target common.HorizontalCoordinate) bool {
+	return GetClearance(profile, target) > 0
+}
+
+/*****************************************************************************************************************/
+
+/*
+finds the windows within which a target is above a local horizon profile, for a given observer, within a date
+range
+
+The clearance of the target is scanned in steps of one minute, and each rise and set is refined by bisection to
+within a second. A window which is already open at the start of the date range has its rise at the start, and a
+window still open at the end has its set at the end. The altitude of the target is geometric, i.e., without
+refraction, which raises a target at the horizon by around half a degree.
+*/
+func GetWindows(
+	start time.Time,
+	end time.Time,
+	observer common.GeographicCoordinate,
+	target common.EquatorialCoordinate,
+	profile Profile,
+) []Window {
+	windows := []Window{}
+
+	step := time.Minute
+
+	// the clearance of the target above the profile, at a given datetime:
+	f := func(datetime time.Time) float64 {
+		return GetClearance(profile, coordinates.ConvertEquatorialToHorizontalCoordinate(datetime, observer, target))
+	}
+
+	// refine a change of sign of the clearance between two datetimes by bisection:
+	bisect := func(a time.Time, b time.Time) time.Time {
+		fa := f(a)
+
+		for b.Sub(a) > time.Second {
+			mid := a.Add(b.Sub(a) / 2)
+
+			if fm := f(mid); (fa > 0) != (fm > 0) {
+				b = mid
+			} else {
+				a, fa = mid, fm
+			}
+		}
+
+		return a.Add(b.Sub(a) / 2)
+	}
+
+	var window *Window
+
+	a := start
+
+	fa := f(a)
+
+	if fa > 0 {
+		window = &Window{Rise: start}
+	}
+
+	for a.Before(end) {
+		b := a.Add(step)
+
+		if b.After(end) {
+			b = end
+		}
+
+		fb := f(b)
+
+		// the target rises above the profile:
+		if fa <= 0 && fb > 0 {
+			window = &Window{Rise: bisect(a, b)}
+		}
+
+		// the target sets below the profile, or the end of the date range is reached:
+		if window != nil && (fa > 0 && fb <= 0 || !b.Before(end)) {
+			window.Set = b
+
+			if fb <= 0 {
+				window.Set = bisect(a, b)
+			}
+
+			windows = append(windows, *window)
+
+			window = nil
+		}
+
+		a, fa = b, fb
+	}
+
+	return windows
+}
+
+/*****************************************************************************************************************/
+
+/*
+the next rise of a target above a local horizon profile, for a given observer, within a day of a given datetime,
+or false if the target does not rise within the day, e.g., because it is circumpolar or never clears the profile
+*/
+func GetRise(
+	datetime time.Time,
+	observer common.GeographicCoordinate,
+	target common.EquatorialCoordinate,
+	profile Profile,
+) (time.Time, bool) {
+	for _, window := range GetWindows(datetime, datetime.Add(24*time.Hour), observer, target, profile) {
+		if window.Rise.After(datetime) {
+			return window.Rise, true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+/*****************************************************************************************************************/
+
+/*
+the next set of a target below a local horizon profile, for a given observer, within a day of a given datetime,
+or false if the target does not set within the day, e.g., because it is circumpolar or never clears the profile
+*/
+func GetSet(
+	datetime time.Time,
+	observer common.GeographicCoordinate,
+	target common.EquatorialCoordinate,
+	profile Profile,
+) (time.Time, bool) {
+	end := datetime.Add(24 * time.Hour)
+
+	for _, window := range GetWindows(datetime, end, observer, target, profile) {
+		if window.Set.Before(end) {
+			return window.Set, true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+/*****************************************************************************************************************/
@@ -0,0 +1,255 @@
+/*****************************************************************************************************************/
+
+//	@author		Michael Roberts <michael@observerly.com>
+//	@package	@observerly/sidera
+//	@license	Copyright © 2021-2024 observerly
+
+/*****************************************************************************************************************/
+
+package horizon
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/observerly/sidera/pkg/common"
+	"github.com/observerly/sidera/pkg/coordinates"
+)
+
+/*****************************************************************************************************************/
+
+// We define a datetime as some arbitrary date and time for testing purposes:
+var datetime time.Time = time.Date(2021, 5, 14, 0, 0, 0, 0, time.UTC)
+
+/*****************************************************************************************************************/
+
+var observer common.GeographicCoordinate = common.GeographicCoordinate{
+	Latitude:  19.8207,
+	Longitude: -155.468094,
+	Elevation: 4205,
+}
+
+/*****************************************************************************************************************/
+
+var betelgeuse common.EquatorialCoordinate = common.EquatorialCoordinate{
+	RightAscension: 88.7929583,
+	Declination:    7.4070639,
+}
+
+/*****************************************************************************************************************/
+
+// a horizon obstructed by trees to the east and a building to the west:
+var profile Profile = NewProfile([]Point{
+	{Azimuth: 0, Altitude: 5},
+	{Azimuth: 90, Altitude: 20},
+	{Azimuth: 180, Altitude: 5},
+	{Azimuth: 270, Altitude: 15},
+})
+
+/*****************************************************************************************************************/
+
+func TestNewProfile(t *testing.T) {
+	p := NewProfile([]Point{{Azimuth: 270, Altitude: 3}, {Azimuth: -90, Altitude: 1}, {Azimuth: 450, Altitude: 2}})
+
+	want := []float64{90, 270, 270}
+
+	for i, point := range p {
+		if point.Azimuth != want[i] {
+			t.Errorf("point %d: got an azimuth of %f, wanted %f", i, point.Azimuth, want[i])
+		}
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetAltitude(t *testing.T) {
+	tests := []struct {
+		azimuth float64
+		want    float64
+	}{
+		{azimuth: 0, want: 5},
+		{azimuth: 45, want: 12.5},
+		{azimuth: 90, want: 20},
+		{azimuth: 225, want: 10},
+		// wrapping around through north:
+		{azimuth: 315, want: 10},
+		{azimuth: 360, want: 5},
+		{azimuth: -45, want: 10},
+	}
+
+	for _, test := range tests {
+		if altitude := GetAltitude(profile, test.azimuth); math.Abs(altitude-test.want) > 1e-9 {
+			t.Errorf("azimuth %f: got %f, wanted %f", test.azimuth, altitude, test.want)
+		}
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetAltitudeGeometricAndFlat(t *testing.T) {
+	if altitude := GetAltitude(Profile{}, 123); altitude != 0 {
+		t.Errorf("got %f, wanted the geometric horizon at zero", altitude)
+	}
+
+	if altitude := GetAltitude(NewFlatProfile(12), 123); altitude != 12 {
+		t.Errorf("got %f, wanted a flat horizon at 12", altitude)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestIsAboveHorizon(t *testing.T) {
+	if !IsAboveHorizon(profile, common.HorizontalCoordinate{Azimuth: 180, Altitude: 10}) {
+		t.Errorf("got obstructed, wanted a target at 10 degrees in the south to be above the horizon")
+	}
+
+	if IsAboveHorizon(profile, common.HorizontalCoordinate{Azimuth: 90, Altitude: 10}) {
+		t.Errorf("got unobstructed, wanted a target at 10 degrees in the east to be obstructed")
+	}
+
+	if c := GetClearance(profile, common.HorizontalCoordinate{Azimuth: 90, Altitude: 10}); c != -10 {
+		t.Errorf("got %f, wanted %f", c, -10.0)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestReadProfileCSV(t *testing.T) {
+	csv := "azimuth,altitude\n0,5\n90,20\n\n180,5\n270,15\n"
+
+	p, err := ReadProfile(strings.NewReader(csv))
+
+	if err != nil {
+		t.Fatalf("got error %v", err)
+	}
+
+	if len(p) != 4 {
+		t.Fatalf("got %d points, wanted 4", len(p))
+	}
+
+	if altitude := GetAltitude(p, 45); math.Abs(altitude-12.5) > 1e-9 {
+		t.Errorf("got %f, wanted %f", altitude, 12.5)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestReadProfileHorizonFile(t *testing.T) {
+	hrz := "# N.I.N.A. horizon file\n; Stellarium comment\n270 15\n0\t5\n  90   20  \n180 5\n"
+
+	p, err := ReadProfile(strings.NewReader(hrz))
+
+	if err != nil {
+		t.Fatalf("got error %v", err)
+	}
+
+	if len(p) != 4 || p[0].Azimuth != 0 || p[3].Azimuth != 270 {
+		t.Fatalf("got %+v, wanted four points in order of azimuth", p)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestReadProfileInvalid(t *testing.T) {
+	tests := []string{
+		"",
+		"# only a comment\n",
+		"0,5\n90\n",
+		"0,5\n90,high\n",
+		"0,5\nnorth,5\n",
+	}
+
+	for _, test := range tests {
+		if _, err := ReadProfile(strings.NewReader(test)); err == nil {
+			t.Errorf("got no error, wanted an error for %q", test)
+		}
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetWindows(t *testing.T) {
+	end := datetime.Add(24 * time.Hour)
+
+	geometric := GetWindows(datetime, end, observer, betelgeuse, Profile{})
+
+	obstructed := GetWindows(datetime, end, observer, betelgeuse, profile)
+
+	if len(geometric) == 0 || len(obstructed) == 0 {
+		t.Fatalf("got %d and %d windows, wanted at least one of each", len(geometric), len(obstructed))
+	}
+
+	duration := func(windows []Window) (d time.Duration) {
+		for _, window := range windows {
+			d += window.Set.Sub(window.Rise)
+		}
+		return d
+	}
+
+	// the obstructions shorten the time for which the target is visible:
+	if duration(obstructed) >= duration(geometric) {
+		t.Errorf("got %v above the profile and %v above the horizon", duration(obstructed), duration(geometric))
+	}
+
+	// the target is on the profile at the rise and set, unless clipped by the date range:
+	for i, window := range obstructed {
+		for _, d := range []time.Time{window.Rise, window.Set} {
+			if d.Equal(datetime) || d.Equal(end) {
+				continue
+			}
+
+			hz := coordinates.ConvertEquatorialToHorizontalCoordinate(d, observer, betelgeuse)
+
+			if c := GetClearance(profile, hz); math.Abs(c) > 0.01 {
+				t.Errorf("window %d: got a clearance of %f at %v, wanted 0", i, c, d)
+			}
+		}
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetRiseAndSet(t *testing.T) {
+	rise, ok := GetRise(datetime, observer, betelgeuse, profile)
+
+	if !ok {
+		t.Fatalf("got no rise, wanted Betelgeuse to rise within a day")
+	}
+
+	set, ok := GetSet(datetime, observer, betelgeuse, profile)
+
+	if !ok {
+		t.Fatalf("got no set, wanted Betelgeuse to set within a day")
+	}
+
+	for _, d := range []time.Time{rise, set} {
+		hz := coordinates.ConvertEquatorialToHorizontalCoordinate(d, observer, betelgeuse)
+
+		if c := GetClearance(profile, hz); math.Abs(c) > 0.01 {
+			t.Errorf("got a clearance of %f at %v, wanted 0", c, d)
+		}
+	}
+
+	// Betelgeuse rises in the east, behind the trees, above 20 degrees:
+	if hz := coordinates.ConvertEquatorialToHorizontalCoordinate(rise, observer, betelgeuse); hz.Altitude < 15 {
+		t.Errorf("got an altitude of %f at the rise, wanted the target to rise behind the trees", hz.Altitude)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetRiseCircumpolar(t *testing.T) {
+	polaris := common.EquatorialCoordinate{RightAscension: 37.9529, Declination: 89.2642}
+
+	if _, ok := GetRise(datetime, observer, polaris, profile); ok {
+		t.Errorf("got a rise, wanted none for a circumpolar target above the profile")
+	}
+
+	if _, ok := GetSet(datetime, observer, polaris, profile); ok {
+		t.Errorf("got a set, wanted none for a circumpolar target above the profile")
+	}
+}
+
+/*****************************************************************************************************************/
@@ -0,0 +1,70 @@
+/*****************************************************************************************************************/
+
+//	@author		Michael Roberts <michael@observerly.com>
+//	@package	@observerly/sidera
+//	@license	Copyright © 2021-2024 observerly
+
+/*****************************************************************************************************************/
+
+package search
+
+/*****************************************************************************************************************/
+
+import (
+	"math"
+	"time"
+)
+
+/*****************************************************************************************************************/
+
+/*
+finds the datetime of a root of a function between two datetimes by bisection, to within a tenth of a second,
+where the function is assumed to change sign exactly once in the interval, and either datetime may be the later
+*/
+func Bisect(a time.Time, b time.Time, f func(datetime time.Time) float64) time.Time {
+	fa := f(a)
+
+	for math.Abs(float64(b.Sub(a))) > float64(100*time.Millisecond) {
+		mid := a.Add(b.Sub(a) / 2)
+
+		if fm := f(mid); fa*fm <= 0 {
+			b = mid
+		} else {
+			a, fa = mid, fm
+		}
+	}
+
+	return a.Add(b.Sub(a) / 2)
+}
+
+/*****************************************************************************************************************/
+
+/*
+finds the datetime of the minimum of a function between two datetimes by golden section search, to within a
+tenth of a second, where the function is assumed to have a single minimum in the interval
+*/
+func Minimise(a time.Time, b time.Time, f func(datetime time.Time) float64) time.Time {
+	ϕ := (math.Sqrt(5) - 1) / 2
+
+	c := b.Add(-time.Duration(ϕ * float64(b.Sub(a))))
+
+	d := a.Add(time.Duration(ϕ * float64(b.Sub(a))))
+
+	fc, fd := f(c), f(d)
+
+	for b.Sub(a) > 100*time.Millisecond {
+		if fc < fd {
+			b, d, fd = d, c, fc
+			c = b.Add(-time.Duration(ϕ * float64(b.Sub(a))))
+			fc = f(c)
+		} else {
+			a, c, fc = c, d, fd
+			d = a.Add(time.Duration(ϕ * float64(b.Sub(a))))
+			fd = f(d)
+		}
+	}
+
+	return a.Add(b.Sub(a) / 2)
+}
+
+/*****************************************************************************************************************/
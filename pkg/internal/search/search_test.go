@@ -0,0 +1,57 @@
+/*****************************************************************************************************************/
+
+//	@author		Michael Roberts <michael@observerly.com>
+//	@package	@observerly/sidera
+//	@license	Copyright © 2021-2024 observerly
+
+/*****************************************************************************************************************/
+
+package search
+
+/*****************************************************************************************************************/
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+/*****************************************************************************************************************/
+
+// We define a datetime as some arbitrary date and time for testing purposes:
+var datetime time.Time = time.Date(2024, 4, 8, 18, 0, 0, 0, time.UTC)
+
+/*****************************************************************************************************************/
+
+func TestBisect(t *testing.T) {
+	want := datetime.Add(17 * time.Minute)
+
+	f := func(d time.Time) float64 {
+		return d.Sub(want).Seconds()
+	}
+
+	if got := Bisect(datetime, datetime.Add(time.Hour), f); math.Abs(got.Sub(want).Seconds()) > 0.1 {
+		t.Errorf("got %v, wanted %v", got, want)
+	}
+
+	// the interval may be given in reverse:
+	if got := Bisect(datetime.Add(time.Hour), datetime, f); math.Abs(got.Sub(want).Seconds()) > 0.1 {
+		t.Errorf("got %v, wanted %v", got, want)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestMinimise(t *testing.T) {
+	want := datetime.Add(17 * time.Minute)
+
+	got := Minimise(datetime, datetime.Add(time.Hour), func(d time.Time) float64 {
+		return math.Pow(d.Sub(want).Seconds(), 2)
+	})
+
+	if math.Abs(got.Sub(want).Seconds()) > 0.1 {
+		t.Errorf("got %v, wanted %v", got, want)
+	}
+}
+
+/*****************************************************************************************************************/
@@ -0,0 +1,580 @@
+/*****************************************************************************************************************/
+
+//	@author		Michael Roberts <michael@observerly.com>
+//	@package	@observerly/sidera
+//	@license	Copyright © 2021-2024 observerly
+
+/*****************************************************************************************************************/
+
+package jpl
+
+/*****************************************************************************************************************/
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/observerly/sidera/pkg/common"
+	"github.com/observerly/sidera/pkg/epoch"
+)
+
+/*****************************************************************************************************************/
+
+/*
+the NAIF integer codes of the bodies and barycentres of the solar system, as used by the JPL Development
+Ephemerides (e.g., DE440 and DE441)
+*/
+const (
+	SolarSystemBarycentre int = 0
+	MercuryBarycentre     int = 1
+	VenusBarycentre       int = 2
+	EarthMoonBarycentre   int = 3
+	MarsBarycentre        int = 4
+	JupiterBarycentre     int = 5
+	SaturnBarycentre      int = 6
+	UranusBarycentre      int = 7
+	NeptuneBarycentre     int = 8
+	PlutoBarycentre       int = 9
+	Sun                   int = 10
+	Mercury               int = 199
+	Venus                 int = 299
+	Moon                  int = 301
+	Earth                 int = 399
+)
+
+/*****************************************************************************************************************/
+
+// the speed of light, in kilometres per second:
+const SPEED_OF_LIGHT float64 = 299792.458
+
+/*****************************************************************************************************************/
+
+// the length of a DAF record, in bytes:
+const recordLength = 1024
+
+/*****************************************************************************************************************/
+
+/*
+a segment of an SPK file, i.e., the Chebyshev coefficients for the position of a target body relative to a
+centre body over an interval of time
+
+The start and end of the interval are given in seconds of Barycentric Dynamical Time (TDB) past J2000.0. Only
+segments of type 2 (Chebyshev position) and type 3 (Chebyshev position and velocity) can be evaluated, which
+are the types used by all of the JPL Development Ephemerides.
+*/
+type Segment struct {
+	Target int
+	Center int
+	Frame  int
+	Type   int
+	Start  float64
+	End    float64
+	// the first and last (1-based, double precision) word addresses of the segment's data:
+	begin int
+	end   int
+	// the start time (s), the length (s) and size (in words) of each record, and the number of records:
+	init   float64
+	intlen float64
+	rsize  int
+	n      int
+}
+
+/*****************************************************************************************************************/
+
+/*
+a JPL Development Ephemeris in the SPICE Double precision Array File (DAF) SPK format, e.g., de440.bsp
+
+The ephemeris gives the positions of the Sun, Moon and planets (and the barycentres of the planetary systems)
+with a precision of better than a milliarcsecond, as an alternative to the analytical theories of the Sun,
+Moon and planets elsewhere in sidera.
+*/
+type Ephemeris struct {
+	Segments []Segment
+	reader   io.ReaderAt
+	closer   io.Closer
+	order    binary.ByteOrder
+}
+
+/*****************************************************************************************************************/
+
+/*
+opens an SPK file from a local path, e.g., "de440.bsp", and reads the summaries of its segments
+
+The file remains open until the ephemeris is closed.
+*/
+func Open(path string) (*Ephemeris, error) {
+	f, err := os.Open(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	e, err := NewEphemeris(f)
+
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	e.closer = f
+
+	return e, nil
+}
+
+/*****************************************************************************************************************/
+
+/*
+reads the summaries of the segments of an SPK file from a reader
+*/
+func NewEphemeris(reader io.ReaderAt) (*Ephemeris, error) {
+	record := make([]byte, recordLength)
+
+	if _, err := reader.ReadAt(record, 0); err != nil {
+		return nil, fmt.Errorf("unable to read the file record: %w", err)
+	}
+
+	// the identification word of the file, which must be "DAF/SPK" (or the older "NAIF/DAF"):
+	id := strings.TrimSpace(string(record[0:8]))
+
+	if id != "DAF/SPK" && id != "NAIF/DAF" {
+		return nil, fmt.Errorf("unsupported file identification word: %q", id)
+	}
+
+	e := &Ephemeris{
+		reader: reader,
+		order:  binary.LittleEndian,
+	}
+
+	// the binary format of the file, which is absent from files written by old versions of the toolkit:
+	switch strings.TrimSpace(string(record[88:96])) {
+	case "BIG-IEEE":
+		e.order = binary.BigEndian
+	case "LTL-IEEE":
+		e.order = binary.LittleEndian
+	default:
+		// SPK files always have two double precision components, so we may infer the byte order from ND:
+		if binary.BigEndian.Uint32(record[8:12]) == 2 {
+			e.order = binary.BigEndian
+		}
+	}
+
+	// the number of double precision (ND) and integer (NI) components of each summary:
+	nd := int(int32(e.order.Uint32(record[8:12])))
+
+	ni := int(int32(e.order.Uint32(record[12:16])))
+
+	if nd != 2 || ni != 6 {
+		return nil, fmt.Errorf("unsupported summary format: ND=%d, NI=%d", nd, ni)
+	}
+
+	// the size of each summary, in double precision words:
+	ss := nd + (ni+1)/2
+
+	// the record number of the first summary record:
+	next := int(int32(e.order.Uint32(record[76:80])))
+
+	// the summary records which have been read, so that a corrupt chain of records which loops back on itself is
+	// detected rather than followed forever:
+	visited := make(map[int]bool)
+
+	for next > 0 {
+		if visited[next] {
+			return nil, fmt.Errorf("summary record %d is linked more than once", next)
+		}
+
+		visited[next] = true
+
+		if _, err := reader.ReadAt(record, int64(next-1)*recordLength); err != nil {
+			return nil, fmt.Errorf("unable to read summary record %d: %w", next, err)
+		}
+
+		// the control area of the summary record, i.e., the next and previous records and the number of summaries:
+		next = int(e.getFloat(record, 0))
+
+		nsum := int(e.getFloat(record, 2))
+
+		// the summary record holds the control area of three words, followed by at most (128 - 3) / ss summaries:
+		if nsum < 0 || nsum > (128-3)/ss {
+			return nil, fmt.Errorf("invalid number of summaries in summary record: %d", nsum)
+		}
+
+		for i := 0; i < nsum; i++ {
+			offset := (3 + i*ss) * 8
+
+			summary := record[offset : offset+ss*8]
+
+			segment := Segment{
+				Start:  e.getFloat(summary, 0),
+				End:    e.getFloat(summary, 1),
+				Target: e.getInt(summary, 16),
+				Center: e.getInt(summary, 20),
+				Frame:  e.getInt(summary, 24),
+				Type:   e.getInt(summary, 28),
+				begin:  e.getInt(summary, 32),
+				end:    e.getInt(summary, 36),
+			}
+
+			if segment.Type == 2 || segment.Type == 3 {
+				if segment.begin < 1 || segment.end-3 < segment.begin {
+					return nil, fmt.Errorf("invalid segment addresses: %d to %d", segment.begin, segment.end)
+				}
+
+				// the segment directory is found in the last four words of the segment:
+				directory, err := e.readFloats(segment.end-3, 4)
+
+				if err != nil {
+					return nil, err
+				}
+
+				segment.init = directory[0]
+				segment.intlen = directory[1]
+				segment.rsize = int(directory[2])
+				segment.n = int(directory[3])
+
+				if err := validateSegment(segment); err != nil {
+					return nil, err
+				}
+			}
+
+			e.Segments = append(e.Segments, segment)
+		}
+	}
+
+	return e, nil
+}
+
+/*****************************************************************************************************************/
+
+/*
+validates the directory of a Chebyshev (type 2 or type 3) segment, i.e., that each record holds its midpoint and
+radius followed by at least one coefficient for each component, that the records cover a positive interval of
+time, and that the records fit within the segment ahead of its directory
+*/
+func validateSegment(segment Segment) error {
+	// the number of components of each record:
+	components := 3
+
+	if segment.Type == 3 {
+		components = 6
+	}
+
+	if segment.rsize < 2+components || (segment.rsize-2)%components != 0 {
+		return fmt.Errorf("invalid record size for a type %d segment: %d", segment.Type, segment.rsize)
+	}
+
+	if segment.n < 1 {
+		return fmt.Errorf("invalid number of records in segment: %d", segment.n)
+	}
+
+	if !(segment.intlen > 0) || math.IsInf(segment.intlen, 0) {
+		return fmt.Errorf("invalid record interval length in segment: %f", segment.intlen)
+	}
+
+	if segment.n > (segment.end-3-segment.begin)/segment.rsize {
+		return fmt.Errorf("%d records of %d words overrun the segment from %d to %d", segment.n, segment.rsize, segment.begin, segment.end)
+	}
+
+	return nil
+}
+
+/*****************************************************************************************************************/
+
+/*
+closes the underlying SPK file, if the ephemeris was opened from a local path
+*/
+func (e *Ephemeris) Close() error {
+	if e.closer == nil {
+		return nil
+	}
+
+	return e.closer.Close()
+}
+
+/*****************************************************************************************************************/
+
+/*
+the double precision value at the given word index of a buffer
+*/
+func (e *Ephemeris) getFloat(buffer []byte, index int) float64 {
+	return math.Float64frombits(e.order.Uint64(buffer[index*8 : index*8+8]))
+}
+
+/*****************************************************************************************************************/
+
+/*
+the integer value at the given byte offset of a buffer
+*/
+func (e *Ephemeris) getInt(buffer []byte, offset int) int {
+	return int(int32(e.order.Uint32(buffer[offset : offset+4])))
+}
+
+/*****************************************************************************************************************/
+
+/*
+reads n double precision values starting at the given (1-based) word address
+*/
+func (e *Ephemeris) readFloats(address int, n int) ([]float64, error) {
+	buffer := make([]byte, n*8)
+
+	if _, err := e.reader.ReadAt(buffer, int64(address-1)*8); err != nil {
+		return nil, fmt.Errorf("unable to read %d words at address %d: %w", n, address, err)
+	}
+
+	values := make([]float64, n)
+
+	for i := range values {
+		values[i] = e.getFloat(buffer, i)
+	}
+
+	return values, nil
+}
+
+/*****************************************************************************************************************/
+
+/*
+evaluates a segment at the given time, in seconds of TDB past J2000.0, returning the position (km) and
+velocity (km/s) of the segment's target relative to its centre
+*/
+func (e *Ephemeris) evaluate(segment Segment, et float64) (common.CartesianCoordinate, common.CartesianCoordinate, error) {
+	if segment.Type != 2 && segment.Type != 3 {
+		return common.CartesianCoordinate{}, common.CartesianCoordinate{}, fmt.Errorf("unsupported segment type: %d", segment.Type)
+	}
+
+	// the index of the record which covers the given time:
+	i := int(math.Floor((et - segment.init) / segment.intlen))
+
+	if i < 0 {
+		i = 0
+	}
+
+	if i > segment.n-1 {
+		i = segment.n - 1
+	}
+
+	record, err := e.readFloats(segment.begin+i*segment.rsize, segment.rsize)
+
+	if err != nil {
+		return common.CartesianCoordinate{}, common.CartesianCoordinate{}, err
+	}
+
+	// the midpoint and radius of the interval covered by the record, in seconds:
+	mid, radius := record[0], record[1]
+
+	if !(radius > 0) {
+		return common.CartesianCoordinate{}, common.CartesianCoordinate{}, fmt.Errorf("invalid record radius: %f", radius)
+	}
+
+	// the number of coefficients per component:
+	components := 3
+
+	if segment.Type == 3 {
+		components = 6
+	}
+
+	n := (segment.rsize - 2) / components
+
+	// the normalised time within the interval, from -1 to +1:
+	s := (et - mid) / radius
+
+	// the Chebyshev polynomials of the first kind, and their derivatives, at the normalised time:
+	T := make([]float64, n)
+
+	dT := make([]float64, n)
+
+	T[0] = 1
+
+	if n > 1 {
+		T[1] = s
+		dT[1] = 1
+	}
+
+	for k := 2; k < n; k++ {
+		T[k] = 2*s*T[k-1] - T[k-2]
+		dT[k] = 2*T[k-1] + 2*s*dT[k-1] - dT[k-2]
+	}
+
+	p := make([]float64, 3)
+
+	v := make([]float64, 3)
+
+	for c := 0; c < 3; c++ {
+		coefficients := record[2+c*n : 2+(c+1)*n]
+
+		for k := 0; k < n; k++ {
+			p[c] += coefficients[k] * T[k]
+			v[c] += coefficients[k] * dT[k] / radius
+		}
+
+		// type 3 segments carry the coefficients of the velocity explicitly:
+		if segment.Type == 3 {
+			v[c] = 0
+
+			coefficients := record[2+(c+3)*n : 2+(c+4)*n]
+
+			for k := 0; k < n; k++ {
+				v[c] += coefficients[k] * T[k]
+			}
+		}
+	}
+
+	return common.CartesianCoordinate{X: p[0], Y: p[1], Z: p[2]}, common.CartesianCoordinate{X: v[0], Y: v[1], Z: v[2]}, nil
+}
+
+/*****************************************************************************************************************/
+
+/*
+the state of a body relative to the solar system barycentre at the given time, in seconds of TDB past J2000.0
+
+The state is found by chaining segments from the body to the solar system barycentre, e.g., from the Moon to
+the Earth-Moon barycentre, and from the Earth-Moon barycentre to the solar system barycentre. Where more than
+one segment covers the body at the given time, the segment found last in the file takes precedence.
+*/
+func (e *Ephemeris) getBarycentricState(body int, et float64) (common.CartesianCoordinate, common.CartesianCoordinate, error) {
+	position := common.CartesianCoordinate{}
+
+	velocity := common.CartesianCoordinate{}
+
+	for body != SolarSystemBarycentre {
+		found := false
+
+		for i := len(e.Segments) - 1; i >= 0; i-- {
+			segment := e.Segments[i]
+
+			if segment.Target != body || et < segment.Start || et > segment.End {
+				continue
+			}
+
+			p, v, err := e.evaluate(segment, et)
+
+			if err != nil {
+				return position, velocity, err
+			}
+
+			position = common.CartesianCoordinate{X: position.X + p.X, Y: position.Y + p.Y, Z: position.Z + p.Z}
+
+			velocity = common.CartesianCoordinate{X: velocity.X + v.X, Y: velocity.Y + v.Y, Z: velocity.Z + v.Z}
+
+			body = segment.Center
+
+			found = true
+
+			break
+		}
+
+		if !found {
+			return position, velocity, fmt.Errorf("no segment covers body %d at %f seconds past J2000.0", body, et)
+		}
+	}
+
+	return position, velocity, nil
+}
+
+/*****************************************************************************************************************/
+
+/*
+the number of seconds of Barycentric Dynamical Time (TDB) past J2000.0 for a given datetime
+*/
+func getEphemerisTime(datetime time.Time) float64 {
+	return (epoch.GetBarycentricDynamicalTimeJulianDate(datetime) - epoch.J2000) * 86400
+}
+
+/*****************************************************************************************************************/
+
+/*
+the state of a target body relative to a centre body for a given datetime, i.e., the position (km) and
+velocity (km/s) referred to the International Celestial Reference Frame (ICRF)
+*/
+func (e *Ephemeris) GetState(
+	datetime time.Time,
+	target int,
+	center int,
+) (common.CartesianCoordinate, common.CartesianCoordinate, error) {
+	et := getEphemerisTime(datetime)
+
+	pt, vt, err := e.getBarycentricState(target, et)
+
+	if err != nil {
+		return pt, vt, err
+	}
+
+	pc, vc, err := e.getBarycentricState(center, et)
+
+	if err != nil {
+		return pc, vc, err
+	}
+
+	return common.CartesianCoordinate{X: pt.X - pc.X, Y: pt.Y - pc.Y, Z: pt.Z - pc.Z},
+		common.CartesianCoordinate{X: vt.X - vc.X, Y: vt.Y - vc.Y, Z: vt.Z - vc.Z},
+		nil
+}
+
+/*****************************************************************************************************************/
+
+/*
+the position of a target body relative to a centre body for a given datetime, in kilometres, referred to the
+International Celestial Reference Frame (ICRF)
+*/
+func (e *Ephemeris) GetPosition(datetime time.Time, target int, center int) (common.CartesianCoordinate, error) {
+	position, _, err := e.GetState(datetime, target, center)
+
+	return position, err
+}
+
+/*****************************************************************************************************************/
+
+/*
+the geocentric astrometric equatorial coordinate of a target body for a given datetime, and its distance from
+the Earth in kilometres
+
+The position of the target is computed at the time at which the light arriving at the Earth left the target,
+and is referred to the International Celestial Reference Frame (ICRF), i.e., the mean equator and equinox of
+J2000.0 to within a few tens of milliarcseconds.
+*/
+func (e *Ephemeris) GetEquatorialCoordinate(datetime time.Time, target int) (common.EquatorialCoordinate, float64, error) {
+	et := getEphemerisTime(datetime)
+
+	earth, _, err := e.getBarycentricState(Earth, et)
+
+	if err != nil {
+		return common.EquatorialCoordinate{}, 0, err
+	}
+
+	// the light travel time from the target to the Earth, in seconds:
+	τ := 0.0
+
+	position := common.CartesianCoordinate{}
+
+	Δ := 0.0
+
+	for i := 0; i < 3; i++ {
+		p, _, err := e.getBarycentricState(target, et-τ)
+
+		if err != nil {
+			return common.EquatorialCoordinate{}, 0, err
+		}
+
+		position = common.CartesianCoordinate{X: p.X - earth.X, Y: p.Y - earth.Y, Z: p.Z - earth.Z}
+
+		Δ = math.Sqrt(math.Pow(position.X, 2) + math.Pow(position.Y, 2) + math.Pow(position.Z, 2))
+
+		τ = Δ / SPEED_OF_LIGHT
+	}
+
+	α := common.Degrees(math.Atan2(position.Y, position.X))
+
+	if α < 0 {
+		α += 360
+	}
+
+	return common.EquatorialCoordinate{
+		RightAscension: α,
+		Declination:    common.Degrees(math.Asin(position.Z / Δ)),
+	}, Δ, nil
+}
+
+/*****************************************************************************************************************/
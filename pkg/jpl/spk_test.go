@@ -0,0 +1,369 @@
+/*****************************************************************************************************************/
+
+//	@author		Michael Roberts <michael@observerly.com>
+//	@package	@observerly/sidera
+//	@license	Copyright © 2021-2024 observerly
+
+/*****************************************************************************************************************/
+
+package jpl
+
+/*****************************************************************************************************************/
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+/*****************************************************************************************************************/
+
+// We define a datetime close to J2000.0, at which the synthetic ephemeris below is evaluated:
+var datetime time.Time = time.Date(2000, 1, 1, 12, 0, 0, 0, time.UTC)
+
+/*****************************************************************************************************************/
+
+/*
+a synthetic segment, with its records laid out as (MID, RADIUS, coefficients...) followed by the directory
+*/
+type segment struct {
+	target, center, kind int
+	init, intlen         float64
+	records              [][]float64
+}
+
+/*****************************************************************************************************************/
+
+/*
+writes a minimal little-endian SPK file containing the given segments, with a single summary record (record 2),
+a name record (record 3), and the segment data starting at record 4
+*/
+func writeSPK(segments []segment) []byte {
+	words := []float64{}
+
+	summaries := []byte{}
+
+	// the first free word address, following the file record, summary record and name record:
+	address := 3*128 + 1
+
+	for _, s := range segments {
+		begin := address + len(words)
+
+		for _, record := range s.records {
+			words = append(words, record...)
+		}
+
+		words = append(words, s.init, s.intlen, float64(len(s.records[0])), float64(len(s.records)))
+
+		end := address + len(words) - 1
+
+		summary := make([]byte, 40)
+
+		binary.LittleEndian.PutUint64(summary[0:], math.Float64bits(s.init))
+		binary.LittleEndian.PutUint64(summary[8:], math.Float64bits(s.init+s.intlen*float64(len(s.records))))
+
+		for i, v := range []int{s.target, s.center, 1, s.kind, begin, end} {
+			binary.LittleEndian.PutUint32(summary[16+i*4:], uint32(int32(v)))
+		}
+
+		summaries = append(summaries, summary...)
+	}
+
+	file := make([]byte, 3*recordLength)
+
+	copy(file[0:], "DAF/SPK ")
+	binary.LittleEndian.PutUint32(file[8:], 2)
+	binary.LittleEndian.PutUint32(file[12:], 6)
+	binary.LittleEndian.PutUint32(file[76:], 2)
+	binary.LittleEndian.PutUint32(file[80:], 2)
+	copy(file[88:], "LTL-IEEE")
+
+	// the control area of the summary record, i.e., next = 0, previous = 0, and the number of summaries:
+	binary.LittleEndian.PutUint64(file[recordLength+16:], math.Float64bits(float64(len(segments))))
+
+	copy(file[recordLength+24:], summaries)
+
+	buffer := bytes.NewBuffer(file)
+
+	for _, w := range words {
+		binary.Write(buffer, binary.LittleEndian, w)
+	}
+
+	return buffer.Bytes()
+}
+
+/*****************************************************************************************************************/
+
+var segments = []segment{
+	// the Earth-Moon barycentre relative to the solar system barycentre (type 2, two records of one day):
+	{
+		target: EarthMoonBarycentre, center: SolarSystemBarycentre, kind: 2, init: -86400, intlen: 86400,
+		records: [][]float64{
+			{-43200, 43200, 1.4e8, 1000, 0, 2.0e7, -500, 0, 0, 0, 0},
+			{43200, 43200, 1.5e8, 1000, 10, 2.0e7, -500, 0, 0, 0, 1},
+		},
+	},
+	// the Earth relative to the Earth-Moon barycentre (type 3, a single record of two days):
+	{
+		target: Earth, center: EarthMoonBarycentre, kind: 3, init: -86400, intlen: 172800,
+		records: [][]float64{
+			{0, 86400, 4000, 0, -3000, 0, 100, 0, 0.01, 0, 0, 0, 0, 0},
+		},
+	},
+	// the Moon relative to the Earth-Moon barycentre (type 2, a single record of two days):
+	{
+		target: Moon, center: EarthMoonBarycentre, kind: 2, init: -86400, intlen: 172800,
+		records: [][]float64{
+			{0, 86400, 380000, 0, 0, 0, 0, 0},
+		},
+	},
+}
+
+/*****************************************************************************************************************/
+
+func TestNewEphemeris(t *testing.T) {
+	e, err := NewEphemeris(bytes.NewReader(writeSPK(segments)))
+
+	if err != nil {
+		t.Fatalf("got error %v", err)
+	}
+
+	if len(e.Segments) != 3 {
+		t.Fatalf("got %d segments, wanted 3", len(e.Segments))
+	}
+
+	s := e.Segments[0]
+
+	if s.Target != EarthMoonBarycentre || s.Center != SolarSystemBarycentre || s.Type != 2 || s.Frame != 1 {
+		t.Errorf("got %+v, wanted a type 2 segment for the Earth-Moon barycentre", s)
+	}
+
+	if s.Start != -86400 || s.End != 86400 || s.n != 2 || s.rsize != 11 {
+		t.Errorf("got %+v, wanted two records of 11 words from -86400 to 86400 seconds", s)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestNewEphemerisInvalidFile(t *testing.T) {
+	file := writeSPK(segments)
+
+	copy(file[0:], "NOT/SPK ")
+
+	if _, err := NewEphemeris(bytes.NewReader(file)); err == nil {
+		t.Errorf("expected an error for an invalid file identification word")
+	}
+
+	if _, err := NewEphemeris(bytes.NewReader([]byte("DAF/SPK "))); err == nil {
+		t.Errorf("expected an error for a truncated file")
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestNewEphemerisCorruptSummaryRecord(t *testing.T) {
+	// more summaries than fit within the summary record:
+	file := writeSPK(segments)
+
+	binary.LittleEndian.PutUint64(file[recordLength+16:], math.Float64bits(100))
+
+	if _, err := NewEphemeris(bytes.NewReader(file)); err == nil {
+		t.Errorf("expected an error for too many summaries in the summary record")
+	}
+
+	// a summary record which links back to itself:
+	file = writeSPK(segments)
+
+	binary.LittleEndian.PutUint64(file[recordLength:], math.Float64bits(2))
+
+	if _, err := NewEphemeris(bytes.NewReader(file)); err == nil {
+		t.Errorf("expected an error for a summary record which links back to itself")
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestNewEphemerisCorruptSegmentDirectory(t *testing.T) {
+	tests := map[string]segment{
+		"a record size of less than two words": {
+			target: Moon, center: EarthMoonBarycentre, kind: 2, init: -86400, intlen: 172800,
+			records: [][]float64{{0}},
+		},
+		"a record size without coefficients": {
+			target: Moon, center: EarthMoonBarycentre, kind: 2, init: -86400, intlen: 172800,
+			records: [][]float64{{0, 86400}},
+		},
+		"a zero interval length": {
+			target: Moon, center: EarthMoonBarycentre, kind: 2, init: -86400, intlen: 0,
+			records: [][]float64{{0, 86400, 380000, 0, 0, 0, 0, 0}},
+		},
+	}
+
+	for name, s := range tests {
+		if _, err := NewEphemeris(bytes.NewReader(writeSPK([]segment{s}))); err == nil {
+			t.Errorf("expected an error for %s", name)
+		}
+	}
+
+	// a number of records which overruns the segment, in the last word of the directory of the first segment:
+	file := writeSPK(segments)
+
+	binary.LittleEndian.PutUint64(file[(3*128+25)*8:], math.Float64bits(1000))
+
+	if _, err := NewEphemeris(bytes.NewReader(file)); err == nil {
+		t.Errorf("expected an error for a number of records which overruns the segment")
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestEvaluateChebyshevType2(t *testing.T) {
+	e, _ := NewEphemeris(bytes.NewReader(writeSPK(segments)))
+
+	// evaluate the second record at a quarter of its interval, i.e., s = -0.5:
+	p, v, err := e.evaluate(e.Segments[0], 21600)
+
+	if err != nil {
+		t.Fatalf("got error %v", err)
+	}
+
+	// x = 1.5e8 T0 + 1000 T1 + 10 T2, where T2(s) = 2s² - 1:
+	if want := 1.5e8 - 500 - 5; math.Abs(p.X-want) > 1e-6 {
+		t.Errorf("got %f, wanted %f", p.X, want)
+	}
+
+	if want := 2.0e7 + 250; math.Abs(p.Y-want) > 1e-6 {
+		t.Errorf("got %f, wanted %f", p.Y, want)
+	}
+
+	if want := -0.5; math.Abs(p.Z-want) > 1e-9 {
+		t.Errorf("got %f, wanted %f", p.Z, want)
+	}
+
+	// dx/dt = (1000 + 10 × 4s) / radius:
+	if want := (1000 - 20) / 43200.0; math.Abs(v.X-want) > 1e-12 {
+		t.Errorf("got %f, wanted %f", v.X, want)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestEvaluateChebyshevType3(t *testing.T) {
+	e, _ := NewEphemeris(bytes.NewReader(writeSPK(segments)))
+
+	p, v, err := e.evaluate(e.Segments[1], 1000)
+
+	if err != nil {
+		t.Fatalf("got error %v", err)
+	}
+
+	if p.X != 4000 || p.Y != -3000 || p.Z != 100 {
+		t.Errorf("got %+v, wanted (4000, -3000, 100)", p)
+	}
+
+	if v.X != 0.01 || v.Y != 0 || v.Z != 0 {
+		t.Errorf("got %+v, wanted (0.01, 0, 0)", v)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetState(t *testing.T) {
+	e, _ := NewEphemeris(bytes.NewReader(writeSPK(segments)))
+
+	p, _, err := e.GetState(datetime, Moon, Earth)
+
+	if err != nil {
+		t.Fatalf("got error %v", err)
+	}
+
+	// the Moon relative to the Earth is independent of the motion of the Earth-Moon barycentre:
+	if p.X != 376000 || p.Y != 3000 || p.Z != -100 {
+		t.Errorf("got %+v, wanted (376000, 3000, -100)", p)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetPosition(t *testing.T) {
+	e, _ := NewEphemeris(bytes.NewReader(writeSPK(segments)))
+
+	p, err := e.GetPosition(datetime, Earth, SolarSystemBarycentre)
+
+	if err != nil {
+		t.Fatalf("got error %v", err)
+	}
+
+	// the Earth is chained to the solar system barycentre via the Earth-Moon barycentre:
+	s := getEphemerisTime(datetime)/43200 - 1
+
+	if want := 1.5e8 + 1000*s + 10*(2*s*s-1) + 4000; math.Abs(p.X-want) > 1e-6 {
+		t.Errorf("got %f, wanted %f", p.X, want)
+	}
+
+	if _, err := e.GetPosition(datetime, MarsBarycentre, SolarSystemBarycentre); err == nil {
+		t.Errorf("expected an error for a body which is not covered by the ephemeris")
+	}
+
+	if _, err := e.GetPosition(datetime.Add(72*time.Hour), Earth, SolarSystemBarycentre); err == nil {
+		t.Errorf("expected an error for a time which is not covered by the ephemeris")
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetEquatorialCoordinate(t *testing.T) {
+	e, _ := NewEphemeris(bytes.NewReader(writeSPK(segments)))
+
+	eq, Δ, err := e.GetEquatorialCoordinate(datetime, Moon)
+
+	if err != nil {
+		t.Fatalf("got error %v", err)
+	}
+
+	want := math.Sqrt(376000*376000 + 3000*3000 + 100*100)
+
+	if math.Abs(Δ-want) > 0.1 {
+		t.Errorf("got %f, wanted %f", Δ, want)
+	}
+
+	if α := 180 / math.Pi * math.Atan2(3000, 376000); math.Abs(eq.RightAscension-α) > 0.0001 {
+		t.Errorf("got %f, wanted %f", eq.RightAscension, α)
+	}
+
+	if δ := 180 / math.Pi * math.Asin(-100/want); math.Abs(eq.Declination-δ) > 0.0001 {
+		t.Errorf("got %f, wanted %f", eq.Declination, δ)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestOpen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "synthetic.bsp")
+
+	if err := os.WriteFile(path, writeSPK(segments), 0o644); err != nil {
+		t.Fatalf("got error %v", err)
+	}
+
+	e, err := Open(path)
+
+	if err != nil {
+		t.Fatalf("got error %v", err)
+	}
+
+	defer e.Close()
+
+	if len(e.Segments) != 3 {
+		t.Errorf("got %d segments, wanted 3", len(e.Segments))
+	}
+
+	if _, err := Open(filepath.Join(t.TempDir(), "missing.bsp")); err == nil {
+		t.Errorf("expected an error for a missing file")
+	}
+}
+
+/*****************************************************************************************************************/
@@ -0,0 +1,314 @@
+/*****************************************************************************************************************/
+
+//	@author		Michael Roberts <michael@observerly.com>
+//	@package	@observerly/sidera
+//	@license	Copyright © 2021-2024 observerly
+
+/*****************************************************************************************************************/
+
+package moon
+
+/*****************************************************************************************************************/
+
+import (
+	"math"
+	"time"
+
+	"github.com/observerly/sidera/pkg/astrometry"
+	"github.com/observerly/sidera/pkg/common"
+	sun "github.com/observerly/sidera/pkg/solar"
+)
+
+/*****************************************************************************************************************/
+
+// the inclination of the mean lunar equator to the ecliptic, in degrees:
+const LUNAR_EQUATOR_INCLINATION float64 = 1.54242
+
+/*****************************************************************************************************************/
+
+/*
+a selenographic coordinate, i.e., a position on the surface of the Moon, in degrees
+
+The selenographic longitude is measured from the mean centre of the Moon's disk (the mean sub-Earth point),
+positive towards Mare Crisium, i.e., towards the east on the Moon's surface (and the west in the Earth's sky).
+The selenographic latitude is positive towards the north, i.e., towards Mare Frigoris.
+*/
+type SelenographicCoordinate struct {
+	Longitude float64
+	Latitude  float64
+}
+
+/*****************************************************************************************************************/
+
+/*
+the physical librations of the Moon (ρ, σ and τ) for a given datetime, in degrees
+
+The physical librations are the small oscillations of the Moon's rotation about its mean motion, caused by
+the torque of the Earth (and Sun) on the Moon's non-spherical figure, and amount to at most a few hundredths
+of a degree. See Meeus, "Astronomical Algorithms", Chapter 53.
+*/
+func getPhysicalLibrationTerms(datetime time.Time) (ρ float64, σ float64, τ float64) {
+	T := getJulianCenturies(datetime)
+
+	D := common.Radians(GetMeanElongation(datetime))
+
+	// the mean anomaly of the Sun:
+	M := common.Radians(normalise(357.5291092 + 35999.0502909*T - 0.0001536*math.Pow(T, 2) +
+		math.Pow(T, 3)/24490000))
+
+	Mʹ := common.Radians(GetMeanAnomaly(datetime))
+
+	F := common.Radians(GetArgumentOfLatitude(datetime))
+
+	Ω := common.Radians(GetLongitudeOfAscendingNode(datetime))
+
+	// the decreasing eccentricity of the Earth's orbit:
+	E := 1 - 0.002516*T - 0.0000074*math.Pow(T, 2)
+
+	K1 := common.Radians(119.75 + 131.849*T)
+
+	K2 := common.Radians(72.56 + 20.186*T)
+
+	ρ = -0.02752*math.Cos(Mʹ) - 0.02245*math.Sin(F) + 0.00684*math.Cos(Mʹ-2*F) - 0.00293*math.Cos(2*F) -
+		0.00085*math.Cos(2*F-2*D) - 0.00054*math.Cos(Mʹ-2*D) - 0.00020*math.Sin(Mʹ+F) - 0.00020*math.Cos(Mʹ+2*F) -
+		0.00020*math.Cos(Mʹ-F) + 0.00014*math.Cos(Mʹ+2*F-2*D)
+
+	σ = -0.02816*math.Sin(Mʹ) + 0.02244*math.Cos(F) - 0.00682*math.Sin(Mʹ-2*F) - 0.00279*math.Sin(2*F) -
+		0.00083*math.Sin(2*F-2*D) + 0.00069*math.Sin(Mʹ-2*D) + 0.00040*math.Cos(Mʹ+F) - 0.00025*math.Sin(2*Mʹ) -
+		0.00023*math.Sin(Mʹ+2*F) + 0.00020*math.Cos(Mʹ-F) + 0.00019*math.Sin(Mʹ-F) + 0.00013*math.Sin(Mʹ+2*F-2*D) -
+		0.00010*math.Cos(Mʹ-3*F)
+
+	τ = 0.02520*E*math.Sin(M) + 0.00473*math.Sin(2*Mʹ-2*F) - 0.00467*math.Sin(Mʹ) + 0.00396*math.Sin(K1) +
+		0.00276*math.Sin(2*Mʹ-2*D) + 0.00196*math.Sin(Ω) - 0.00183*math.Cos(Mʹ-F) + 0.00115*math.Sin(Mʹ-2*D) -
+		0.00096*math.Sin(Mʹ-D) + 0.00046*math.Sin(2*F-2*D) - 0.00039*math.Sin(Mʹ-F) - 0.00032*math.Sin(Mʹ-M-D) +
+		0.00027*math.Sin(2*Mʹ-M-2*D) + 0.00023*math.Sin(K2) - 0.00014*math.Sin(2*D) + 0.00014*math.Cos(2*Mʹ-2*F) -
+		0.00012*math.Sin(Mʹ-2*F) - 0.00012*math.Sin(2*Mʹ) + 0.00011*math.Sin(2*Mʹ-2*M-2*D)
+
+	return ρ, σ, τ
+}
+
+/*****************************************************************************************************************/
+
+/*
+the optical and physical librations for a body seen in a given direction from the Moon, as the selenographic
+coordinate of the point on the Moon's surface beneath that body
+
+The direction is the apparent ecliptic longitude and latitude of the body as seen from the centre of the Moon,
+reversed, i.e., the apparent geocentric ecliptic coordinate of the Moon in the case of the Earth.
+*/
+func getLibrations(datetime time.Time, λ float64, β float64) (optical SelenographicCoordinate, physical SelenographicCoordinate) {
+	Δψ, _ := astrometry.GetNutation(datetime)
+
+	I := common.Radians(LUNAR_EQUATOR_INCLINATION)
+
+	Ω := common.Radians(GetLongitudeOfAscendingNode(datetime))
+
+	F := common.Radians(GetArgumentOfLatitude(datetime))
+
+	W := common.Radians(λ-Δψ) - Ω
+
+	b := common.Radians(β)
+
+	A := math.Atan2(math.Sin(W)*math.Cos(b)*math.Cos(I)-math.Sin(b)*math.Sin(I), math.Cos(W)*math.Cos(b))
+
+	optical = SelenographicCoordinate{
+		Longitude: math.Remainder(common.Degrees(A-F), 360),
+		Latitude:  common.Degrees(math.Asin(-math.Sin(W)*math.Cos(b)*math.Sin(I) - math.Sin(b)*math.Cos(I))),
+	}
+
+	ρ, σ, τ := getPhysicalLibrationTerms(datetime)
+
+	physical = SelenographicCoordinate{
+		Longitude: -τ + (ρ*math.Cos(A)+σ*math.Sin(A))*math.Tan(common.Radians(optical.Latitude)),
+		Latitude:  σ*math.Cos(A) - ρ*math.Sin(A),
+	}
+
+	return optical, physical
+}
+
+/*****************************************************************************************************************/
+
+/*
+the apparent geocentric ecliptic coordinate of the Moon for a given datetime, referred to the true equinox of
+date, i.e., including the nutation in longitude
+*/
+func getApparentEclipticCoordinate(datetime time.Time) common.EclipticCoordinate {
+	ec := GetEclipticCoordinate(datetime)
+
+	Δψ, _ := astrometry.GetNutation(datetime)
+
+	return common.EclipticCoordinate{
+		Longitude: normalise(ec.Longitude + Δψ),
+		Latitude:  ec.Latitude,
+	}
+}
+
+/*****************************************************************************************************************/
+
+/*
+the Optical Libration of the Moon for a given datetime, in degrees
+
+The Lunar Optical Libration is the apparent rocking of the Moon as seen from the centre of the Earth, which
+arises from the eccentricity of the Moon's orbit (in longitude) and the inclination of the Moon's equator to its
+orbit (in latitude), and amounts to around ±7.9 degrees in longitude and ±6.9 degrees in latitude.
+*/
+func GetOpticalLibration(datetime time.Time) SelenographicCoordinate {
+	ec := getApparentEclipticCoordinate(datetime)
+
+	optical, _ := getLibrations(datetime, ec.Longitude, ec.Latitude)
+
+	return optical
+}
+
+/*****************************************************************************************************************/
+
+/*
+the Physical Libration of the Moon for a given datetime, in degrees
+
+The Lunar Physical Libration is the small real oscillation of the Moon's rotation about its mean motion, and
+amounts to only a few hundredths of a degree.
+*/
+func GetPhysicalLibration(datetime time.Time) SelenographicCoordinate {
+	ec := getApparentEclipticCoordinate(datetime)
+
+	_, physical := getLibrations(datetime, ec.Longitude, ec.Latitude)
+
+	return physical
+}
+
+/*****************************************************************************************************************/
+
+/*
+the total Libration of the Moon for a given datetime, in degrees
+
+The Lunar Libration is the sum of the optical and physical librations, and is the selenographic coordinate of
+the point on the Moon's surface at the centre of its disk as seen from the centre of the Earth. A positive
+libration in longitude turns the eastern limb (Mare Crisium) towards the Earth, and a positive libration in
+latitude turns the northern limb towards the Earth.
+*/
+func GetLibration(datetime time.Time) SelenographicCoordinate {
+	ec := getApparentEclipticCoordinate(datetime)
+
+	optical, physical := getLibrations(datetime, ec.Longitude, ec.Latitude)
+
+	return SelenographicCoordinate{
+		Longitude: optical.Longitude + physical.Longitude,
+		Latitude:  optical.Latitude + physical.Latitude,
+	}
+}
+
+/*****************************************************************************************************************/
+
+/*
+the Position Angle of the Moon's Axis of rotation for a given datetime, in degrees
+
+The Lunar Position Angle of the Axis is the angle of the northern end of the Moon's axis of rotation, measured
+from the north point of the Moon's disk towards the east, as seen from the centre of the Earth. It varies
+between approximately -25 and +25 degrees.
+*/
+func GetPositionAngleOfAxis(datetime time.Time) float64 {
+	Δψ, Δε := astrometry.GetNutation(datetime)
+
+	// the true obliquity of the ecliptic:
+	ε := common.Radians(astrometry.GetObliquityOfTheEcliptic(datetime) + Δε)
+
+	I := common.Radians(LUNAR_EQUATOR_INCLINATION)
+
+	Ω := GetLongitudeOfAscendingNode(datetime)
+
+	ρ, σ, _ := getPhysicalLibrationTerms(datetime)
+
+	V := common.Radians(Ω + Δψ + σ/math.Sin(I))
+
+	X := math.Sin(I+common.Radians(ρ)) * math.Sin(V)
+
+	Y := math.Sin(I+common.Radians(ρ))*math.Cos(V)*math.Cos(ε) - math.Cos(I+common.Radians(ρ))*math.Sin(ε)
+
+	ω := math.Atan2(X, Y)
+
+	// the apparent right ascension of the Moon, referred to the true equator and equinox of date:
+	ec := getApparentEclipticCoordinate(datetime)
+
+	λ := common.Radians(ec.Longitude)
+
+	β := common.Radians(ec.Latitude)
+
+	α := math.Atan2(math.Sin(λ)*math.Cos(ε)-math.Tan(β)*math.Sin(ε), math.Cos(λ))
+
+	b := common.Radians(GetLibration(datetime).Latitude)
+
+	return common.Degrees(math.Asin(math.Hypot(X, Y) * math.Cos(α-ω) / math.Cos(b)))
+}
+
+/*****************************************************************************************************************/
+
+/*
+the Selenographic Coordinate of the Sun for a given datetime, in degrees
+
+The selenographic coordinate of the Sun is the point on the Moon's surface at which the Sun is at the zenith,
+i.e., the subsolar point. It is found as for the libration of the Moon, from the direction of the Sun as seen
+from the Moon, which differs from its direction as seen from the Earth by up to around 0.15 degrees.
+*/
+func GetSelenographicCoordinateOfSun(datetime time.Time) SelenographicCoordinate {
+	ec := getApparentEclipticCoordinate(datetime)
+
+	Δψ, _ := astrometry.GetNutation(datetime)
+
+	R := sun.GetDistance(datetime) * common.AU
+
+	// the apparent longitude of the Sun, corrected for nutation and annual aberration:
+	λ0 := sun.GetTrueEclipticLongitude(datetime) + Δψ - 20.4898/3600/sun.GetDistance(datetime)
+
+	Δ := GetDistance(datetime)
+
+	β := common.Radians(ec.Latitude)
+
+	// the heliocentric (selenocentric) ecliptic coordinate of the Moon:
+	λH := λ0 + 180 + Δ/R*common.Degrees(math.Cos(β)*math.Sin(common.Radians(λ0-ec.Longitude)))
+
+	βH := Δ / R * ec.Latitude
+
+	optical, physical := getLibrations(datetime, λH, βH)
+
+	return SelenographicCoordinate{
+		Longitude: optical.Longitude + physical.Longitude,
+		Latitude:  optical.Latitude + physical.Latitude,
+	}
+}
+
+/*****************************************************************************************************************/
+
+/*
+the Selenographic Colongitude of the Sun for a given datetime, in degrees
+
+The Selenographic Colongitude is the selenographic longitude of the morning terminator on the Moon's equator,
+measured westward, and is 90 degrees less the selenographic longitude of the subsolar point. It is
+approximately 0 at first quarter, 90 at full moon, 180 at last quarter and 270 at new moon, and increases by
+around 12.2 degrees per day.
+*/
+func GetSelenographicColongitude(datetime time.Time) float64 {
+	return normalise(90 - GetSelenographicCoordinateOfSun(datetime).Longitude)
+}
+
+/*****************************************************************************************************************/
+
+/*
+the altitude of the Sun above the local horizon at a given selenographic coordinate on the Moon's surface, for
+a given datetime, in degrees
+
+The altitude is zero on the terminator, positive on the sunlit side, and negative on the night side. Low
+positive altitudes of a few degrees cast the long shadows which show the relief of a crater most clearly.
+*/
+func GetSelenographicSolarAltitude(datetime time.Time, coordinate SelenographicCoordinate) float64 {
+	s := GetSelenographicCoordinateOfSun(datetime)
+
+	b0 := common.Radians(s.Latitude)
+
+	β := common.Radians(coordinate.Latitude)
+
+	Δλ := common.Radians(coordinate.Longitude - s.Longitude)
+
+	return common.Degrees(math.Asin(math.Sin(b0)*math.Sin(β) + math.Cos(b0)*math.Cos(β)*math.Cos(Δλ)))
+}
+
+/*****************************************************************************************************************/
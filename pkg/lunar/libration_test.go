@@ -0,0 +1,139 @@
+/*****************************************************************************************************************/
+
+//	@author		Michael Roberts <michael@observerly.com>
+//	@package	@observerly/sidera
+//	@license	Copyright © 2021-2024 observerly
+
+/*****************************************************************************************************************/
+
+package moon
+
+/*****************************************************************************************************************/
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+/*****************************************************************************************************************/
+
+func TestGetOpticalLibration(t *testing.T) {
+	// See Meeus, "Astronomical Algorithms", Example 53.a, for 1992 April 12 0h TT:
+	var got = GetOpticalLibration(datetime)
+
+	if math.Abs(got.Longitude-(-1.206)) > 0.001 {
+		t.Errorf("got %f, wanted %f", got.Longitude, -1.206)
+	}
+
+	if math.Abs(got.Latitude-4.194) > 0.001 {
+		t.Errorf("got %f, wanted %f", got.Latitude, 4.194)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetPhysicalLibration(t *testing.T) {
+	var got = GetPhysicalLibration(datetime)
+
+	if math.Abs(got.Longitude-(-0.025)) > 0.001 {
+		t.Errorf("got %f, wanted %f", got.Longitude, -0.025)
+	}
+
+	if math.Abs(got.Latitude-0.006) > 0.001 {
+		t.Errorf("got %f, wanted %f", got.Latitude, 0.006)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetLibration(t *testing.T) {
+	var got = GetLibration(datetime)
+
+	if math.Abs(got.Longitude-(-1.23)) > 0.005 {
+		t.Errorf("got %f, wanted %f", got.Longitude, -1.23)
+	}
+
+	if math.Abs(got.Latitude-4.20) > 0.005 {
+		t.Errorf("got %f, wanted %f", got.Latitude, 4.20)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetLibrationRange(t *testing.T) {
+	// the libration remains within its well known limits over the course of a year:
+	for d := 0; d < 365; d++ {
+		l := GetLibration(datetime.Add(time.Duration(d) * 24 * time.Hour))
+
+		if math.Abs(l.Longitude) > 8.2 || math.Abs(l.Latitude) > 7 {
+			t.Errorf("got %+v, wanted a libration within ±8.2° in longitude and ±7° in latitude", l)
+		}
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetPositionAngleOfAxis(t *testing.T) {
+	var got float64 = GetPositionAngleOfAxis(datetime)
+
+	if math.Abs(got-15.08) > 0.005 {
+		t.Errorf("got %f, wanted %f", got, 15.08)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetSelenographicCoordinateOfSun(t *testing.T) {
+	var got = GetSelenographicCoordinateOfSun(datetime)
+
+	if math.Abs(got.Longitude-67.89) > 0.01 {
+		t.Errorf("got %f, wanted %f", got.Longitude, 67.89)
+	}
+
+	if math.Abs(got.Latitude-1.46) > 0.01 {
+		t.Errorf("got %f, wanted %f", got.Latitude, 1.46)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetSelenographicColongitude(t *testing.T) {
+	var got float64 = GetSelenographicColongitude(datetime)
+
+	if math.Abs(got-22.11) > 0.01 {
+		t.Errorf("got %f, wanted %f", got, 22.11)
+	}
+
+	// the colongitude increases by around 12.2 degrees per day:
+	Δ := math.Mod(GetSelenographicColongitude(datetime.Add(24*time.Hour))-got+360, 360)
+
+	if math.Abs(Δ-12.2) > 0.3 {
+		t.Errorf("got %f, wanted %f", Δ, 12.2)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetSelenographicSolarAltitude(t *testing.T) {
+	s := GetSelenographicCoordinateOfSun(datetime)
+
+	// the Sun is at the zenith of the subsolar point:
+	if got := GetSelenographicSolarAltitude(datetime, s); math.Abs(got-90) > 1e-6 {
+		t.Errorf("got %f, wanted %f", got, 90.0)
+	}
+
+	// the morning terminator on the equator lies at a longitude of minus the colongitude:
+	c := GetSelenographicColongitude(datetime)
+
+	if got := GetSelenographicSolarAltitude(datetime, SelenographicCoordinate{Longitude: -c, Latitude: 0}); math.Abs(got) > 0.05 {
+		t.Errorf("got %f, wanted %f", got, 0.0)
+	}
+
+	// Copernicus (9.62°N, 20.08°W) lies just inside the morning terminator, in the low Sun:
+	if got := GetSelenographicSolarAltitude(datetime, SelenographicCoordinate{Longitude: -20.08, Latitude: 9.62}); got < 0 || got > 5 {
+		t.Errorf("got %f, wanted a low positive altitude", got)
+	}
+}
+
+/*****************************************************************************************************************/
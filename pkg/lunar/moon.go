@@ -0,0 +1,459 @@
+/*****************************************************************************************************************/
+
+//	@author		Michael Roberts <michael@observerly.com>
+//	@package	@observerly/sidera
+//	@license	Copyright © 2021-2024 observerly
+
+/*****************************************************************************************************************/
+
+package moon
+
+/*****************************************************************************************************************/
+
+import (
+	"math"
+	"time"
+
+	"github.com/observerly/sidera/pkg/common"
+	"github.com/observerly/sidera/pkg/coordinates"
+	"github.com/observerly/sidera/pkg/epoch"
+	sun "github.com/observerly/sidera/pkg/solar"
+)
+
+/*****************************************************************************************************************/
+
+/*
+the periodic terms for the longitude (Σl, in 0.000001 degrees) and distance (Σr, in 0.001 km) of the Moon
+
+Each term is a multiple of the mean elongation of the Moon (D), the mean anomaly of the Sun (M), the mean
+anomaly of the Moon (M') and the Moon's argument of latitude (F). See Meeus, "Astronomical Algorithms",
+Table 47.A, derived from the ELP-2000/82 lunar theory of Chapront-Touzé and Chapront.
+*/
+var longitudeAndDistanceTerms = [][6]float64{
+	{0, 0, 1, 0, 6288774, -20905355},
+	{2, 0, -1, 0, 1274027, -3699111},
+	{2, 0, 0, 0, 658314, -2955968},
+	{0, 0, 2, 0, 213618, -569925},
+	{0, 1, 0, 0, -185116, 48888},
+	{0, 0, 0, 2, -114332, -3149},
+	{2, 0, -2, 0, 58793, 246158},
+	{2, -1, -1, 0, 57066, -152138},
+	{2, 0, 1, 0, 53322, -170733},
+	{2, -1, 0, 0, 45758, -204586},
+	{0, 1, -1, 0, -40923, -129620},
+	{1, 0, 0, 0, -34720, 108743},
+	{0, 1, 1, 0, -30383, 104755},
+	{2, 0, 0, -2, 15327, 10321},
+	{0, 0, 1, 2, -12528, 0},
+	{0, 0, 1, -2, 10980, 79661},
+	{4, 0, -1, 0, 10675, -34782},
+	{0, 0, 3, 0, 10034, -23210},
+	{4, 0, -2, 0, 8548, -21636},
+	{2, 1, -1, 0, -7888, 24208},
+	{2, 1, 0, 0, -6766, 30824},
+	{1, 0, -1, 0, -5163, -8379},
+	{1, 1, 0, 0, 4987, -16675},
+	{2, -1, 1, 0, 4036, -12831},
+	{2, 0, 2, 0, 3994, -10445},
+	{4, 0, 0, 0, 3861, -11650},
+	{2, 0, -3, 0, 3665, 14403},
+	{0, 1, -2, 0, -2689, -7003},
+	{2, 0, -1, 2, -2602, 0},
+	{2, -1, -2, 0, 2390, 10056},
+	{1, 0, 1, 0, -2348, 6322},
+	{2, -2, 0, 0, 2236, -9884},
+	{0, 1, 2, 0, -2120, 5751},
+	{0, 2, 0, 0, -2069, 0},
+	{2, -2, -1, 0, 2048, -4950},
+	{2, 0, 1, -2, -1773, 4130},
+	{2, 0, 0, 2, -1595, 0},
+	{4, -1, -1, 0, 1215, -3958},
+	{0, 0, 2, 2, -1110, 0},
+	{3, 0, -1, 0, -892, 3258},
+	{2, 1, 1, 0, -810, 2616},
+	{4, -1, -2, 0, 759, -1897},
+	{0, 2, -1, 0, -713, -2117},
+	{2, 2, -1, 0, -700, 2354},
+	{2, 1, -2, 0, 691, 0},
+	{2, -1, 0, -2, 596, 0},
+	{4, 0, 1, 0, 549, -1423},
+	{0, 0, 4, 0, 537, -1117},
+	{4, -1, 0, 0, 520, -1571},
+	{1, 0, -2, 0, -487, -1739},
+	{2, 1, 0, -2, -399, 0},
+	{0, 0, 2, -2, -381, -4421},
+	{1, 1, 1, 0, 351, 0},
+	{3, 0, -2, 0, -340, 0},
+	{4, 0, -3, 0, 330, 0},
+	{2, -1, 2, 0, 327, 0},
+	{0, 2, 1, 0, -323, 1165},
+	{1, 1, -1, 0, 299, 0},
+	{2, 0, 3, 0, 294, 0},
+	{2, 0, -1, -2, 0, 8752},
+}
+
+/*****************************************************************************************************************/
+
+/*
+the periodic terms for the latitude (Σb, in 0.000001 degrees) of the Moon
+
+Each term is a multiple of the mean elongation of the Moon (D), the mean anomaly of the Sun (M), the mean
+anomaly of the Moon (M') and the Moon's argument of latitude (F). See Meeus, "Astronomical Algorithms",
+Table 47.B.
+*/
+var latitudeTerms = [][5]float64{
+	{0, 0, 0, 1, 5128122},
+	{0, 0, 1, 1, 280602},
+	{0, 0, 1, -1, 277693},
+	{2, 0, 0, -1, 173237},
+	{2, 0, -1, 1, 55413},
+	{2, 0, -1, -1, 46271},
+	{2, 0, 0, 1, 32573},
+	{0, 0, 2, 1, 17198},
+	{2, 0, 1, -1, 9266},
+	{0, 0, 2, -1, 8822},
+	{2, -1, 0, -1, 8216},
+	{2, 0, -2, -1, 4324},
+	{2, 0, 1, 1, 4200},
+	{2, 1, 0, -1, -3359},
+	{2, -1, -1, 1, 2463},
+	{2, -1, 0, 1, 2211},
+	{2, -1, -1, -1, 2065},
+	{0, 1, -1, -1, -1870},
+	{4, 0, -1, -1, 1828},
+	{0, 1, 0, 1, -1794},
+	{0, 0, 0, 3, -1749},
+	{0, 1, -1, 1, -1565},
+	{1, 0, 0, 1, -1491},
+	{0, 1, 1, 1, -1475},
+	{0, 1, 1, -1, -1410},
+	{0, 1, 0, -1, -1344},
+	{1, 0, 0, -1, -1335},
+	{0, 0, 3, 1, 1107},
+	{4, 0, 0, -1, 1021},
+	{4, 0, -1, 1, 833},
+	{0, 0, 1, -3, 777},
+	{4, 0, -2, 1, 671},
+	{2, 0, 0, -3, 607},
+	{2, 0, 2, -1, 596},
+	{2, -1, 1, -1, 491},
+	{2, 0, -2, 1, -451},
+	{0, 0, 3, -1, 439},
+	{2, 0, 2, 1, 422},
+	{2, 0, -3, -1, 421},
+	{2, 1, -1, 1, -366},
+	{2, 1, 0, 1, -351},
+	{4, 0, 0, 1, 331},
+	{2, -1, 1, 1, 315},
+	{2, -2, 0, -1, 302},
+	{0, 0, 1, 3, -283},
+	{2, 1, 1, -1, -229},
+	{1, 1, 0, -1, 223},
+	{1, 1, 0, 1, 223},
+	{0, 1, -2, -1, -220},
+	{2, 1, -1, -1, -220},
+	{1, 0, 1, 1, -185},
+	{2, -1, -2, -1, 181},
+	{0, 1, 2, 1, -177},
+	{4, 0, -2, -1, 176},
+	{4, -1, -1, -1, 166},
+	{1, 0, 1, -1, -164},
+	{4, 0, 1, -1, 132},
+	{1, 0, -1, -1, -119},
+	{4, -1, 0, -1, 115},
+	{2, -2, 0, 1, 107},
+}
+
+/*****************************************************************************************************************/
+
+/*
+the number of Julian centuries since J2000.0, in Terrestrial Time, for a given datetime
+
+The Moon moves by around half an arcsecond per second of time, and so the lunar theory is evaluated in the
+uniform time scale of Terrestrial Time (TT), rather than in Universal Time.
+*/
+func getJulianCenturies(datetime time.Time) float64 {
+	return (epoch.GetTerrestrialTimeJulianDate(datetime) - epoch.J2000) / 36525
+}
+
+/*****************************************************************************************************************/
+
+/*
+the Mean Longitude of the Moon for a given datetime, in degrees
+
+The Lunar Mean Longitude is the ecliptic longitude at which the Moon would be found if its orbit were circular
+and uninclined, referred to the mean equinox of date.
+*/
+func GetMeanLongitude(datetime time.Time) float64 {
+	T := getJulianCenturies(datetime)
+
+	L := 218.3164477 + 481267.88123421*T - 0.0015786*math.Pow(T, 2) + math.Pow(T, 3)/538841 -
+		math.Pow(T, 4)/65194000
+
+	return normalise(L)
+}
+
+/*****************************************************************************************************************/
+
+/*
+the Mean Elongation of the Moon for a given datetime, in degrees
+
+The Lunar Mean Elongation is the difference between the mean longitudes of the Moon and the Sun, and
+increases by 360 degrees over each synodic month.
+*/
+func GetMeanElongation(datetime time.Time) float64 {
+	T := getJulianCenturies(datetime)
+
+	D := 297.8501921 + 445267.1114034*T - 0.0018819*math.Pow(T, 2) + math.Pow(T, 3)/545868 -
+		math.Pow(T, 4)/113065000
+
+	return normalise(D)
+}
+
+/*****************************************************************************************************************/
+
+/*
+the Mean Anomaly of the Moon for a given datetime, in degrees
+
+The Lunar Mean Anomaly is the angle between the perigee of the Moon's orbit and the mean position of the
+Moon along its orbit around the Earth, and increases by 360 degrees over each anomalistic month.
+*/
+func GetMeanAnomaly(datetime time.Time) float64 {
+	T := getJulianCenturies(datetime)
+
+	M := 134.9633964 + 477198.8675055*T + 0.0087414*math.Pow(T, 2) + math.Pow(T, 3)/69699 -
+		math.Pow(T, 4)/14712000
+
+	return normalise(M)
+}
+
+/*****************************************************************************************************************/
+
+/*
+the Argument of Latitude of the Moon for a given datetime, in degrees
+
+The Lunar Argument of Latitude is the mean distance of the Moon from its ascending node, and increases by
+360 degrees over each draconic month.
+*/
+func GetArgumentOfLatitude(datetime time.Time) float64 {
+	T := getJulianCenturies(datetime)
+
+	F := 93.2720950 + 483202.0175233*T - 0.0036539*math.Pow(T, 2) - math.Pow(T, 3)/3526000 +
+		math.Pow(T, 4)/863310000
+
+	return normalise(F)
+}
+
+/*****************************************************************************************************************/
+
+/*
+the Longitude of the Ascending Node of the Moon's mean orbit for a given datetime, in degrees
+
+The ascending node of the Moon's orbit regresses westward along the ecliptic, completing one revolution
+every 18.6 years.
+*/
+func GetLongitudeOfAscendingNode(datetime time.Time) float64 {
+	T := getJulianCenturies(datetime)
+
+	Ω := 125.0445479 - 1934.1362891*T + 0.0020754*math.Pow(T, 2) + math.Pow(T, 3)/467441 -
+		math.Pow(T, 4)/60616000
+
+	return normalise(Ω)
+}
+
+/*****************************************************************************************************************/
+
+/*
+the periodic sums for the longitude (Σl, 0.000001 degrees), latitude (Σb, 0.000001 degrees) and distance
+(Σr, 0.001 km) of the Moon for a given datetime
+*/
+func getPeriodicTerms(datetime time.Time) (Σl float64, Σb float64, Σr float64) {
+	T := getJulianCenturies(datetime)
+
+	L := common.Radians(GetMeanLongitude(datetime))
+
+	D := common.Radians(GetMeanElongation(datetime))
+
+	// the mean anomaly of the Sun:
+	M := common.Radians(normalise(357.5291092 + 35999.0502909*T - 0.0001536*math.Pow(T, 2) +
+		math.Pow(T, 3)/24490000))
+
+	Mʹ := common.Radians(GetMeanAnomaly(datetime))
+
+	F := common.Radians(GetArgumentOfLatitude(datetime))
+
+	// the effects of Venus (A1), Jupiter (A2) and the flattening of the Earth (A3):
+	A1 := common.Radians(119.75 + 131.849*T)
+	A2 := common.Radians(53.09 + 479264.290*T)
+	A3 := common.Radians(313.45 + 481266.484*T)
+
+	// the decreasing eccentricity of the Earth's orbit, which scales the terms involving M:
+	E := 1 - 0.002516*T - 0.0000074*math.Pow(T, 2)
+
+	for _, term := range longitudeAndDistanceTerms {
+		arg := term[0]*D + term[1]*M + term[2]*Mʹ + term[3]*F
+
+		e := math.Pow(E, math.Abs(term[1]))
+
+		Σl += term[4] * e * math.Sin(arg)
+		Σr += term[5] * e * math.Cos(arg)
+	}
+
+	for _, term := range latitudeTerms {
+		arg := term[0]*D + term[1]*M + term[2]*Mʹ + term[3]*F
+
+		Σb += term[4] * math.Pow(E, math.Abs(term[1])) * math.Sin(arg)
+	}
+
+	Σl += 3958*math.Sin(A1) + 1962*math.Sin(L-F) + 318*math.Sin(A2)
+
+	Σb += -2235*math.Sin(L) + 382*math.Sin(A3) + 175*math.Sin(A1-F) + 175*math.Sin(A1+F) +
+		127*math.Sin(L-Mʹ) - 115*math.Sin(L+Mʹ)
+
+	return Σl, Σb, Σr
+}
+
+/*****************************************************************************************************************/
+
+/*
+the Ecliptic Coordinate of the Moon for a given datetime
+
+The Lunar Ecliptic Coordinate is the geocentric position of the Moon referred to the mean ecliptic and
+equinox of date, accurate to around 10 arcseconds in longitude and 4 arcseconds in latitude.
+*/
+func GetEclipticCoordinate(datetime time.Time) common.EclipticCoordinate {
+	Σl, Σb, _ := getPeriodicTerms(datetime)
+
+	return common.EclipticCoordinate{
+		Longitude: normalise(GetMeanLongitude(datetime) + Σl/1000000),
+		Latitude:  Σb / 1000000,
+	}
+}
+
+/*****************************************************************************************************************/
+
+/*
+the Distance of the Moon from the centre of the Earth for a given datetime, in kilometres
+
+The Lunar Distance varies between approximately 356,500 km at perigee and 406,700 km at apogee.
+*/
+func GetDistance(datetime time.Time) float64 {
+	_, _, Σr := getPeriodicTerms(datetime)
+
+	return 385000.56 + Σr/1000
+}
+
+/*****************************************************************************************************************/
+
+/*
+the Equatorial Horizontal Parallax of the Moon for a given datetime, in degrees
+
+The Lunar Horizontal Parallax is the angle subtended by the Earth's equatorial radius at the distance of the
+Moon, and varies between approximately 0.90 and 1.02 degrees.
+*/
+func GetHorizontalParallax(datetime time.Time) float64 {
+	return common.Degrees(math.Asin(common.EARTH_EQUATORIAL_RADIUS / GetDistance(datetime)))
+}
+
+/*****************************************************************************************************************/
+
+/*
+the geocentric Semi-Diameter of the Moon for a given datetime, in degrees
+
+The Lunar Semi-Diameter is the angle subtended by the Moon's radius (k = 0.272481 Earth equatorial radii) as
+seen from the centre of the Earth, and varies between approximately 0.245 and 0.279 degrees.
+*/
+func GetSemiDiameter(datetime time.Time) float64 {
+	return common.Degrees(math.Asin(0.272481 * common.EARTH_EQUATORIAL_RADIUS / GetDistance(datetime)))
+}
+
+/*****************************************************************************************************************/
+
+/*
+the Phase Angle of the Moon for a given datetime, in degrees
+
+The Lunar Phase Angle is the angle between the Sun and the Earth as seen from the Moon, and is zero at full Moon
+and 180 degrees at new Moon. See Meeus, "Astronomical Algorithms", Chapter 48.
+*/
+func GetPhaseAngle(datetime time.Time) float64 {
+	ec := GetEclipticCoordinate(datetime)
+
+	// the apparent longitude of the Sun, corrected for the annual aberration of -20.4898" / R:
+	λ0 := sun.GetTrueEclipticLongitude(datetime) - 20.4898/3600/sun.GetDistance(datetime)
+
+	// the geocentric elongation of the Moon from the Sun:
+	ψ := math.Acos(math.Cos(common.Radians(ec.Latitude)) * math.Cos(common.Radians(ec.Longitude-λ0)))
+
+	// the distances of the Sun and the Moon from the Earth, in kilometres:
+	R := sun.GetDistance(datetime) * common.AU
+
+	Δ := GetDistance(datetime)
+
+	return common.Degrees(math.Atan2(R*math.Sin(ψ), Δ-R*math.Cos(ψ)))
+}
+
+/*****************************************************************************************************************/
+
+/*
+the Illuminated Fraction of the disk of the Moon for a given datetime
+
+The Lunar Illuminated Fraction is the ratio of the illuminated area of the disk to the total area of the disk,
+as seen from the Earth, and ranges from 0 (new) to 1 (full).
+*/
+func GetIlluminatedFraction(datetime time.Time) float64 {
+	i := common.Radians(GetPhaseAngle(datetime))
+
+	return (1 + math.Cos(i)) / 2
+}
+
+/*****************************************************************************************************************/
+
+/*
+the Equatorial Coordinate of the Moon for a given datetime
+
+The Lunar Equatorial Coordinate is the geocentric position of the Moon referred to the mean equator and
+equinox of date.
+*/
+func GetEquatorialCoordinate(datetime time.Time) common.EquatorialCoordinate {
+	// get the lunar ecliptic coordinate:
+	ec := GetEclipticCoordinate(datetime)
+
+	// convert the lunar ecliptic coordinate to the lunar equatorial coordinate:
+	return coordinates.ConvertEclipticToEquatorialCoordinate(datetime, ec)
+}
+
+/*****************************************************************************************************************/
+
+/*
+the Horizontal Coordinate of the Moon for a given datetime and observer
+
+The Lunar Horizontal Coordinate is the geocentric position of the Moon in the sky relative to the observer's
+local horizon.
+*/
+func GetHorizontalCoordinate(
+	datetime time.Time,
+	observer common.GeographicCoordinate,
+) common.HorizontalCoordinate {
+	// get the lunar equatorial coordinate:
+	eq := GetEquatorialCoordinate(datetime)
+
+	// convert the lunar equatorial coordinate to the lunar horizontal coordinate:
+	return coordinates.ConvertEquatorialToHorizontalCoordinate(datetime, observer, eq)
+}
+
+/*****************************************************************************************************************/
+
+/*
+normalises an angle to the range 0 to 360 degrees
+*/
+func normalise(angle float64) float64 {
+	angle = math.Mod(angle, 360)
+
+	if angle < 0 {
+		angle += 360
+	}
+
+	return angle
+}
+
+/*****************************************************************************************************************/
@@ -0,0 +1,205 @@
+/*****************************************************************************************************************/
+
+//	@author		Michael Roberts <michael@observerly.com>
+//	@package	@observerly/sidera
+//	@license	Copyright © 2021-2024 observerly
+
+/*****************************************************************************************************************/
+
+package moon
+
+/*****************************************************************************************************************/
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/observerly/sidera/pkg/common"
+)
+
+/*****************************************************************************************************************/
+
+// We define a datetime of 1992 April 12 0h TT for comparison against Meeus, "Astronomical Algorithms", Example 47.a:
+var datetime time.Time = time.Date(1992, 4, 11, 23, 59, 1, 816000000, time.UTC)
+
+/*****************************************************************************************************************/
+
+var observer common.GeographicCoordinate = common.GeographicCoordinate{
+	Latitude:  19.8207,
+	Longitude: -155.468094,
+	Elevation: 4205,
+}
+
+/*****************************************************************************************************************/
+
+func TestGetLunarMeanLongitude(t *testing.T) {
+	var got float64 = GetMeanLongitude(datetime)
+
+	var want float64 = 134.290182
+
+	if math.Abs(got-want) > 0.00001 {
+		t.Errorf("got %f, wanted %f", got, want)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetLunarMeanElongation(t *testing.T) {
+	var got float64 = GetMeanElongation(datetime)
+
+	var want float64 = 113.842304
+
+	if math.Abs(got-want) > 0.00001 {
+		t.Errorf("got %f, wanted %f", got, want)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetLunarMeanAnomaly(t *testing.T) {
+	var got float64 = GetMeanAnomaly(datetime)
+
+	var want float64 = 5.150833
+
+	if math.Abs(got-want) > 0.00001 {
+		t.Errorf("got %f, wanted %f", got, want)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetLunarArgumentOfLatitude(t *testing.T) {
+	var got float64 = GetArgumentOfLatitude(datetime)
+
+	var want float64 = 219.889721
+
+	if math.Abs(got-want) > 0.00001 {
+		t.Errorf("got %f, wanted %f", got, want)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetLunarLongitudeOfAscendingNode(t *testing.T) {
+	var got float64 = GetLongitudeOfAscendingNode(datetime)
+
+	var want float64 = 274.400656
+
+	if math.Abs(got-want) > 0.00001 {
+		t.Errorf("got %f, wanted %f", got, want)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetLunarEclipticCoordinate(t *testing.T) {
+	var got = GetEclipticCoordinate(datetime)
+
+	var want = common.EclipticCoordinate{
+		Longitude: 133.162655,
+		Latitude:  -3.229126,
+	}
+
+	if math.Abs(got.Longitude-want.Longitude) > 0.00001 {
+		t.Errorf("got %f, wanted %f", got.Longitude, want.Longitude)
+	}
+
+	if math.Abs(got.Latitude-want.Latitude) > 0.00001 {
+		t.Errorf("got %f, wanted %f", got.Latitude, want.Latitude)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetLunarDistance(t *testing.T) {
+	var got float64 = GetDistance(datetime)
+
+	var want float64 = 368409.7
+
+	if math.Abs(got-want) > 0.1 {
+		t.Errorf("got %f, wanted %f", got, want)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetLunarHorizontalParallax(t *testing.T) {
+	var got float64 = GetHorizontalParallax(datetime)
+
+	var want float64 = 0.991990
+
+	if math.Abs(got-want) > 0.00001 {
+		t.Errorf("got %f, wanted %f", got, want)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetLunarSemiDiameter(t *testing.T) {
+	var got float64 = GetSemiDiameter(datetime)
+
+	// s = 358473400 / Δ arcseconds:
+	var want float64 = 358473400 / 368409.7 / 3600
+
+	if math.Abs(got-want) > 0.00001 {
+		t.Errorf("got %f, wanted %f", got, want)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetLunarPhaseAngle(t *testing.T) {
+	var got float64 = GetPhaseAngle(datetime)
+
+	// Meeus, "Astronomical Algorithms", Example 48.a:
+	var want float64 = 69.0756
+
+	if math.Abs(got-want) > 0.01 {
+		t.Errorf("got %f, wanted %f", got, want)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetLunarIlluminatedFraction(t *testing.T) {
+	var got float64 = GetIlluminatedFraction(datetime)
+
+	// Meeus, "Astronomical Algorithms", Example 48.a:
+	var want float64 = 0.6786
+
+	if math.Abs(got-want) > 0.0002 {
+		t.Errorf("got %f, wanted %f", got, want)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetLunarEquatorialCoordinate(t *testing.T) {
+	var got = GetEquatorialCoordinate(datetime)
+
+	// α = 134.688470, δ = +13.768368 (apparent), less nutation and the difference in obliquity:
+	if math.Abs(got.RightAscension-134.6838) > 0.01 {
+		t.Errorf("got %f, wanted %f", got.RightAscension, 134.6838)
+	}
+
+	if math.Abs(got.Declination-13.7684) > 0.01 {
+		t.Errorf("got %f, wanted %f", got.Declination, 13.7684)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetLunarHorizontalCoordinate(t *testing.T) {
+	var got = GetHorizontalCoordinate(datetime, observer)
+
+	if got.Altitude < -90 || got.Altitude > 90 {
+		t.Errorf("got %f, wanted an altitude between -90 and 90 degrees", got.Altitude)
+	}
+
+	if got.Azimuth < 0 || got.Azimuth > 360 {
+		t.Errorf("got %f, wanted an azimuth between 0 and 360 degrees", got.Azimuth)
+	}
+}
+
+/*****************************************************************************************************************/
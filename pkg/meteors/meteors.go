@@ -0,0 +1,263 @@
+/*****************************************************************************************************************/
+
+//	@author		Michael Roberts <michael@observerly.com>
+//	@package	@observerly/sidera
+//	@license	Copyright © 2021-2024 observerly
+
+/*****************************************************************************************************************/
+
+package meteors
+
+/*****************************************************************************************************************/
+
+import (
+	"math"
+	"time"
+
+	"github.com/observerly/sidera/pkg/common"
+	"github.com/observerly/sidera/pkg/coordinates"
+	"github.com/observerly/sidera/pkg/epoch"
+	sun "github.com/observerly/sidera/pkg/solar"
+)
+
+/*****************************************************************************************************************/
+
+/*
+a meteor shower
+
+The activity of a shower is defined by the solar longitudes (referred to the equinox of J2000.0, in degrees) of
+the beginning, peak and end of its activity, which recur at the same solar longitudes each year. The radiant is
+the equatorial coordinate (J2000.0) of the radiant at the peak of activity, and the drift is the daily motion of
+the radiant in right ascension and declination, in degrees per degree of solar longitude.
+
+The velocity is the geocentric velocity of the meteoroids, in kilometres per second, the population index (r)
+is the ratio of the number of meteors of magnitude m+1 to those of magnitude m, and the zenithal hourly rate
+(ZHR) is the number of meteors a single observer would see at the peak under a limiting magnitude of +6.5 with
+the radiant at the zenith. A ZHR of zero denotes a shower whose rate varies from year to year.
+*/
+type Shower struct {
+	Number              int
+	Code                string
+	Name                string
+	Begin               float64
+	Peak                float64
+	End                 float64
+	Radiant             common.EquatorialCoordinate
+	RightAscensionDrift float64
+	DeclinationDrift    float64
+	Velocity            float64
+	PopulationIndex     float64
+	ZenithalHourlyRate  float64
+}
+
+/*****************************************************************************************************************/
+
+/*
+the established meteor showers of the IAU Meteor Data Center, with their activity and radiants as given in the
+International Meteor Organization's Meteor Shower Calendar (working list of visual meteor showers), in order of
+the beginning of their activity from the start of the year
+*/
+var Showers = []Shower{
+	{Number: 10, Code: "QUA", Name: "Quadrantids", Begin: 275.6, Peak: 283.15, End: 290.9, Radiant: common.EquatorialCoordinate{RightAscension: 230, Declination: 49}, RightAscensionDrift: 0.8, DeclinationDrift: -0.2, Velocity: 41, PopulationIndex: 2.1, ZenithalHourlyRate: 110},
+	{Number: 102, Code: "ACE", Name: "α-Centaurids", Begin: 310.3, Peak: 319.2, End: 330.5, Radiant: common.EquatorialCoordinate{RightAscension: 210, Declination: -59}, RightAscensionDrift: 1.0, DeclinationDrift: -0.3, Velocity: 58, PopulationIndex: 2.0, ZenithalHourlyRate: 6},
+	{Number: 118, Code: "GNO", Name: "γ-Normids", Begin: 335.5, Peak: 354, End: 7.5, Radiant: common.EquatorialCoordinate{RightAscension: 239, Declination: -50}, RightAscensionDrift: 1.0, DeclinationDrift: 0.1, Velocity: 56, PopulationIndex: 2.4, ZenithalHourlyRate: 6},
+	{Number: 6, Code: "LYR", Name: "April Lyrids", Begin: 24.2, Peak: 32.32, End: 39.8, Radiant: common.EquatorialCoordinate{RightAscension: 271, Declination: 34}, RightAscensionDrift: 1.1, DeclinationDrift: 0.0, Velocity: 49, PopulationIndex: 2.1, ZenithalHourlyRate: 18},
+	{Number: 137, Code: "PPU", Name: "π-Puppids", Begin: 25.2, Peak: 33.5, End: 37.9, Radiant: common.EquatorialCoordinate{RightAscension: 110, Declination: -45}, RightAscensionDrift: 0.6, DeclinationDrift: -0.2, Velocity: 18, PopulationIndex: 2.0, ZenithalHourlyRate: 0},
+	{Number: 31, Code: "ETA", Name: "η-Aquariids", Begin: 29.1, Peak: 45.5, End: 66.8, Radiant: common.EquatorialCoordinate{RightAscension: 338, Declination: -1}, RightAscensionDrift: 0.9, DeclinationDrift: 0.4, Velocity: 66, PopulationIndex: 2.4, ZenithalHourlyRate: 50},
+	{Number: 145, Code: "ELY", Name: "η-Lyrids", Begin: 42.7, Peak: 48.0, End: 53.4, Radiant: common.EquatorialCoordinate{RightAscension: 287, Declination: 44}, RightAscensionDrift: 0.8, DeclinationDrift: 0.0, Velocity: 43, PopulationIndex: 3.0, ZenithalHourlyRate: 3},
+	{Number: 171, Code: "ARI", Name: "Daytime Arietids", Begin: 53.4, Peak: 76.6, End: 92.7, Radiant: common.EquatorialCoordinate{RightAscension: 44, Declination: 24}, RightAscensionDrift: 0.7, DeclinationDrift: 0.2, Velocity: 38, PopulationIndex: 2.8, ZenithalHourlyRate: 30},
+	{Number: 170, Code: "JBO", Name: "June Boötids", Begin: 90.7, Peak: 95.7, End: 100.3, Radiant: common.EquatorialCoordinate{RightAscension: 224, Declination: 48}, RightAscensionDrift: 0.3, DeclinationDrift: -0.2, Velocity: 18, PopulationIndex: 2.2, ZenithalHourlyRate: 0},
+	{Number: 1, Code: "CAP", Name: "α-Capricornids", Begin: 101.2, Peak: 127, End: 142.3, Radiant: common.EquatorialCoordinate{RightAscension: 307, Declination: -10}, RightAscensionDrift: 0.6, DeclinationDrift: 0.2, Velocity: 23, PopulationIndex: 2.5, ZenithalHourlyRate: 5},
+	{Number: 5, Code: "SDA", Name: "Southern δ-Aquariids", Begin: 109.8, Peak: 127, End: 150.0, Radiant: common.EquatorialCoordinate{RightAscension: 340, Declination: -16}, RightAscensionDrift: 0.8, DeclinationDrift: 0.2, Velocity: 41, PopulationIndex: 2.5, ZenithalHourlyRate: 25},
+	{Number: 183, Code: "PAU", Name: "Piscis Austrinids", Begin: 112.7, Peak: 125, End: 137.5, Radiant: common.EquatorialCoordinate{RightAscension: 341, Declination: -30}, RightAscensionDrift: 0.7, DeclinationDrift: 0.3, Velocity: 35, PopulationIndex: 3.2, ZenithalHourlyRate: 5},
+	{Number: 7, Code: "PER", Name: "Perseids", Begin: 114.6, Peak: 140.0, End: 151.0, Radiant: common.EquatorialCoordinate{RightAscension: 48, Declination: 58}, RightAscensionDrift: 1.4, DeclinationDrift: 0.3, Velocity: 59, PopulationIndex: 2.2, ZenithalHourlyRate: 100},
+	{Number: 12, Code: "KCG", Name: "κ-Cygnids", Begin: 130.8, Peak: 145, End: 152.0, Radiant: common.EquatorialCoordinate{RightAscension: 286, Declination: 59}, RightAscensionDrift: 0.3, DeclinationDrift: 0.1, Velocity: 25, PopulationIndex: 3.0, ZenithalHourlyRate: 3},
+	{Number: 206, Code: "AUR", Name: "Aurigids", Begin: 154.9, Peak: 158.6, End: 162.6, Radiant: common.EquatorialCoordinate{RightAscension: 91, Declination: 39}, RightAscensionDrift: 1.1, DeclinationDrift: 0.0, Velocity: 66, PopulationIndex: 2.5, ZenithalHourlyRate: 6},
+	{Number: 208, Code: "SPE", Name: "September ε-Perseids", Begin: 162.6, Peak: 166.7, End: 178.2, Radiant: common.EquatorialCoordinate{RightAscension: 48, Declination: 40}, RightAscensionDrift: 1.1, DeclinationDrift: 0.2, Velocity: 64, PopulationIndex: 3.0, ZenithalHourlyRate: 5},
+	{Number: 2, Code: "STA", Name: "Southern Taurids", Begin: 167.4, Peak: 197, End: 237.8, Radiant: common.EquatorialCoordinate{RightAscension: 32, Declination: 9}, RightAscensionDrift: 0.8, DeclinationDrift: 0.3, Velocity: 27, PopulationIndex: 2.3, ZenithalHourlyRate: 5},
+	{Number: 8, Code: "ORI", Name: "Orionids", Begin: 189.0, Peak: 208, End: 224.7, Radiant: common.EquatorialCoordinate{RightAscension: 95, Declination: 16}, RightAscensionDrift: 0.7, DeclinationDrift: 0.0, Velocity: 66, PopulationIndex: 2.5, ZenithalHourlyRate: 20},
+	{Number: 9, Code: "DRA", Name: "October Draconids", Begin: 192.9, Peak: 195.4, End: 196.8, Radiant: common.EquatorialCoordinate{RightAscension: 262, Declination: 54}, RightAscensionDrift: 0.0, DeclinationDrift: 0.0, Velocity: 20, PopulationIndex: 2.6, ZenithalHourlyRate: 10},
+	{Number: 224, Code: "DAU", Name: "δ-Aurigids", Begin: 196.8, Peak: 198, End: 204.8, Radiant: common.EquatorialCoordinate{RightAscension: 84, Declination: 44}, RightAscensionDrift: 1.1, DeclinationDrift: -0.1, Velocity: 64, PopulationIndex: 3.0, ZenithalHourlyRate: 2},
+	{Number: 23, Code: "EGE", Name: "ε-Geminids", Begin: 200.8, Peak: 205, End: 213.7, Radiant: common.EquatorialCoordinate{RightAscension: 102, Declination: 27}, RightAscensionDrift: 0.9, DeclinationDrift: -0.1, Velocity: 70, PopulationIndex: 3.0, ZenithalHourlyRate: 3},
+	{Number: 22, Code: "LMI", Name: "Leonis Minorids", Begin: 205.8, Peak: 211, End: 213.7, Radiant: common.EquatorialCoordinate{RightAscension: 162, Declination: 37}, RightAscensionDrift: 0.9, DeclinationDrift: -0.4, Velocity: 62, PopulationIndex: 3.0, ZenithalHourlyRate: 2},
+	{Number: 17, Code: "NTA", Name: "Northern Taurids", Begin: 206.8, Peak: 230, End: 258.1, Radiant: common.EquatorialCoordinate{RightAscension: 58, Declination: 22}, RightAscensionDrift: 0.9, DeclinationDrift: 0.15, Velocity: 29, PopulationIndex: 2.3, ZenithalHourlyRate: 5},
+	{Number: 13, Code: "LEO", Name: "Leonids", Begin: 223.7, Peak: 235.27, End: 247.9, Radiant: common.EquatorialCoordinate{RightAscension: 152, Declination: 22}, RightAscensionDrift: 0.7, DeclinationDrift: -0.4, Velocity: 71, PopulationIndex: 2.5, ZenithalHourlyRate: 15},
+	{Number: 250, Code: "NOO", Name: "November Orionids", Begin: 230.8, Peak: 246, End: 254.0, Radiant: common.EquatorialCoordinate{RightAscension: 91, Declination: 16}, RightAscensionDrift: 0.9, DeclinationDrift: 0.0, Velocity: 44, PopulationIndex: 3.0, ZenithalHourlyRate: 3},
+	{Number: 246, Code: "AMO", Name: "α-Monocerotids", Begin: 232.8, Peak: 239.32, End: 242.9, Radiant: common.EquatorialCoordinate{RightAscension: 117, Declination: 1}, RightAscensionDrift: 1.1, DeclinationDrift: -0.1, Velocity: 65, PopulationIndex: 2.4, ZenithalHourlyRate: 0},
+	{Number: 254, Code: "PHO", Name: "Phoenicids", Begin: 245.9, Peak: 250.0, End: 257.1, Radiant: common.EquatorialCoordinate{RightAscension: 18, Declination: -53}, RightAscensionDrift: 0.8, DeclinationDrift: 0.2, Velocity: 18, PopulationIndex: 2.8, ZenithalHourlyRate: 0},
+	{Number: 301, Code: "PUP", Name: "Puppid-Velids", Begin: 248.9, Peak: 255, End: 263.2, Radiant: common.EquatorialCoordinate{RightAscension: 123, Declination: -45}, RightAscensionDrift: 0.6, DeclinationDrift: -0.2, Velocity: 40, PopulationIndex: 2.9, ZenithalHourlyRate: 10},
+	{Number: 16, Code: "HYD", Name: "σ-Hydrids", Begin: 251.0, Peak: 257, End: 268.2, Radiant: common.EquatorialCoordinate{RightAscension: 125, Declination: 2}, RightAscensionDrift: 0.7, DeclinationDrift: -0.2, Velocity: 58, PopulationIndex: 3.0, ZenithalHourlyRate: 7},
+	{Number: 4, Code: "GEM", Name: "Geminids", Begin: 252.0, Peak: 262.2, End: 268.2, Radiant: common.EquatorialCoordinate{RightAscension: 112, Declination: 33}, RightAscensionDrift: 1.0, DeclinationDrift: -0.2, Velocity: 35, PopulationIndex: 2.6, ZenithalHourlyRate: 150},
+	{Number: 19, Code: "MON", Name: "December Monocerotids", Begin: 253.0, Peak: 257, End: 268.2, Radiant: common.EquatorialCoordinate{RightAscension: 100, Declination: 8}, RightAscensionDrift: 0.9, DeclinationDrift: 0.0, Velocity: 41, PopulationIndex: 3.0, ZenithalHourlyRate: 3},
+	{Number: 32, Code: "DLM", Name: "December Leonis Minorids", Begin: 253.0, Peak: 268, End: 315.1, Radiant: common.EquatorialCoordinate{RightAscension: 161, Declination: 30}, RightAscensionDrift: 0.8, DeclinationDrift: -0.35, Velocity: 64, PopulationIndex: 3.0, ZenithalHourlyRate: 5},
+	{Number: 20, Code: "COM", Name: "Comae Berenicids", Begin: 260.1, Peak: 264, End: 271.3, Radiant: common.EquatorialCoordinate{RightAscension: 175, Declination: 18}, RightAscensionDrift: 0.8, DeclinationDrift: -0.3, Velocity: 65, PopulationIndex: 3.0, ZenithalHourlyRate: 3},
+	{Number: 15, Code: "URS", Name: "Ursids", Begin: 265.2, Peak: 270.7, End: 274.4, Radiant: common.EquatorialCoordinate{RightAscension: 217, Declination: 76}, RightAscensionDrift: 0.0, DeclinationDrift: -0.3, Velocity: 33, PopulationIndex: 3.0, ZenithalHourlyRate: 10},
+}
+
+/*****************************************************************************************************************/
+
+/*
+the difference between two solar longitudes, b - a, normalised to the range [-180, 180) degrees
+*/
+func getDifference(a float64, b float64) float64 {
+	return math.Mod(math.Mod(b-a+180, 360)+360, 360) - 180
+}
+
+/*****************************************************************************************************************/
+
+/*
+the solar longitude for a given datetime, referred to the equinox of J2000.0, in degrees
+
+The solar longitude is the standard measure of the Earth's position in its orbit used to tabulate meteor shower
+activity, as showers recur at the same solar longitude each year, irrespective of the calendar. The true ecliptic
+longitude of the Sun is referred to the mean equinox of date, and so is corrected for general precession in
+longitude of 1.3969713 degrees per Julian century.
+*/
+func GetSolarLongitude(datetime time.Time) float64 {
+	T := (epoch.GetJulianDate(datetime) - epoch.J2000) / 36525
+
+	λ := math.Mod(sun.GetTrueEclipticLongitude(datetime)-1.3969713*T, 360)
+
+	// applies modulo correction to the angle, and ensures always positive:
+	if λ < 0 {
+		λ += 360
+	}
+
+	return λ
+}
+
+/*****************************************************************************************************************/
+
+/*
+whether a meteor shower is active for a given datetime, i.e., the solar longitude lies within its period of
+activity, allowing for periods of activity which span the solar longitude of zero degrees
+*/
+func IsActive(datetime time.Time, shower Shower) bool {
+	λ := GetSolarLongitude(datetime)
+
+	if shower.Begin <= shower.End {
+		return λ >= shower.Begin && λ <= shower.End
+	}
+
+	return λ >= shower.Begin || λ <= shower.End
+}
+
+/*****************************************************************************************************************/
+
+/*
+the meteor showers active for a given datetime, in order of their beginning of activity through the year
+*/
+func GetActiveShowers(datetime time.Time) []Shower {
+	showers := []Shower{}
+
+	for _, shower := range Showers {
+		if IsActive(datetime, shower) {
+			showers = append(showers, shower)
+		}
+	}
+
+	return showers
+}
+
+/*****************************************************************************************************************/
+
+/*
+the equatorial coordinate (J2000.0) of the radiant of a meteor shower for a given datetime
+
+The radiant moves against the background stars from night to night, as a result of the Earth's orbital motion,
+and so the radiant at the peak is moved by the drift for the difference in solar longitude from the peak.
+*/
+func GetRadiant(datetime time.Time, shower Shower) common.EquatorialCoordinate {
+	Δλ := getDifference(shower.Peak, GetSolarLongitude(datetime))
+
+	α := math.Mod(shower.Radiant.RightAscension+shower.RightAscensionDrift*Δλ, 360)
+
+	// applies modulo correction to the angle, and ensures always positive:
+	if α < 0 {
+		α += 360
+	}
+
+	return common.EquatorialCoordinate{
+		RightAscension: α,
+		Declination:    math.Max(-90, math.Min(90, shower.Radiant.Declination+shower.DeclinationDrift*Δλ)),
+	}
+}
+
+/*****************************************************************************************************************/
+
+/*
+the horizontal coordinate of the radiant of a meteor shower for a given datetime and observer
+
+The radiant is precessed from J2000.0 to the equinox of date before its conversion to the observer's horizon.
+*/
+func GetRadiantHorizontalCoordinate(
+	datetime time.Time,
+	observer common.GeographicCoordinate,
+	shower Shower,
+) common.HorizontalCoordinate {
+	eq := coordinates.GetPrecessedEquatorialCoordinate(datetime, GetRadiant(datetime, shower))
+
+	return coordinates.ConvertEquatorialToHorizontalCoordinate(datetime, observer, eq)
+}
+
+/*****************************************************************************************************************/
+
+/*
+the expected hourly rate of a meteor shower, at its peak, for a given datetime, observer and limiting magnitude
+
+The observed rate is reduced from the zenithal hourly rate by the sine of the altitude of the radiant, and by the
+population index raised to the power of the difference between the limiting magnitude of +6.5 and the observer's
+limiting magnitude. The rate is zero when the radiant is below the horizon or the shower is inactive.
+*/
+func GetHourlyRate(
+	datetime time.Time,
+	observer common.GeographicCoordinate,
+	shower Shower,
+	limitingMagnitude float64,
+) float64 {
+	if !IsActive(datetime, shower) {
+		return 0
+	}
+
+	hz := GetRadiantHorizontalCoordinate(datetime, observer, shower)
+
+	if hz.Altitude <= 0 {
+		return 0
+	}
+
+	return shower.ZenithalHourlyRate * math.Sin(common.Radians(hz.Altitude)) /
+		math.Pow(shower.PopulationIndex, 6.5-limitingMagnitude)
+}
+
+/*****************************************************************************************************************/
+
+/*
+the datetime of the peak of a meteor shower on or after a given datetime, i.e., the next instant at which the
+solar longitude reaches that of the peak, found by bisection to within a second
+*/
+func GetPeak(datetime time.Time, shower Shower) time.Time {
+	// the number of days for the Sun to reach the solar longitude of the peak, at its mean rate:
+	days := math.Mod(shower.Peak-GetSolarLongitude(datetime)+360, 360) / 0.9856474
+
+	a := datetime.Add(time.Duration((days - 3) * 24 * float64(time.Hour)))
+
+	b := datetime.Add(time.Duration((days + 3) * 24 * float64(time.Hour)))
+
+	if a.Before(datetime) {
+		a = datetime
+	}
+
+	for b.Sub(a) > time.Second {
+		mid := a.Add(b.Sub(a) / 2)
+
+		if getDifference(shower.Peak, GetSolarLongitude(mid)) < 0 {
+			a = mid
+		} else {
+			b = mid
+		}
+	}
+
+	return a.Add(b.Sub(a) / 2)
+}
+
+/*****************************************************************************************************************/
@@ -0,0 +1,205 @@
+/*****************************************************************************************************************/
+
+//	@author		Michael Roberts <michael@observerly.com>
+//	@package	@observerly/sidera
+//	@license	Copyright © 2021-2024 observerly
+
+/*****************************************************************************************************************/
+
+package meteors
+
+/*****************************************************************************************************************/
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/observerly/sidera/pkg/common"
+)
+
+/*****************************************************************************************************************/
+
+var observer common.GeographicCoordinate = common.GeographicCoordinate{
+	Latitude:  51.4769,
+	Longitude: -0.0005,
+	Elevation: 46,
+}
+
+/*****************************************************************************************************************/
+
+func getShower(code string) Shower {
+	for _, shower := range Showers {
+		if shower.Code == code {
+			return shower
+		}
+	}
+
+	return Shower{}
+}
+
+/*****************************************************************************************************************/
+
+func TestShowers(t *testing.T) {
+	for _, shower := range Showers {
+		if shower.Begin < 0 || shower.Begin >= 360 || shower.End < 0 || shower.End >= 360 {
+			t.Errorf("%s: got activity %f to %f, wanted solar longitudes in [0, 360)", shower.Code, shower.Begin, shower.End)
+		}
+
+		// the peak lies within the period of activity, measured onwards from its beginning:
+		if math.Mod(shower.Peak-shower.Begin+360, 360) > math.Mod(shower.End-shower.Begin+360, 360) {
+			t.Errorf("%s: got peak %f, outside of activity %f to %f", shower.Code, shower.Peak, shower.Begin, shower.End)
+		}
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetSolarLongitude(t *testing.T) {
+	// the March equinox of 2024 occurred at 03:06 UT on 20 March, when the solar longitude of date was zero:
+	var got float64 = GetSolarLongitude(time.Date(2024, 3, 20, 3, 6, 0, 0, time.UTC))
+
+	// the solar longitude referred to J2000.0 is less by the precession of 0.34 degrees since J2000.0:
+	var want float64 = 360 - 0.3379
+
+	if math.Abs(got-want) > 0.01 {
+		t.Errorf("got %f, wanted %f", got, want)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestIsActive(t *testing.T) {
+	perseids := getShower("PER")
+
+	if !IsActive(time.Date(2024, 8, 12, 0, 0, 0, 0, time.UTC), perseids) {
+		t.Errorf("expected the Perseids to be active on 12 August")
+	}
+
+	if IsActive(time.Date(2024, 9, 12, 0, 0, 0, 0, time.UTC), perseids) {
+		t.Errorf("expected the Perseids to be inactive on 12 September")
+	}
+
+	// the γ-Normids are active across the solar longitude of zero degrees:
+	normids := getShower("GNO")
+
+	if !IsActive(time.Date(2024, 3, 25, 0, 0, 0, 0, time.UTC), normids) {
+		t.Errorf("expected the γ-Normids to be active on 25 March")
+	}
+
+	if IsActive(time.Date(2024, 4, 15, 0, 0, 0, 0, time.UTC), normids) {
+		t.Errorf("expected the γ-Normids to be inactive on 15 April")
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetActiveShowers(t *testing.T) {
+	showers := GetActiveShowers(time.Date(2024, 12, 14, 0, 0, 0, 0, time.UTC))
+
+	codes := make(map[string]bool)
+
+	for _, shower := range showers {
+		codes[shower.Code] = true
+	}
+
+	for _, code := range []string{"GEM", "HYD", "MON", "DLM", "COM", "PUP"} {
+		if !codes[code] {
+			t.Errorf("expected %s to be active on 14 December", code)
+		}
+	}
+
+	if codes["PER"] || codes["QUA"] {
+		t.Errorf("expected neither the Perseids nor the Quadrantids to be active on 14 December")
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetRadiant(t *testing.T) {
+	perseids := getShower("PER")
+
+	// at the peak, the radiant is at its tabulated position:
+	got := GetRadiant(GetPeak(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), perseids), perseids)
+
+	if math.Abs(got.RightAscension-48) > 0.001 || math.Abs(got.Declination-58) > 0.001 {
+		t.Errorf("got %f, %f, wanted 48, 58", got.RightAscension, got.Declination)
+	}
+
+	// around ten days before the peak, the radiant is around fourteen degrees to the west:
+	got = GetRadiant(time.Date(2024, 8, 2, 12, 0, 0, 0, time.UTC), perseids)
+
+	if math.Abs(got.RightAscension-34) > 1 || math.Abs(got.Declination-55) > 1 {
+		t.Errorf("got %f, %f, wanted 34, 55", got.RightAscension, got.Declination)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetRadiantHorizontalCoordinate(t *testing.T) {
+	perseids := getShower("PER")
+
+	// the Perseid radiant is circumpolar from Greenwich, and highest before dawn:
+	dawn := GetRadiantHorizontalCoordinate(time.Date(2024, 8, 12, 3, 0, 0, 0, time.UTC), observer, perseids)
+
+	dusk := GetRadiantHorizontalCoordinate(time.Date(2024, 8, 11, 21, 0, 0, 0, time.UTC), observer, perseids)
+
+	if dawn.Altitude < 60 {
+		t.Errorf("got altitude %f, wanted above 60 degrees", dawn.Altitude)
+	}
+
+	if dusk.Altitude > dawn.Altitude || dusk.Altitude < 0 {
+		t.Errorf("got altitude %f, wanted above the horizon but below %f", dusk.Altitude, dawn.Altitude)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetHourlyRate(t *testing.T) {
+	geminids := getShower("GEM")
+
+	datetime := time.Date(2024, 12, 14, 2, 0, 0, 0, time.UTC)
+
+	hz := GetRadiantHorizontalCoordinate(datetime, observer, geminids)
+
+	var got float64 = GetHourlyRate(datetime, observer, geminids, 6.5)
+
+	var want float64 = 150 * math.Sin(common.Radians(hz.Altitude))
+
+	if math.Abs(got-want) > 0.0001 {
+		t.Errorf("got %f, wanted %f", got, want)
+	}
+
+	// under a limiting magnitude of +5.5, the rate is reduced by the population index:
+	if got := GetHourlyRate(datetime, observer, geminids, 5.5); math.Abs(got-want/2.6) > 0.0001 {
+		t.Errorf("got %f, wanted %f", got, want/2.6)
+	}
+
+	// the Geminid radiant is below the horizon from Greenwich in the late afternoon:
+	if got := GetHourlyRate(time.Date(2024, 12, 14, 14, 0, 0, 0, time.UTC), observer, geminids, 6.5); got != 0 {
+		t.Errorf("got %f, wanted 0", got)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetPeak(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// the peaks of the major showers in 2024, as predicted by the International Meteor Organization:
+	peaks := map[string]time.Time{
+		"QUA": time.Date(2024, 1, 4, 9, 0, 0, 0, time.UTC),
+		"PER": time.Date(2024, 8, 12, 13, 0, 0, 0, time.UTC),
+		"GEM": time.Date(2024, 12, 14, 1, 0, 0, 0, time.UTC),
+	}
+
+	for code, want := range peaks {
+		got := GetPeak(start, getShower(code))
+
+		if math.Abs(got.Sub(want).Hours()) > 1 {
+			t.Errorf("%s: got %v, wanted %v", code, got, want)
+		}
+	}
+}
+
+/*****************************************************************************************************************/
@@ -0,0 +1,202 @@
+/*****************************************************************************************************************/
+
+//	@author		Michael Roberts <michael@observerly.com>
+//	@package	@observerly/sidera
+//	@license	Copyright © 2021-2024 observerly
+
+/*****************************************************************************************************************/
+
+package mount
+
+/*****************************************************************************************************************/
+
+import (
+	"math"
+	"time"
+
+	"github.com/observerly/sidera/pkg/astrometry"
+	"github.com/observerly/sidera/pkg/common"
+	"github.com/observerly/sidera/pkg/epoch"
+)
+
+/*****************************************************************************************************************/
+
+type PierSide int
+
+/*****************************************************************************************************************/
+
+const (
+	// the telescope is on the east side of the pier, pointing west of the meridian, i.e., the normal pointing state:
+	PierEast PierSide = iota
+	// the telescope is on the west side of the pier, pointing east of the meridian, i.e., the flipped pointing state:
+	PierWest
+)
+
+/*****************************************************************************************************************/
+
+/*
+the limits of a German equatorial mount about the meridian, in degrees of hour angle
+
+The mount may track a target on the west side of the pier past the meridian by up to PastMeridian degrees before
+the telescope must flip to the east side of the pier, e.g., 2.5 degrees for ten minutes of time. When slewing to a
+target east of the meridian by less than BeforeMeridian degrees, the telescope is placed on the east side of the
+pier at the outset, to avoid a flip shortly after the start of an observation.
+*/
+type Limits struct {
+	PastMeridian   float64
+	BeforeMeridian float64
+}
+
+/*****************************************************************************************************************/
+
+/*
+the angles of the axes of a German equatorial mount, in degrees, and the side of the pier on which the telescope
+lies
+
+The hour angle axis is measured westward from the meridian, with the counterweights down, in the range -180 to
+180 degrees, and the declination axis is measured from the celestial equator, through the pole, in the range -90
+to 270 degrees, so that a declination axis beyond 90 degrees indicates that the telescope has passed over the pole
+onto the west side of the pier.
+*/
+type AxisCoordinate struct {
+	HourAngle   float64
+	Declination float64
+	PierSide    PierSide
+}
+
+/*****************************************************************************************************************/
+
+/*
+the hour angle, in degrees, wrapped to the range -180 to 180 degrees, i.e., negative east of the meridian
+*/
+func getHourAngle(ha float64) float64 {
+	return math.Remainder(ha, 360)
+}
+
+/*****************************************************************************************************************/
+
+/*
+the side of the pier on which the telescope should be placed to observe a target at a given datetime, for a given
+observer and set of limits
+
+Targets west of the meridian, or east of it by less than the BeforeMeridian limit, are observed from the east side
+of the pier, and all other targets from the west side of the pier.
+*/
+func GetPierSide(
+	datetime time.Time,
+	observer common.GeographicCoordinate,
+	target common.EquatorialCoordinate,
+	limits Limits,
+) PierSide {
+	ha := getHourAngle(astrometry.GetHourAngle(datetime, observer, target))
+
+	if ha >= -limits.BeforeMeridian {
+		return PierEast
+	}
+
+	return PierWest
+}
+
+/*****************************************************************************************************************/
+
+/*
+converts the hour angle and declination of a target, in degrees, to the angles of the axes of a German equatorial
+mount for a given side of the pier
+
+On the west side of the pier the telescope is flipped over the pole, so that the hour angle axis is rotated by 180
+degrees and the declination axis reads 180 degrees minus the declination.
+*/
+func GetAxisCoordinate(ha float64, dec float64, side PierSide) AxisCoordinate {
+	if side == PierWest {
+		return AxisCoordinate{
+			HourAngle:   getHourAngle(ha + 180),
+			Declination: 180 - dec,
+			PierSide:    PierWest,
+		}
+	}
+
+	return AxisCoordinate{
+		HourAngle:   getHourAngle(ha),
+		Declination: dec,
+		PierSide:    PierEast,
+	}
+}
+
+/*****************************************************************************************************************/
+
+/*
+converts the topocentric apparent coordinate of a target to the angles of the axes of a German equatorial mount,
+at a given datetime and for a given observer, with the telescope on a given side of the pier
+*/
+func ConvertEquatorialToAxisCoordinate(
+	datetime time.Time,
+	observer common.GeographicCoordinate,
+	target common.EquatorialCoordinate,
+	side PierSide,
+) AxisCoordinate {
+	ha := astrometry.GetHourAngle(datetime, observer, target)
+
+	return GetAxisCoordinate(ha, target.Declination, side)
+}
+
+/*****************************************************************************************************************/
+
+/*
+converts the angles of the axes of a German equatorial mount to the topocentric apparent coordinate at which the
+telescope is pointing, at a given datetime and for a given observer
+*/
+func ConvertAxisToEquatorialCoordinate(
+	datetime time.Time,
+	observer common.GeographicCoordinate,
+	axes AxisCoordinate,
+) common.EquatorialCoordinate {
+	ha, dec := axes.HourAngle, axes.Declination
+
+	// a declination axis beyond the pole indicates that the telescope is flipped, whatever its recorded pier side:
+	if dec > 90 {
+		ha, dec = ha-180, 180-dec
+	}
+
+	LST := epoch.GetLocalSiderealTime(datetime, observer)
+
+	return common.EquatorialCoordinate{
+		RightAscension: math.Mod(math.Mod(LST*15-ha, 360)+360, 360),
+		Declination:    dec,
+	}
+}
+
+/*****************************************************************************************************************/
+
+/*
+the datetime at which a target being tracked from a given side of the pier will require a meridian flip, for a
+given observer and set of limits, or false if no flip is required
+
+A target tracked from the west side of the pier must be flipped when its hour angle reaches the PastMeridian limit
+west of the meridian, which is immediately if it has already passed the limit. A target tracked from the east side
+of the pier moves away from the meridian, and so never requires a flip. The altitude of the target is not
+considered, i.e., the target may set before the flip is reached.
+*/
+func GetMeridianFlip(
+	datetime time.Time,
+	observer common.GeographicCoordinate,
+	target common.EquatorialCoordinate,
+	side PierSide,
+	limits Limits,
+) (time.Time, bool) {
+	if side == PierEast {
+		return time.Time{}, false
+	}
+
+	ha := getHourAngle(astrometry.GetHourAngle(datetime, observer, target))
+
+	// the hour angle remaining until the limit, in degrees:
+	Δh := limits.PastMeridian - ha
+
+	if Δh <= 0 {
+		return datetime, true
+	}
+
+	return datetime.Add(time.Duration(Δh / astrometry.SIDEREAL_ROTATION_RATE * float64(time.Second))), true
+}
+
+/*****************************************************************************************************************/
@@ -0,0 +1,164 @@
+/*****************************************************************************************************************/
+
+//	@author		Michael Roberts <michael@observerly.com>
+//	@package	@observerly/sidera
+//	@license	Copyright © 2021-2024 observerly
+
+/*****************************************************************************************************************/
+
+package mount
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/observerly/sidera/pkg/astrometry"
+	"github.com/observerly/sidera/pkg/common"
+	"github.com/observerly/sidera/pkg/epoch"
+)
+
+/*****************************************************************************************************************/
+
+// We define a datetime as some arbitrary date and time for testing purposes:
+var datetime time.Time = time.Date(2021, 5, 14, 0, 0, 0, 0, time.UTC)
+
+/*****************************************************************************************************************/
+
+var observer common.GeographicCoordinate = common.GeographicCoordinate{
+	Latitude:  19.8207,
+	Longitude: -155.468094,
+	Elevation: 4205,
+}
+
+/*****************************************************************************************************************/
+
+var limits Limits = Limits{
+	PastMeridian:   2.5,
+	BeforeMeridian: 5,
+}
+
+/*****************************************************************************************************************/
+
+// a target at a given hour angle, in degrees, and declination at the test datetime:
+func getTarget(ha float64, dec float64) common.EquatorialCoordinate {
+	LST := epoch.GetLocalSiderealTime(datetime, observer)
+
+	return common.EquatorialCoordinate{
+		RightAscension: math.Mod(LST*15-ha+720, 360),
+		Declination:    dec,
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetPierSide(t *testing.T) {
+	tests := []struct {
+		ha   float64
+		want PierSide
+	}{
+		{ha: 45, want: PierEast},
+		{ha: 0.5, want: PierEast},
+		{ha: -2, want: PierEast},
+		{ha: -10, want: PierWest},
+		{ha: -90, want: PierWest},
+	}
+
+	for _, test := range tests {
+		if side := GetPierSide(datetime, observer, getTarget(test.ha, 30), limits); side != test.want {
+			t.Errorf("hour angle %f: got %d, wanted %d", test.ha, side, test.want)
+		}
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetAxisCoordinate(t *testing.T) {
+	axes := GetAxisCoordinate(30, 45, PierEast)
+
+	if axes.HourAngle != 30 || axes.Declination != 45 || axes.PierSide != PierEast {
+		t.Errorf("got %+v, wanted the hour angle and declination unchanged", axes)
+	}
+
+	axes = GetAxisCoordinate(330, 45, PierWest)
+
+	if math.Abs(axes.HourAngle-150) > 1e-9 {
+		t.Errorf("got %f, wanted %f", axes.HourAngle, 150.0)
+	}
+
+	if math.Abs(axes.Declination-135) > 1e-9 {
+		t.Errorf("got %f, wanted %f", axes.Declination, 135.0)
+	}
+
+	if axes.PierSide != PierWest {
+		t.Errorf("got %d, wanted %d", axes.PierSide, PierWest)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestConvertEquatorialToAxisCoordinateRoundTrip(t *testing.T) {
+	target := common.EquatorialCoordinate{
+		RightAscension: 88.7929583,
+		Declination:    7.4070639,
+	}
+
+	for _, side := range []PierSide{PierEast, PierWest} {
+		axes := ConvertEquatorialToAxisCoordinate(datetime, observer, target, side)
+
+		eq := ConvertAxisToEquatorialCoordinate(datetime, observer, axes)
+
+		if math.Abs(eq.RightAscension-target.RightAscension) > 1e-9 {
+			t.Errorf("pier side %d: got %f, wanted %f", side, eq.RightAscension, target.RightAscension)
+		}
+
+		if math.Abs(eq.Declination-target.Declination) > 1e-9 {
+			t.Errorf("pier side %d: got %f, wanted %f", side, eq.Declination, target.Declination)
+		}
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetMeridianFlip(t *testing.T) {
+	target := getTarget(-30, 30)
+
+	flip, ok := GetMeridianFlip(datetime, observer, target, PierWest, limits)
+
+	if !ok {
+		t.Fatalf("got no meridian flip, wanted a flip for a target east of the meridian")
+	}
+
+	// the target moves 32.5 degrees of hour angle, i.e., 2h 9m 39s of sidereal time, before the flip:
+	want := 32.5 / astrometry.SIDEREAL_ROTATION_RATE
+
+	if math.Abs(flip.Sub(datetime).Seconds()-want) > 1 {
+		t.Errorf("got %f seconds, wanted %f seconds", flip.Sub(datetime).Seconds(), want)
+	}
+
+	ha := math.Remainder(astrometry.GetHourAngle(flip, observer, target), 360)
+
+	if math.Abs(ha-limits.PastMeridian) > 0.01 {
+		t.Errorf("got %f, wanted the hour angle at the flip to be %f", ha, limits.PastMeridian)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetMeridianFlipPastLimit(t *testing.T) {
+	flip, ok := GetMeridianFlip(datetime, observer, getTarget(10, 30), PierWest, limits)
+
+	if !ok || !flip.Equal(datetime) {
+		t.Errorf("got %v, %t, wanted an immediate flip", flip, ok)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetMeridianFlipPierEast(t *testing.T) {
+	if _, ok := GetMeridianFlip(datetime, observer, getTarget(30, 30), PierEast, limits); ok {
+		t.Errorf("got a meridian flip, wanted none for a target tracked from the east side of the pier")
+	}
+}
+
+/*****************************************************************************************************************/
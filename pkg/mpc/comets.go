@@ -0,0 +1,178 @@
+/*****************************************************************************************************************/
+
+//	@author		Michael Roberts <michael@observerly.com>
+//	@package	@observerly/sidera
+//	@license	Copyright © 2021-2024 observerly
+
+/*****************************************************************************************************************/
+
+package mpc
+
+/*****************************************************************************************************************/
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/observerly/sidera/pkg/orbits"
+)
+
+/*****************************************************************************************************************/
+
+/*
+a comet, as described by a single line of the Minor Planet Center's CometEls.txt file
+
+The number is zero for a comet which has not been numbered as a periodic comet, in which case the provisional
+designation identifies it. The orbit type is one of "P" (periodic), "C" (long-period), "D" (defunct), "X"
+(uncertain), "I" (interstellar) or "A" (an asteroidal object on a cometary orbit). The name is the readable
+designation given in columns 103-158, e.g., "1P/Halley". The orbital elements are referred to the mean
+ecliptic and equinox of J2000.0, with the time of perihelion and the epoch in TT.
+*/
+type Comet struct {
+	Number                 int
+	OrbitType              string
+	ProvisionalDesignation string
+	Name                   string
+	AbsoluteMagnitude      float64
+	SlopeParameter         float64
+	Elements               orbits.OrbitalElements
+	Reference              string
+}
+
+/*****************************************************************************************************************/
+
+/*
+parses a single line of the Minor Planet Center's CometEls.txt file
+
+The line is in the export format for comet orbits, in which the time of perihelion passage is given as a
+calendar date with a fractional day, and the epoch of osculation as a calendar date. The epoch may be blank,
+in which case the elements are taken to osculate at the time of perihelion passage. The absolute magnitude
+and slope parameter, which here describe the total magnitude as m = H + 5 log Δ + 2.5 K log r, may also be
+blank, in which case they are returned as zero.
+*/
+func ParseComet(line string) (Comet, error) {
+	if len(line) < 79 {
+		return Comet{}, fmt.Errorf("invalid comet line: got %d characters, wanted at least 79", len(line))
+	}
+
+	comet := Comet{
+		OrbitType: getColumns(line, 5, 5),
+		Name:      getColumns(line, 103, 158),
+		Reference: getColumns(line, 160, 168),
+	}
+
+	var err error
+
+	if number := getColumns(line, 1, 4); number != "" {
+		if comet.Number, err = strconv.Atoi(number); err != nil {
+			return Comet{}, fmt.Errorf("invalid periodic comet number: %q", number)
+		}
+	}
+
+	if designation := getColumns(line, 6, 12); designation != "" {
+		if comet.ProvisionalDesignation, err = UnpackProvisionalDesignation(designation); err != nil {
+			return Comet{}, err
+		}
+	}
+
+	if comet.Number == 0 && comet.ProvisionalDesignation == "" {
+		return Comet{}, fmt.Errorf("invalid comet line: no number or provisional designation")
+	}
+
+	// the year, month and (fractional) day of perihelion passage:
+	year, errYear := strconv.Atoi(getColumns(line, 15, 18))
+
+	month, errMonth := strconv.Atoi(getColumns(line, 20, 21))
+
+	day, errDay := strconv.ParseFloat(getColumns(line, 23, 29), 64)
+
+	if errYear != nil || errMonth != nil || errDay != nil {
+		return Comet{}, fmt.Errorf("invalid time of perihelion passage: %q", getColumns(line, 15, 29))
+	}
+
+	// the perihelion distance, eccentricity, argument of perihelion, longitude of the ascending node and inclination:
+	values := make([]float64, 5)
+
+	for i, field := range []struct {
+		start, end int
+		name       string
+	}{
+		{31, 39, "perihelion distance"},
+		{42, 49, "eccentricity"},
+		{52, 59, "argument of perihelion"},
+		{62, 69, "longitude of the ascending node"},
+		{72, 79, "inclination"},
+	} {
+		if values[i], err = parseFloat(line, field.start, field.end, field.name); err != nil {
+			return Comet{}, err
+		}
+	}
+
+	T := getJulianDate(year, month, day)
+
+	comet.Elements = orbits.OrbitalElements{
+		Epoch:                    T,
+		PerihelionDistance:       values[0],
+		Eccentricity:             values[1],
+		ArgumentOfPerihelion:     values[2],
+		LongitudeOfAscendingNode: values[3],
+		Inclination:              values[4],
+		TimeOfPerihelion:         T,
+	}
+
+	if epoch := getColumns(line, 82, 89); epoch != "" {
+		year, errYear := strconv.Atoi(getColumns(line, 82, 85))
+
+		month, errMonth := strconv.Atoi(getColumns(line, 86, 87))
+
+		day, errDay := strconv.Atoi(getColumns(line, 88, 89))
+
+		if errYear != nil || errMonth != nil || errDay != nil {
+			return Comet{}, fmt.Errorf("invalid epoch: %q", epoch)
+		}
+
+		comet.Elements.Epoch = getJulianDate(year, month, float64(day))
+	}
+
+	if H := getColumns(line, 92, 95); H != "" {
+		if comet.AbsoluteMagnitude, err = parseFloat(line, 92, 95, "absolute magnitude"); err != nil {
+			return Comet{}, err
+		}
+	}
+
+	if G := getColumns(line, 97, 100); G != "" {
+		if comet.SlopeParameter, err = parseFloat(line, 97, 100, "slope parameter"); err != nil {
+			return Comet{}, err
+		}
+	}
+
+	return comet, nil
+}
+
+/*****************************************************************************************************************/
+
+/*
+reads the comets from a CometEls.txt file, or any file in the same export format
+
+Blank lines are skipped. An error is returned for the first line which cannot be parsed, giving its line
+number.
+*/
+func ReadComets(r io.Reader) ([]Comet, error) {
+	return readRecords(r, ParseComet)
+}
+
+/*****************************************************************************************************************/
+
+/*
+the designation of a comet, e.g., "1P" for a numbered periodic comet, or "C/1995 O1" otherwise
+*/
+func (c Comet) Designation() string {
+	if c.Number > 0 {
+		return fmt.Sprintf("%d%s", c.Number, c.OrbitType)
+	}
+
+	return fmt.Sprintf("%s/%s", c.OrbitType, c.ProvisionalDesignation)
+}
+
+/*****************************************************************************************************************/
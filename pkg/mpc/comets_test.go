@@ -0,0 +1,111 @@
+/*****************************************************************************************************************/
+
+//	@author		Michael Roberts <michael@observerly.com>
+//	@package	@observerly/sidera
+//	@license	Copyright © 2021-2024 observerly
+
+/*****************************************************************************************************************/
+
+package mpc
+
+/*****************************************************************************************************************/
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+/*****************************************************************************************************************/
+
+var halley = "0001P         1986 02  9.4589  0.574009  0.967277  111.8657   59.0975  162.1770  20250101   4.0  6.0  1P/Halley                                                 98, 1083"
+
+/*****************************************************************************************************************/
+
+var haleBopp = "    CJ95O010  1997 03 29.6333  0.916241  0.994928  130.5682  282.4709   89.2856  20230101  -2.0  4.0  C/1995 O1 (Hale-Bopp)                                    MPC106342"
+
+/*****************************************************************************************************************/
+
+func TestParseComet(t *testing.T) {
+	comet, err := ParseComet(halley)
+
+	if err != nil {
+		t.Fatalf("got error %v", err)
+	}
+
+	if comet.Number != 1 || comet.OrbitType != "P" || comet.Name != "1P/Halley" || comet.Designation() != "1P" {
+		t.Errorf("got %d, %q, %q, wanted 1, \"P\", \"1P/Halley\"", comet.Number, comet.OrbitType, comet.Name)
+	}
+
+	// 9.4589 February 1986 (TT):
+	if math.Abs(comet.Elements.TimeOfPerihelion-2446470.9589) > 1e-6 {
+		t.Errorf("got %f, wanted %f", comet.Elements.TimeOfPerihelion, 2446470.9589)
+	}
+
+	// 1 January 2025, 0h TT:
+	if comet.Elements.Epoch != 2460676.5 {
+		t.Errorf("got %f, wanted %f", comet.Elements.Epoch, 2460676.5)
+	}
+
+	if comet.Elements.PerihelionDistance != 0.574009 || comet.Elements.Eccentricity != 0.967277 {
+		t.Errorf("got q = %f, e = %f, wanted q = 0.574009, e = 0.967277", comet.Elements.PerihelionDistance, comet.Elements.Eccentricity)
+	}
+
+	if comet.Elements.ArgumentOfPerihelion != 111.8657 || comet.Elements.LongitudeOfAscendingNode != 59.0975 || comet.Elements.Inclination != 162.1770 {
+		t.Errorf("got %+v", comet.Elements)
+	}
+
+	if comet.AbsoluteMagnitude != 4 || comet.SlopeParameter != 6 || comet.Reference != "98, 1083" {
+		t.Errorf("got %+v", comet)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestParseCometProvisionalDesignation(t *testing.T) {
+	comet, err := ParseComet(haleBopp)
+
+	if err != nil {
+		t.Fatalf("got error %v", err)
+	}
+
+	if comet.Number != 0 || comet.ProvisionalDesignation != "1995 O1" || comet.Designation() != "C/1995 O1" {
+		t.Errorf("got %d, %q, %q, wanted 0, \"1995 O1\", \"C/1995 O1\"", comet.Number, comet.ProvisionalDesignation, comet.Designation())
+	}
+
+	if comet.AbsoluteMagnitude != -2 || comet.Name != "C/1995 O1 (Hale-Bopp)" {
+		t.Errorf("got %+v", comet)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestParseCometInvalid(t *testing.T) {
+	if _, err := ParseComet(halley[:60]); err == nil {
+		t.Errorf("expected an error for a truncated line")
+	}
+
+	if _, err := ParseComet("    " + halley[4:]); err == nil {
+		t.Errorf("expected an error for a comet without a number or designation")
+	}
+
+	if _, err := ParseComet(strings.Replace(halley, "1986 02", "1986 0x", 1)); err == nil {
+		t.Errorf("expected an error for an invalid time of perihelion passage")
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestReadComets(t *testing.T) {
+	comets, err := ReadComets(strings.NewReader(halley + "\n\n" + haleBopp + "\n"))
+
+	if err != nil {
+		t.Fatalf("got error %v", err)
+	}
+
+	if len(comets) != 2 || comets[0].Designation() != "1P" || comets[1].Designation() != "C/1995 O1" {
+		t.Errorf("got %+v, wanted 1P and C/1995 O1", comets)
+	}
+}
+
+/*****************************************************************************************************************/
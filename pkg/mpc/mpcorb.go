@@ -0,0 +1,255 @@
+/*****************************************************************************************************************/
+
+//	@author		Michael Roberts <michael@observerly.com>
+//	@package	@observerly/sidera
+//	@license	Copyright © 2021-2024 observerly
+
+/*****************************************************************************************************************/
+
+package mpc
+
+/*****************************************************************************************************************/
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/observerly/sidera/pkg/orbits"
+)
+
+/*****************************************************************************************************************/
+
+/*
+a minor planet, as described by a single line of the Minor Planet Center's MPCORB.DAT file
+
+The number is zero for an unnumbered minor planet, in which case the provisional designation identifies it.
+The name is the readable designation given in columns 167-194, e.g., "(1) Ceres" or "2023 AB1". The orbital
+elements are referred to the mean ecliptic and equinox of J2000.0, with the epoch in TT.
+*/
+type MinorPlanet struct {
+	Number                 int
+	ProvisionalDesignation string
+	Name                   string
+	AbsoluteMagnitude      float64
+	SlopeParameter         float64
+	Elements               orbits.OrbitalElements
+	SemiMajorAxis          float64
+	MeanAnomaly            float64
+	MeanDailyMotion        float64
+	Uncertainty            string
+	Reference              string
+	Observations           int
+	Oppositions            int
+	RMSResidual            float64
+	LastObservation        time.Time
+}
+
+/*****************************************************************************************************************/
+
+/*
+the trimmed contents of the given (one-based, inclusive) columns of a fixed-width line, or an empty string if
+the line is too short
+*/
+func getColumns(line string, start int, end int) string {
+	if len(line) < start {
+		return ""
+	}
+
+	return strings.TrimSpace(line[start-1 : min(end, len(line))])
+}
+
+/*****************************************************************************************************************/
+
+/*
+parses the floating point value in the given columns of a fixed-width line, returning an error naming the field
+*/
+func parseFloat(line string, start int, end int, field string) (float64, error) {
+	value, err := strconv.ParseFloat(getColumns(line, start, end), 64)
+
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s in columns %d-%d: %q", field, start, end, getColumns(line, start, end))
+	}
+
+	return value, nil
+}
+
+/*****************************************************************************************************************/
+
+/*
+parses a single line of the Minor Planet Center's MPCORB.DAT file
+
+The line is in the export format for minor planet orbits, in which the designation in columns 1-7 is either a
+packed number or a packed provisional designation, and the epoch in columns 21-25 is a packed date. The
+absolute magnitude and slope parameter may be blank, in which case they are returned as zero and 0.15.
+*/
+func ParseMinorPlanet(line string) (MinorPlanet, error) {
+	if len(line) < 103 {
+		return MinorPlanet{}, fmt.Errorf("invalid MPCORB line: got %d characters, wanted at least 103", len(line))
+	}
+
+	mp := MinorPlanet{
+		SlopeParameter: 0.15,
+		Uncertainty:    getColumns(line, 106, 106),
+		Reference:      getColumns(line, 108, 116),
+		Name:           getColumns(line, 167, 194),
+	}
+
+	designation := getColumns(line, 1, 7)
+
+	var err error
+
+	if len(designation) == 5 {
+		mp.Number, err = UnpackNumber(designation)
+	} else {
+		mp.ProvisionalDesignation, err = UnpackProvisionalDesignation(designation)
+	}
+
+	if err != nil {
+		return MinorPlanet{}, err
+	}
+
+	if H := getColumns(line, 9, 13); H != "" {
+		if mp.AbsoluteMagnitude, err = parseFloat(line, 9, 13, "absolute magnitude"); err != nil {
+			return MinorPlanet{}, err
+		}
+	}
+
+	if G := getColumns(line, 15, 19); G != "" {
+		if mp.SlopeParameter, err = parseFloat(line, 15, 19, "slope parameter"); err != nil {
+			return MinorPlanet{}, err
+		}
+	}
+
+	JD, err := UnpackEpoch(getColumns(line, 21, 25))
+
+	if err != nil {
+		return MinorPlanet{}, err
+	}
+
+	// the mean anomaly, argument of perihelion, longitude of the ascending node, inclination and eccentricity:
+	values := make([]float64, 5)
+
+	for i, field := range []struct {
+		start, end int
+		name       string
+	}{
+		{27, 35, "mean anomaly"},
+		{38, 46, "argument of perihelion"},
+		{49, 57, "longitude of the ascending node"},
+		{60, 68, "inclination"},
+		{71, 79, "eccentricity"},
+	} {
+		if values[i], err = parseFloat(line, field.start, field.end, field.name); err != nil {
+			return MinorPlanet{}, err
+		}
+	}
+
+	if mp.MeanDailyMotion, err = parseFloat(line, 81, 91, "mean daily motion"); err != nil {
+		return MinorPlanet{}, err
+	}
+
+	if mp.SemiMajorAxis, err = parseFloat(line, 93, 103, "semi-major axis"); err != nil {
+		return MinorPlanet{}, err
+	}
+
+	mp.MeanAnomaly = values[0]
+
+	mp.Elements = orbits.NewOrbitalElements(JD, mp.SemiMajorAxis, values[4], values[3], values[2], values[1], values[0])
+
+	// the time of perihelion passage is found from the mean daily motion given in the file, which may include
+	// the mass of the minor planet, so that the position at the epoch is exactly that computed by the MPC:
+	if mp.MeanDailyMotion > 0 {
+		mp.Elements.TimeOfPerihelion = JD - math.Remainder(mp.MeanAnomaly, 360)/mp.MeanDailyMotion
+	}
+
+	// the optional observational summary, which may be blank for orbits computed from few observations:
+	mp.Observations, _ = strconv.Atoi(getColumns(line, 118, 122))
+
+	mp.Oppositions, _ = strconv.Atoi(getColumns(line, 124, 126))
+
+	mp.RMSResidual, _ = strconv.ParseFloat(getColumns(line, 138, 141), 64)
+
+	mp.LastObservation, _ = time.Parse("20060102", getColumns(line, 195, 202))
+
+	return mp, nil
+}
+
+/*****************************************************************************************************************/
+
+/*
+reads the records of a fixed-width file, line by line, parsing each non-blank line with the given function
+
+A header, up to and including a line of dashes which precedes the records, is skipped if present. An error
+is returned for the first line which cannot be parsed, giving its line number.
+*/
+func readRecords[T any](r io.Reader, parse func(line string) (T, error)) ([]T, error) {
+	records := []T{}
+
+	scanner := bufio.NewScanner(r)
+
+	// the first error found before any record, which is discarded if it turns out to be part of a header:
+	var headerErr error
+
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimRight(scanner.Text(), "\r")
+
+		// the header ends with a line of dashes, before which any records or errors are discarded:
+		if strings.HasPrefix(line, "-----") {
+			records, headerErr = []T{}, nil
+			continue
+		}
+
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		record, err := parse(line)
+
+		if err != nil && len(records) == 0 {
+			if headerErr == nil {
+				headerErr = fmt.Errorf("line %d: %w", n, err)
+			}
+
+			continue
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", n, err)
+		}
+
+		if headerErr != nil {
+			return nil, headerErr
+		}
+
+		records = append(records, record)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if headerErr != nil {
+		return nil, headerErr
+	}
+
+	return records, nil
+}
+
+/*****************************************************************************************************************/
+
+/*
+reads the minor planets from an MPCORB.DAT file, or any file in the same export format, e.g., NEA.txt
+
+The header of the file, up to and including the line of dashes which precedes the orbits, is skipped if
+present, as are any blank lines.
+*/
+func ReadMinorPlanets(r io.Reader) ([]MinorPlanet, error) {
+	return readRecords(r, ParseMinorPlanet)
+}
+
+/*****************************************************************************************************************/
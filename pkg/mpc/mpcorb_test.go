@@ -0,0 +1,156 @@
+/*****************************************************************************************************************/
+
+//	@author		Michael Roberts <michael@observerly.com>
+//	@package	@observerly/sidera
+//	@license	Copyright © 2021-2024 observerly
+
+/*****************************************************************************************************************/
+
+package mpc
+
+/*****************************************************************************************************************/
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+/*****************************************************************************************************************/
+
+var ceres = "00001    3.34  0.15 K239D  60.07881   73.42179   80.25496   10.58688  0.0789126  0.21411952   2.7672729  0 E2023-F25  7283 125 1801-2023 0.65 M-v 30k MPCLINUX   0000      (1) Ceres              20230321"
+
+/*****************************************************************************************************************/
+
+var unnumbered = "K07Tf8A 17.62  0.15 K239D  32.42110  279.04820   68.88342    7.28318  0.1834557  0.24183651   2.5530118  1 MPO751233    58   5 2001-2022 0.53 M-v 3Ek MPCLINUX   0000 2007 TA418                  20221201"
+
+/*****************************************************************************************************************/
+
+func TestParseMinorPlanet(t *testing.T) {
+	mp, err := ParseMinorPlanet(ceres)
+
+	if err != nil {
+		t.Fatalf("got error %v", err)
+	}
+
+	if mp.Number != 1 || mp.ProvisionalDesignation != "" || mp.Name != "(1) Ceres" {
+		t.Errorf("got %d, %q, %q, wanted 1, \"\", \"(1) Ceres\"", mp.Number, mp.ProvisionalDesignation, mp.Name)
+	}
+
+	if mp.AbsoluteMagnitude != 3.34 || mp.SlopeParameter != 0.15 {
+		t.Errorf("got H = %f, G = %f, wanted H = 3.34, G = 0.15", mp.AbsoluteMagnitude, mp.SlopeParameter)
+	}
+
+	if mp.Elements.Epoch != 2460200.5 {
+		t.Errorf("got %f, wanted %f", mp.Elements.Epoch, 2460200.5)
+	}
+
+	if mp.Elements.ArgumentOfPerihelion != 73.42179 || mp.Elements.LongitudeOfAscendingNode != 80.25496 {
+		t.Errorf("got ω = %f, Ω = %f, wanted ω = 73.42179, Ω = 80.25496", mp.Elements.ArgumentOfPerihelion, mp.Elements.LongitudeOfAscendingNode)
+	}
+
+	if mp.Elements.Inclination != 10.58688 || mp.Elements.Eccentricity != 0.0789126 {
+		t.Errorf("got i = %f, e = %f, wanted i = 10.58688, e = 0.0789126", mp.Elements.Inclination, mp.Elements.Eccentricity)
+	}
+
+	if q := 2.7672729 * (1 - 0.0789126); math.Abs(mp.Elements.PerihelionDistance-q) > 1e-9 {
+		t.Errorf("got %f, wanted %f", mp.Elements.PerihelionDistance, q)
+	}
+
+	// the time of perihelion passage, from the mean anomaly and the mean daily motion given in the file:
+	if T := 2460200.5 - 60.07881/0.21411952; math.Abs(mp.Elements.TimeOfPerihelion-T) > 1e-6 {
+		t.Errorf("got %f, wanted %f", mp.Elements.TimeOfPerihelion, T)
+	}
+
+	if mp.Observations != 7283 || mp.Oppositions != 125 || mp.RMSResidual != 0.65 || mp.Reference != "E2023-F25" {
+		t.Errorf("got %+v", mp)
+	}
+
+	if !mp.LastObservation.Equal(time.Date(2023, 3, 21, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("got %v, wanted 2023-03-21", mp.LastObservation)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestParseMinorPlanetProvisionalDesignation(t *testing.T) {
+	mp, err := ParseMinorPlanet(unnumbered)
+
+	if err != nil {
+		t.Fatalf("got error %v", err)
+	}
+
+	if mp.Number != 0 || mp.ProvisionalDesignation != "2007 TA418" || mp.Name != "2007 TA418" {
+		t.Errorf("got %d, %q, %q, wanted 0, \"2007 TA418\", \"2007 TA418\"", mp.Number, mp.ProvisionalDesignation, mp.Name)
+	}
+
+	if mp.AbsoluteMagnitude != 17.62 || mp.Uncertainty != "1" {
+		t.Errorf("got H = %f, U = %q, wanted H = 17.62, U = \"1\"", mp.AbsoluteMagnitude, mp.Uncertainty)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestParseMinorPlanetInvalid(t *testing.T) {
+	if _, err := ParseMinorPlanet(ceres[:80]); err == nil {
+		t.Errorf("expected an error for a truncated line")
+	}
+
+	if _, err := ParseMinorPlanet(strings.Replace(ceres, "0.0789126", "0.07x9126", 1)); err == nil {
+		t.Errorf("expected an error for an invalid eccentricity")
+	}
+
+	if _, err := ParseMinorPlanet(strings.Replace(ceres, "K239D", "K23XD", 1)); err == nil {
+		t.Errorf("expected an error for an invalid epoch")
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestReadMinorPlanets(t *testing.T) {
+	file := strings.Join([]string{
+		"MINOR PLANET CENTER ORBIT DATABASE (MPCORB)",
+		"",
+		"Des'n     H     G   Epoch     M        Peri.      Node       Incl.       e            n           a        Reference #Obs #Opp    Arc    rms  Perts   Computer",
+		"----------------------------------------------------------------------------------------------------------------------------------------------------------------",
+		ceres,
+		"",
+		unnumbered,
+	}, "\r\n")
+
+	minorPlanets, err := ReadMinorPlanets(strings.NewReader(file))
+
+	if err != nil {
+		t.Fatalf("got error %v", err)
+	}
+
+	if len(minorPlanets) != 2 || minorPlanets[0].Number != 1 || minorPlanets[1].ProvisionalDesignation != "2007 TA418" {
+		t.Errorf("got %+v, wanted Ceres and 2007 TA418", minorPlanets)
+	}
+
+	// a file without a header is also accepted:
+	minorPlanets, err = ReadMinorPlanets(strings.NewReader(ceres + "\n" + unnumbered + "\n"))
+
+	if err != nil || len(minorPlanets) != 2 {
+		t.Errorf("got %d minor planets and error %v, wanted 2", len(minorPlanets), err)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestReadMinorPlanetsInvalid(t *testing.T) {
+	_, err := ReadMinorPlanets(strings.NewReader(ceres + "\n" + ceres[:80] + "\n"))
+
+	if err == nil || !strings.HasPrefix(err.Error(), "line 2:") {
+		t.Errorf("got error %v, wanted an error for line 2", err)
+	}
+
+	_, err = ReadMinorPlanets(strings.NewReader("not an orbit\n" + ceres + "\n"))
+
+	if err == nil || !strings.HasPrefix(err.Error(), "line 1:") {
+		t.Errorf("got error %v, wanted an error for line 1", err)
+	}
+}
+
+/*****************************************************************************************************************/
@@ -0,0 +1,204 @@
+/*****************************************************************************************************************/
+
+//	@author		Michael Roberts <michael@observerly.com>
+//	@package	@observerly/sidera
+//	@license	Copyright © 2021-2024 observerly
+
+/*****************************************************************************************************************/
+
+package mpc
+
+/*****************************************************************************************************************/
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/observerly/sidera/pkg/epoch"
+)
+
+/*****************************************************************************************************************/
+
+// the digits of the base-62 alphabet used by the Minor Planet Center's packed formats:
+const base62 = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+/*****************************************************************************************************************/
+
+// the centuries encoded by the leading letter of a packed date or provisional designation:
+var centuries = map[byte]int{
+	'I': 1800,
+	'J': 1900,
+	'K': 2000,
+}
+
+/*****************************************************************************************************************/
+
+/*
+the value of a single character in the base-62 alphabet, or -1 if the character is not in the alphabet
+*/
+func getBase62Value(c byte) int {
+	return strings.IndexByte(base62, c)
+}
+
+/*****************************************************************************************************************/
+
+/*
+unpacks a packed minor planet number, e.g., "00001" is 1, "A0345" is 100345, and "~0000" is 620000
+
+Numbers below 100,000 are written as five digits. Numbers from 100,000 to 619,999 replace the leading two
+digits by a single letter, A-Z for 10-35 and a-z for 36-61. Numbers from 620,000 onwards are written as a
+tilde followed by four base-62 digits, counting from 620,000.
+*/
+func UnpackNumber(packed string) (int, error) {
+	packed = strings.TrimSpace(packed)
+
+	if len(packed) != 5 {
+		return 0, fmt.Errorf("invalid packed number: %q", packed)
+	}
+
+	if packed[0] == '~' {
+		n := 0
+
+		for i := 1; i < 5; i++ {
+			v := getBase62Value(packed[i])
+
+			if v < 0 {
+				return 0, fmt.Errorf("invalid packed number: %q", packed)
+			}
+
+			n = n*62 + v
+		}
+
+		return 620000 + n, nil
+	}
+
+	v := getBase62Value(packed[0])
+
+	n, err := strconv.Atoi(packed[1:])
+
+	if v < 0 || err != nil || strings.ContainsAny(packed[1:], "+-") {
+		return 0, fmt.Errorf("invalid packed number: %q", packed)
+	}
+
+	return v*10000 + n, nil
+}
+
+/*****************************************************************************************************************/
+
+/*
+unpacks a packed provisional designation, e.g., "K07Tf8A" is "2007 TA418", and "J95O010" is "1995 O1"
+
+Minor planet designations are made up of the century letter, the two digit year, the half-month letter, a
+two character cycle count (where a leading letter stands for a multiple of ten) and the second letter.
+Comet designations share the same layout, with the number of the comet in place of the cycle count, and
+the final character either "0" or a lowercase letter for a fragment. The survey designations of the
+Palomar-Leiden and Trojan surveys, e.g., "PLS2040" for "2040 P-L" and "T1S3138" for "3138 T-1", are also
+recognised.
+*/
+func UnpackProvisionalDesignation(packed string) (string, error) {
+	packed = strings.TrimSpace(packed)
+
+	if len(packed) != 7 {
+		return "", fmt.Errorf("invalid packed provisional designation: %q", packed)
+	}
+
+	// the survey designations, i.e., the Palomar-Leiden survey and the three Trojan surveys:
+	switch packed[:3] {
+	case "PLS", "T1S", "T2S", "T3S":
+		survey := map[string]string{"PLS": "P-L", "T1S": "T-1", "T2S": "T-2", "T3S": "T-3"}[packed[:3]]
+
+		return fmt.Sprintf("%s %s", packed[3:], survey), nil
+	}
+
+	century, ok := centuries[packed[0]]
+
+	year, err := strconv.Atoi(packed[1:3])
+
+	if !ok || err != nil || packed[3] < 'A' || packed[3] > 'Z' {
+		return "", fmt.Errorf("invalid packed provisional designation: %q", packed)
+	}
+
+	// the cycle count, or the comet number, where the leading character may be a base-62 digit:
+	if getBase62Value(packed[4]) < 0 || packed[5] < '0' || packed[5] > '9' {
+		return "", fmt.Errorf("invalid packed provisional designation: %q", packed)
+	}
+
+	cycle := getBase62Value(packed[4])*10 + int(packed[5]-'0')
+
+	designation := fmt.Sprintf("%d %c", century+year, packed[3])
+
+	switch last := packed[6]; {
+	// a comet without a fragment designation:
+	case last == '0':
+		return fmt.Sprintf("%s%d", designation, cycle), nil
+	// a fragment of a comet, e.g., "J93F02a" is "1993 F2-A":
+	case last >= 'a' && last <= 'z':
+		return fmt.Sprintf("%s%d-%c", designation, cycle, last-'a'+'A'), nil
+	// a minor planet, with the cycle count omitted when zero:
+	case last >= 'A' && last <= 'Z':
+		if cycle == 0 {
+			return fmt.Sprintf("%s%c", designation, last), nil
+		}
+
+		return fmt.Sprintf("%s%c%d", designation, last, cycle), nil
+	}
+
+	return "", fmt.Errorf("invalid packed provisional designation: %q", packed)
+}
+
+/*****************************************************************************************************************/
+
+/*
+unpacks a packed date, e.g., "K239D" is 13 September 2023, returning the Julian Date at 0h TT
+
+The packed date is made up of the century letter, the two digit year, and the month and day each written
+as a single base-62 digit, i.e., 1-9 followed by A-V for 10-31. Packed dates may carry additional digits
+giving the fraction of the day, e.g., "K239D5" for 13.5 September 2023.
+*/
+func UnpackEpoch(packed string) (float64, error) {
+	packed = strings.TrimSpace(packed)
+
+	if len(packed) < 5 {
+		return 0, fmt.Errorf("invalid packed epoch: %q", packed)
+	}
+
+	century, ok := centuries[packed[0]]
+
+	year, err := strconv.Atoi(packed[1:3])
+
+	month := getBase62Value(packed[3])
+
+	day := getBase62Value(packed[4])
+
+	if !ok || err != nil || month < 1 || month > 12 || day < 1 || day > 31 {
+		return 0, fmt.Errorf("invalid packed epoch: %q", packed)
+	}
+
+	fraction := 0.0
+
+	if len(packed) > 5 {
+		fraction, err = strconv.ParseFloat("0."+packed[5:], 64)
+
+		if err != nil {
+			return 0, fmt.Errorf("invalid packed epoch: %q", packed)
+		}
+	}
+
+	return getJulianDate(century+year, month, float64(day)+fraction), nil
+}
+
+/*****************************************************************************************************************/
+
+/*
+the Julian Date for a given year, month and (fractional) day of the month
+*/
+func getJulianDate(year int, month int, day float64) float64 {
+	// the Julian Date of 0h on the zeroth day of the month, i.e., the last day of the previous month:
+	JD := epoch.GetJulianDate(time.Date(year, time.Month(month), 0, 0, 0, 0, 0, time.UTC))
+
+	return JD + day
+}
+
+/*****************************************************************************************************************/
@@ -0,0 +1,131 @@
+/*****************************************************************************************************************/
+
+//	@author		Michael Roberts <michael@observerly.com>
+//	@package	@observerly/sidera
+//	@license	Copyright © 2021-2024 observerly
+
+/*****************************************************************************************************************/
+
+package mpc
+
+/*****************************************************************************************************************/
+
+import (
+	"math"
+	"testing"
+)
+
+/*****************************************************************************************************************/
+
+func TestUnpackNumber(t *testing.T) {
+	tests := map[string]int{
+		"00001": 1,
+		"99999": 99999,
+		"A0345": 100345,
+		"a0017": 360017,
+		"z9999": 619999,
+		"~0000": 620000,
+		"~000z": 620061,
+		"~AZaz": 620000 + 10*62*62*62 + 35*62*62 + 36*62 + 61,
+	}
+
+	for packed, want := range tests {
+		got, err := UnpackNumber(packed)
+
+		if err != nil {
+			t.Errorf("%q: got error %v", packed, err)
+		}
+
+		if got != want {
+			t.Errorf("%q: got %d, wanted %d", packed, got, want)
+		}
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestUnpackNumberInvalid(t *testing.T) {
+	for _, packed := range []string{"", "0001", "000001", "!0001", "A00-1", "~00!0"} {
+		if _, err := UnpackNumber(packed); err == nil {
+			t.Errorf("%q: expected an error", packed)
+		}
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestUnpackProvisionalDesignation(t *testing.T) {
+	tests := map[string]string{
+		"J95X00A": "1995 XA",
+		"J95X01L": "1995 XL1",
+		"K07Tf8A": "2007 TA418",
+		"I98H01B": "1898 HB1",
+		"PLS2040": "2040 P-L",
+		"T1S3138": "3138 T-1",
+		"T3S1010": "1010 T-3",
+		"J95O010": "1995 O1",
+		"J93F02a": "1993 F2-A",
+		"K19Q040": "2019 Q4",
+	}
+
+	for packed, want := range tests {
+		got, err := UnpackProvisionalDesignation(packed)
+
+		if err != nil {
+			t.Errorf("%q: got error %v", packed, err)
+		}
+
+		if got != want {
+			t.Errorf("%q: got %q, wanted %q", packed, got, want)
+		}
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestUnpackProvisionalDesignationInvalid(t *testing.T) {
+	for _, packed := range []string{"", "K07Tf8", "L07Tf8A", "K0xTf8A", "K071f8A", "K07T!8A", "K07Tfa8", "K07Tf8!"} {
+		if _, err := UnpackProvisionalDesignation(packed); err == nil {
+			t.Errorf("%q: expected an error", packed)
+		}
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestUnpackEpoch(t *testing.T) {
+	tests := map[string]float64{
+		// 13 September 2023, 0h TT:
+		"K239D": 2460200.5,
+		// 1 January 2000, 0h TT:
+		"K0011": 2451544.5,
+		// 31 December 1996, 0h TT:
+		"J96CV": 2450448.5,
+		// 13.5 September 2023:
+		"K239D5": 2460201.0,
+	}
+
+	for packed, want := range tests {
+		got, err := UnpackEpoch(packed)
+
+		if err != nil {
+			t.Errorf("%q: got error %v", packed, err)
+		}
+
+		if math.Abs(got-want) > 1e-6 {
+			t.Errorf("%q: got %f, wanted %f", packed, got, want)
+		}
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestUnpackEpochInvalid(t *testing.T) {
+	for _, packed := range []string{"", "K239", "L239D", "K2x9D", "K230D", "K23DD", "K239W", "K239Dx"} {
+		if _, err := UnpackEpoch(packed); err == nil {
+			t.Errorf("%q: expected an error", packed)
+		}
+	}
+}
+
+/*****************************************************************************************************************/
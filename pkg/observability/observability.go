@@ -0,0 +1,323 @@
+/*****************************************************************************************************************/
+
+//	@author		Michael Roberts <michael@observerly.com>
+//	@package	@observerly/sidera
+//	@license	Copyright © 2021-2024 observerly
+
+/*****************************************************************************************************************/
+
+package observability
+
+/*****************************************************************************************************************/
+
+import (
+	"time"
+
+	"github.com/observerly/sidera/pkg/astrometry"
+	"github.com/observerly/sidera/pkg/common"
+	"github.com/observerly/sidera/pkg/coordinates"
+	"github.com/observerly/sidera/pkg/horizon"
+	moon "github.com/observerly/sidera/pkg/lunar"
+	"github.com/observerly/sidera/pkg/refraction"
+	sun "github.com/observerly/sidera/pkg/solar"
+)
+
+/*****************************************************************************************************************/
+
+// the altitude of the Sun, in degrees, below which the sky is astronomically dark:
+const ASTRONOMICAL_TWILIGHT float64 = -18
+
+/*****************************************************************************************************************/
+
+/*
+the constraints within which a target is observable, in addition to astronomical darkness
+
+The minimum altitude and the minimum separation from the Moon are in degrees. A maximum airmass of zero places no
+limit on the airmass, and an empty horizon profile is the geometric horizon, so that the zero value constrains the
+target only to be above the horizon in astronomical darkness.
+*/
+type Constraints struct {
+	MinimumAltitude       float64
+	MaximumAirmass        float64
+	MinimumMoonSeparation float64
+	Horizon               horizon.Profile
+}
+
+/*****************************************************************************************************************/
+
+/*
+an interval of time, from its start to its end
+*/
+type Window struct {
+	Start time.Time
+	End   time.Time
+}
+
+/*****************************************************************************************************************/
+
+/*
+a night, i.e., a period of astronomical darkness, and the usable time within it for which a target is observable
+*/
+type Night struct {
+	Start  time.Time
+	End    time.Time
+	Usable time.Duration
+}
+
+/*****************************************************************************************************************/
+
+/*
+the observability of a target within a date range, as the windows within which it is observable, the usable time
+on each night, and the total usable time
+*/
+type Report struct {
+	Target  common.EquatorialCoordinate
+	Windows []Window
+	Nights  []Night
+	Usable  time.Duration
+}
+
+/*****************************************************************************************************************/
+
+/*
+the state of the sky at a given datetime, i.e., the altitude of the Sun and the position of the Moon, which is
+common to all targets
+*/
+type state struct {
+	datetime time.Time
+	sun      float64
+	moon     common.EquatorialCoordinate
+}
+
+/*****************************************************************************************************************/
+
+// the interval at which the constraints are sampled, within which the boundaries are refined by bisection:
+const step = 5 * time.Minute
+
+/*****************************************************************************************************************/
+
+/*
+the state of the sky at a given datetime, for a given observer, where the altitude of the Sun is from its apparent
+position, as the mean longitude would misplace the Sun by up to two degrees, i.e., several minutes of twilight
+*/
+func getState(datetime time.Time, observer common.GeographicCoordinate) state {
+	return state{
+		datetime: datetime,
+		sun:      sun.GetApparentHorizontalCoordinate(datetime, observer).Altitude,
+		moon:     moon.GetEquatorialCoordinate(datetime),
+	}
+}
+
+/*****************************************************************************************************************/
+
+/*
+the states of the sky at the sampling interval between two datetimes, including both, for a given observer
+*/
+func getStates(start time.Time, end time.Time, observer common.GeographicCoordinate) []state {
+	states := []state{}
+
+	for datetime := start; datetime.Before(end); datetime = datetime.Add(step) {
+		states = append(states, getState(datetime, observer))
+	}
+
+	return append(states, getState(end, observer))
+}
+
+/*****************************************************************************************************************/
+
+/*
+whether the sky is astronomically dark in a given state
+*/
+func isDark(s state) bool {
+	return s.sun < ASTRONOMICAL_TWILIGHT
+}
+
+/*****************************************************************************************************************/
+
+/*
+whether a target is observable in a given state of the sky, for a given observer and set of constraints
+*/
+func isObservable(s state, observer common.GeographicCoordinate, target common.EquatorialCoordinate, constraints Constraints) bool {
+	if !isDark(s) {
+		return false
+	}
+
+	hz := coordinates.ConvertEquatorialToHorizontalCoordinate(s.datetime, observer, target)
+
+	if hz.Altitude < constraints.MinimumAltitude || !horizon.IsAboveHorizon(constraints.Horizon, hz) {
+		return false
+	}
+
+	if constraints.MaximumAirmass > 0 && refraction.GetAirmass(hz) > constraints.MaximumAirmass {
+		return false
+	}
+
+	return astrometry.GetAngularSeparation(s.moon, target) >= constraints.MinimumMoonSeparation
+}
+
+/*****************************************************************************************************************/
+
+/*
+finds the datetime at which a condition changes between two datetimes by bisection, to within a second, where the
+condition is assumed to change exactly once in the interval
+*/
+func bisect(a time.Time, b time.Time, f func(datetime time.Time) bool) time.Time {
+	fa := f(a)
+
+	for b.Sub(a) > time.Second {
+		mid := a.Add(b.Sub(a) / 2)
+
+		if f(mid) == fa {
+			a = mid
+		} else {
+			b = mid
+		}
+	}
+
+	return a.Add(b.Sub(a) / 2)
+}
+
+/*****************************************************************************************************************/
+
+/*
+finds the windows within which a condition holds across a series of states of the sky, refining each boundary by
+bisection, where a window which is open at the first or the last state is clipped to it
+*/
+func getWindows(states []state, condition func(s state) bool, observer common.GeographicCoordinate) []Window {
+	windows := []Window{}
+
+	f := func(datetime time.Time) bool {
+		return condition(getState(datetime, observer))
+	}
+
+	var window *Window
+
+	for i, s := range states {
+		ok := condition(s)
+
+		switch {
+		case ok && window == nil && i == 0:
+			window = &Window{Start: s.datetime}
+		case ok && window == nil:
+			window = &Window{Start: bisect(states[i-1].datetime, s.datetime, f)}
+		case !ok && window != nil:
+			window.End = bisect(states[i-1].datetime, s.datetime, f)
+			windows = append(windows, *window)
+			window = nil
+		}
+	}
+
+	if window != nil {
+		window.End = states[len(states)-1].datetime
+		windows = append(windows, *window)
+	}
+
+	return windows
+}
+
+/*****************************************************************************************************************/
+
+/*
+the nights within a date range, for a given observer, i.e., the periods of astronomical darkness, when the Sun is
+more than 18 degrees below the horizon
+
+A night which is already in progress at the start of the date range begins at the start, and a night still in
+progress at the end ends at the end. There are no nights within the summer of high latitudes, where the Sun does
+not reach astronomical darkness.
+*/
+func GetNights(start time.Time, end time.Time, observer common.GeographicCoordinate) []Window {
+	return getWindows(getStates(start, end, observer), isDark, observer)
+}
+
+/*****************************************************************************************************************/
+
+/*
+finds the windows within which a target is observable within a date range, for a given observer and set of
+constraints
+
+The target is observable when the sky is astronomically dark, and the target is above both the minimum altitude
+and the horizon profile, below the maximum airmass, and at least the minimum separation from the Moon. The
+constraints are sampled at intervals of five minutes, and each boundary is refined by bisection to within a
+second, so that only windows shorter than the sampling interval may be missed.
+*/
+func GetWindows(
+	start time.Time,
+	end time.Time,
+	observer common.GeographicCoordinate,
+	target common.EquatorialCoordinate,
+	constraints Constraints,
+) []Window {
+	condition := func(s state) bool {
+		return isObservable(s, observer, target, constraints)
+	}
+
+	return getWindows(getStates(start, end, observer), condition, observer)
+}
+
+/*****************************************************************************************************************/
+
+/*
+the observability report of a set of targets within a date range, for a given observer and set of constraints, in
+the order of the targets
+
+The report of each target gives the windows within which it is observable, the usable time within each night, and
+the total usable time across the date range. The states of the Sun and the Moon are computed once and shared
+between the targets.
+*/
+func GetReport(
+	start time.Time,
+	end time.Time,
+	observer common.GeographicCoordinate,
+	targets []common.EquatorialCoordinate,
+	constraints Constraints,
+) []Report {
+	states := getStates(start, end, observer)
+
+	nights := getWindows(states, isDark, observer)
+
+	reports := make([]Report, len(targets))
+
+	for i, target := range targets {
+		condition := func(s state) bool {
+			return isObservable(s, observer, target, constraints)
+		}
+
+		windows := getWindows(states, condition, observer)
+
+		report := Report{
+			Target:  target,
+			Windows: windows,
+			Nights:  make([]Night, len(nights)),
+		}
+
+		for j, night := range nights {
+			report.Nights[j] = Night{Start: night.Start, End: night.End}
+
+			// the windows lie within the nights, as the target is only observable in astronomical darkness, though
+			// their boundaries are refined independently, so only the overlap with the night is counted:
+			for _, window := range windows {
+				a, b := window.Start, window.End
+
+				if a.Before(night.Start) {
+					a = night.Start
+				}
+
+				if b.After(night.End) {
+					b = night.End
+				}
+
+				if b.After(a) {
+					report.Nights[j].Usable += b.Sub(a)
+				}
+			}
+
+			report.Usable += report.Nights[j].Usable
+		}
+
+		reports[i] = report
+	}
+
+	return reports
+}
+
+/*****************************************************************************************************************/
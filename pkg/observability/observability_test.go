@@ -0,0 +1,255 @@
+/*****************************************************************************************************************/
+
+//	@author		Michael Roberts <michael@observerly.com>
+//	@package	@observerly/sidera
+//	@license	Copyright © 2021-2024 observerly
+
+/*****************************************************************************************************************/
+
+package observability
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/observerly/sidera/pkg/astrometry"
+	"github.com/observerly/sidera/pkg/common"
+	"github.com/observerly/sidera/pkg/coordinates"
+	"github.com/observerly/sidera/pkg/horizon"
+	moon "github.com/observerly/sidera/pkg/lunar"
+	"github.com/observerly/sidera/pkg/refraction"
+	sun "github.com/observerly/sidera/pkg/solar"
+)
+
+/*****************************************************************************************************************/
+
+// We define a date range of three days as some arbitrary dates for testing purposes:
+var start time.Time = time.Date(2021, 5, 14, 0, 0, 0, 0, time.UTC)
+
+var end time.Time = start.Add(72 * time.Hour)
+
+/*****************************************************************************************************************/
+
+var observer common.GeographicCoordinate = common.GeographicCoordinate{
+	Latitude:  19.8207,
+	Longitude: -155.468094,
+	Elevation: 4205,
+}
+
+/*****************************************************************************************************************/
+
+// Arcturus is well placed in the evening sky in May:
+var arcturus common.EquatorialCoordinate = common.EquatorialCoordinate{
+	RightAscension: 213.9153,
+	Declination:    19.1824,
+}
+
+/*****************************************************************************************************************/
+
+// Betelgeuse is close to the Sun in May, and sets in the evening twilight:
+var betelgeuse common.EquatorialCoordinate = common.EquatorialCoordinate{
+	RightAscension: 88.7929583,
+	Declination:    7.4070639,
+}
+
+/*****************************************************************************************************************/
+
+var constraints Constraints = Constraints{
+	MinimumAltitude:       30,
+	MaximumAirmass:        1.8,
+	MinimumMoonSeparation: 20,
+}
+
+/*****************************************************************************************************************/
+
+func TestGetNights(t *testing.T) {
+	nights := GetNights(start, end, observer)
+
+	if len(nights) < 3 || len(nights) > 4 {
+		t.Fatalf("got %d nights, wanted three or four within three days", len(nights))
+	}
+
+	for i, night := range nights {
+		// the Sun is at the limit of astronomical darkness at the start and end, unless clipped by the date range:
+		for _, datetime := range []time.Time{night.Start, night.End} {
+			if datetime.Equal(start) || datetime.Equal(end) {
+				continue
+			}
+
+			if h := sun.GetApparentHorizontalCoordinate(datetime, observer).Altitude; math.Abs(h-ASTRONOMICAL_TWILIGHT) > 0.01 {
+				t.Errorf("night %d: got a solar altitude of %f at %v, wanted %f", i, h, datetime, ASTRONOMICAL_TWILIGHT)
+			}
+		}
+
+		// a complete night in May, in Hawaii, lasts for around eight and a half hours:
+		if night.Start.After(start) && night.End.Before(end) {
+			if d := night.End.Sub(night.Start).Hours(); d < 7.5 || d > 9.5 {
+				t.Errorf("night %d: got a duration of %f hours", i, d)
+			}
+		}
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetWindows(t *testing.T) {
+	windows := GetWindows(start, end, observer, arcturus, constraints)
+
+	if len(windows) < 3 {
+		t.Fatalf("got %d windows, wanted at least one on each night", len(windows))
+	}
+
+	for i, window := range windows {
+		if !window.End.After(window.Start) {
+			t.Errorf("window %d: got a start of %v after the end of %v", i, window.Start, window.End)
+		}
+
+		// the constraints are all satisfied at the middle of each window:
+		mid := window.Start.Add(window.End.Sub(window.Start) / 2)
+
+		hz := coordinates.ConvertEquatorialToHorizontalCoordinate(mid, observer, arcturus)
+
+		if hz.Altitude < 30 {
+			t.Errorf("window %d: got an altitude of %f, wanted at least 30", i, hz.Altitude)
+		}
+
+		if X := refraction.GetAirmass(hz); X > 1.8 {
+			t.Errorf("window %d: got an airmass of %f, wanted at most 1.8", i, X)
+		}
+
+		if h := sun.GetApparentHorizontalCoordinate(mid, observer).Altitude; h > ASTRONOMICAL_TWILIGHT {
+			t.Errorf("window %d: got a solar altitude of %f, wanted astronomical darkness", i, h)
+		}
+
+		if θ := astrometry.GetAngularSeparation(moon.GetEquatorialCoordinate(mid), arcturus); θ < 20 {
+			t.Errorf("window %d: got a lunar separation of %f, wanted at least 20", i, θ)
+		}
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetWindowsUnobservable(t *testing.T) {
+	if windows := GetWindows(start, end, observer, betelgeuse, constraints); len(windows) != 0 {
+		t.Errorf("got %d windows, wanted none for a target close to the Sun", len(windows))
+	}
+
+	// a target at the position of the Moon is never far enough from it:
+	target := moon.GetEquatorialCoordinate(start.Add(12 * time.Hour))
+
+	day := start.Add(24 * time.Hour)
+
+	if windows := GetWindows(start, day, observer, target, Constraints{MinimumMoonSeparation: 30}); len(windows) != 0 {
+		t.Errorf("got %d windows, wanted none for a target close to the Moon", len(windows))
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetWindowsHorizon(t *testing.T) {
+	open := GetWindows(start, end, observer, arcturus, Constraints{})
+
+	// Arcturus rises in the east, behind a wall of trees:
+	obstructed := GetWindows(start, end, observer, arcturus, Constraints{
+		Horizon: horizon.NewProfile([]horizon.Point{
+			{Azimuth: 0, Altitude: 70},
+			{Azimuth: 179, Altitude: 70},
+			{Azimuth: 180, Altitude: 0},
+			{Azimuth: 359, Altitude: 0},
+		}),
+	})
+
+	if len(open) == 0 || len(obstructed) == 0 {
+		t.Fatalf("got %d and %d windows, wanted at least one of each", len(open), len(obstructed))
+	}
+
+	if !obstructed[0].Start.After(open[0].Start) {
+		t.Errorf("got %v, wanted the target to become observable later than %v", obstructed[0].Start, open[0].Start)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetReport(t *testing.T) {
+	reports := GetReport(start, end, observer, []common.EquatorialCoordinate{arcturus, betelgeuse}, constraints)
+
+	if len(reports) != 2 {
+		t.Fatalf("got %d reports, wanted one for each target", len(reports))
+	}
+
+	if reports[0].Target != arcturus || reports[1].Target != betelgeuse {
+		t.Errorf("got the reports out of the order of the targets")
+	}
+
+	nights := GetNights(start, end, observer)
+
+	for i, report := range reports {
+		if len(report.Nights) != len(nights) {
+			t.Errorf("report %d: got %d nights, wanted %d", i, len(report.Nights), len(nights))
+		}
+
+		total := time.Duration(0)
+
+		for _, window := range report.Windows {
+			total += window.End.Sub(window.Start)
+		}
+
+		// the usable time is the total time within the windows, to within the refinement of their boundaries:
+		if math.Abs(report.Usable.Seconds()-total.Seconds()) > 5 {
+			t.Errorf("report %d: got %v usable, wanted %v", i, report.Usable, total)
+		}
+
+		for j, night := range report.Nights {
+			if night.Usable > night.End.Sub(night.Start) {
+				t.Errorf("report %d, night %d: got %v usable in a night of %v", i, j, night.Usable, night.End.Sub(night.Start))
+			}
+		}
+	}
+
+	// Arcturus is observable for several hours a night, and Betelgeuse not at all:
+	if h := reports[0].Usable.Hours(); h < 9 {
+		t.Errorf("got %f hours usable for Arcturus, wanted several hours a night", h)
+	}
+
+	if reports[1].Usable != 0 {
+		t.Errorf("got %v usable for Betelgeuse, wanted none", reports[1].Usable)
+	}
+
+	// the windows of the report match those for the target alone:
+	windows := GetWindows(start, end, observer, arcturus, constraints)
+
+	if len(windows) != len(reports[0].Windows) {
+		t.Errorf("got %d windows, wanted %d", len(reports[0].Windows), len(windows))
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetNightsEquinox(t *testing.T) {
+	// an observer on the equator, at the Greenwich meridian, on the night of the March equinox of 2021, when the
+	// Sun sets perpendicularly to the horizon at 15 degrees an hour, so that astronomical twilight lasts 1h 12m:
+	equator := common.GeographicCoordinate{Latitude: 0, Longitude: 0, Elevation: 0}
+
+	nights := GetNights(time.Date(2021, 3, 20, 12, 0, 0, 0, time.UTC), time.Date(2021, 3, 21, 12, 0, 0, 0, time.UTC), equator)
+
+	if len(nights) != 1 {
+		t.Fatalf("got %d nights, wanted 1", len(nights))
+	}
+
+	// with the equation of time of -7m 30s, the geometric Sun sets at 18:07:30 and rises at 06:07:30, so that
+	// astronomical darkness is from 19:19:30 to 04:55:30:
+	start := time.Date(2021, 3, 20, 19, 19, 30, 0, time.UTC)
+
+	end := time.Date(2021, 3, 21, 4, 55, 30, 0, time.UTC)
+
+	if Δ := nights[0].Start.Sub(start); math.Abs(Δ.Seconds()) > 60 {
+		t.Errorf("got a start of %v, wanted %v", nights[0].Start, start)
+	}
+
+	if Δ := nights[0].End.Sub(end); math.Abs(Δ.Seconds()) > 60 {
+		t.Errorf("got an end of %v, wanted %v", nights[0].End, end)
+	}
+}
+
+/*****************************************************************************************************************/
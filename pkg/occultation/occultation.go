@@ -0,0 +1,407 @@
+/*****************************************************************************************************************/
+
+//	@author		Michael Roberts <michael@observerly.com>
+//	@package	@observerly/sidera
+//	@license	Copyright © 2021-2024 observerly
+
+/*****************************************************************************************************************/
+
+package occultation
+
+/*****************************************************************************************************************/
+
+import (
+	"math"
+	"time"
+
+	"github.com/observerly/sidera/pkg/astrometry"
+	"github.com/observerly/sidera/pkg/common"
+	"github.com/observerly/sidera/pkg/coordinates"
+	"github.com/observerly/sidera/pkg/epoch"
+	"github.com/observerly/sidera/pkg/internal/search"
+	moon "github.com/observerly/sidera/pkg/lunar"
+	"github.com/observerly/sidera/pkg/planets"
+	sun "github.com/observerly/sidera/pkg/solar"
+)
+
+/*****************************************************************************************************************/
+
+// the ratio of the mean radius of the Moon to the equatorial radius of the Earth:
+const MOON_RADIUS_RATIO float64 = 0.2725076
+
+/*****************************************************************************************************************/
+
+type EventType int
+
+/*****************************************************************************************************************/
+
+const (
+	// the target disappears behind the leading (eastern) limb of the Moon:
+	Disappearance EventType = iota
+	// the target reappears from behind the trailing (western) limb of the Moon:
+	Reappearance
+)
+
+/*****************************************************************************************************************/
+
+/*
+a target which may be occulted by the Moon, i.e., a star or a planet
+
+The equatorial coordinate of the target is its apparent geocentric position, referred to the mean equator and
+equinox of date, and the distance is in kilometres, which is infinite for a star.
+*/
+type Target struct {
+	Name                    string
+	GetEquatorialCoordinate func(datetime time.Time) (common.EquatorialCoordinate, float64)
+}
+
+/*****************************************************************************************************************/
+
+/*
+the disappearance or reappearance of a target at the limb of the Moon, as seen by an observer
+
+The position angle is the angle of the point of contact on the limb of the Moon, measured from the north point
+of the Moon's disk towards the east, in degrees. The limb is bright when the point of contact is on the sunlit
+half of the Moon's limb. The altitudes of the Moon and the Sun are their geometric altitudes at the event, in
+degrees.
+*/
+type Event struct {
+	Type          EventType
+	Datetime      time.Time
+	PositionAngle float64
+	BrightLimb    bool
+	MoonAltitude  float64
+	SunAltitude   float64
+}
+
+/*****************************************************************************************************************/
+
+/*
+an occultation of a target by the Moon, as seen by an observer
+*/
+type Occultation struct {
+	Target        string
+	Disappearance Event
+	Reappearance  Event
+}
+
+/*****************************************************************************************************************/
+
+/*
+the annual aberration of a target for a given datetime, as the corrections to its right ascension and
+declination in degrees
+
+See Meeus, "Astronomical Algorithms", Equation 23.3, including the terms in the eccentricity of the Earth's
+orbit. The aberration displaces a star by up to 20.5 arcseconds towards the apex of the Earth's motion, which
+is equivalent to around 40 seconds of time in the motion of the Moon, and so must be applied to the position
+of the star, though not to the position of the Moon, which shares the Earth's motion around the Sun.
+*/
+func getAberration(datetime time.Time, target common.EquatorialCoordinate) (float64, float64) {
+	// the number of centuries since J2000.0:
+	T := (epoch.GetJulianDate(datetime) - epoch.J2000) / 36525
+
+	// the constant of aberration, in degrees:
+	κ := 20.49552 / 3600
+
+	// the eccentricity of the Earth's orbit and the longitude of its perihelion:
+	e := 0.016708634 - 0.000042037*T - 0.0000001267*math.Pow(T, 2)
+
+	π := common.Radians(102.93735 + 1.71946*T + 0.00046*math.Pow(T, 2))
+
+	ε := common.Radians(astrometry.GetObliquityOfTheEcliptic(datetime))
+
+	λ := common.Radians(sun.GetTrueEclipticLongitude(datetime))
+
+	α := common.Radians(target.RightAscension)
+
+	δ := common.Radians(target.Declination)
+
+	Δα := (-κ*(math.Cos(α)*math.Cos(λ)*math.Cos(ε)+math.Sin(α)*math.Sin(λ)) +
+		e*κ*(math.Cos(α)*math.Cos(π)*math.Cos(ε)+math.Sin(α)*math.Sin(π))) / math.Cos(δ)
+
+	Δδ := -κ*(math.Cos(λ)*math.Cos(ε)*(math.Tan(ε)*math.Cos(δ)-math.Sin(α)*math.Sin(δ))+math.Cos(α)*math.Sin(δ)*math.Sin(λ)) +
+		e*κ*(math.Cos(π)*math.Cos(ε)*(math.Tan(ε)*math.Cos(δ)-math.Sin(α)*math.Sin(δ))+math.Cos(α)*math.Sin(δ)*math.Sin(π))
+
+	return Δα, Δδ
+}
+
+/*****************************************************************************************************************/
+
+/*
+the target for a star, given its equatorial coordinate referred to the mean equator and equinox of J2000.0
+
+The coordinate of the star is precessed to the mean equator and equinox of date, and corrected for annual
+aberration. The proper motion of the star is not included, and should be applied to its coordinate beforehand
+for stars of high proper motion.
+*/
+func NewStar(name string, coordinate common.EquatorialCoordinate) Target {
+	return Target{
+		Name: name,
+		GetEquatorialCoordinate: func(datetime time.Time) (common.EquatorialCoordinate, float64) {
+			eq := coordinates.GetPrecessedEquatorialCoordinate(datetime, coordinate)
+
+			Δα, Δδ := getAberration(datetime, eq)
+
+			return common.EquatorialCoordinate{
+				RightAscension: math.Mod(eq.RightAscension+Δα+360, 360),
+				Declination:    eq.Declination + Δδ,
+			}, math.Inf(1)
+		},
+	}
+}
+
+/*****************************************************************************************************************/
+
+/*
+the target for a major planet, using its geocentric position corrected for light travel time and annual
+aberration
+
+The occultation of a planet is timed for its centre; the disappearance or reappearance of its whole disk is
+gradual, over a period of up to around a minute and a half for Jupiter.
+*/
+func NewPlanet(planet planets.Planet) Target {
+	return Target{
+		Name: planet.Name,
+		GetEquatorialCoordinate: func(datetime time.Time) (common.EquatorialCoordinate, float64) {
+			eq, Δ := planets.GetEquatorialCoordinate(datetime, planet)
+
+			Δα, Δδ := getAberration(datetime, eq)
+
+			return common.EquatorialCoordinate{
+				RightAscension: math.Mod(eq.RightAscension+Δα+360, 360),
+				Declination:    eq.Declination + Δδ,
+			}, Δ * common.AU
+		},
+	}
+}
+
+/*****************************************************************************************************************/
+
+/*
+the topocentric direction of a body, as a unit vector in the equatorial frame of date, and its topocentric
+distance in kilometres, given its geocentric equatorial coordinate and distance and the geocentric position of
+the observer
+*/
+func getTopocentricDirection(
+	eq common.EquatorialCoordinate,
+	distance float64,
+	observer common.CartesianCoordinate,
+) (common.CartesianCoordinate, float64) {
+	α := common.Radians(eq.RightAscension)
+
+	δ := common.Radians(eq.Declination)
+
+	u := common.CartesianCoordinate{
+		X: math.Cos(δ) * math.Cos(α),
+		Y: math.Cos(δ) * math.Sin(α),
+		Z: math.Sin(δ),
+	}
+
+	// the parallax of a star is negligible:
+	if math.IsInf(distance, 1) {
+		return u, distance
+	}
+
+	p := common.CartesianCoordinate{
+		X: distance*u.X - observer.X,
+		Y: distance*u.Y - observer.Y,
+		Z: distance*u.Z - observer.Z,
+	}
+
+	ρ := math.Sqrt(p.X*p.X + p.Y*p.Y + p.Z*p.Z)
+
+	return common.CartesianCoordinate{X: p.X / ρ, Y: p.Y / ρ, Z: p.Z / ρ}, ρ
+}
+
+/*****************************************************************************************************************/
+
+/*
+the position angle of the direction b from the direction a, measured from north through east, in degrees
+*/
+func getPositionAngle(a common.CartesianCoordinate, b common.CartesianCoordinate) float64 {
+	α1, δ1 := math.Atan2(a.Y, a.X), math.Asin(a.Z)
+
+	α2, δ2 := math.Atan2(b.Y, b.X), math.Asin(b.Z)
+
+	χ := common.Degrees(math.Atan2(
+		math.Cos(δ2)*math.Sin(α2-α1),
+		math.Cos(δ1)*math.Sin(δ2)-math.Sin(δ1)*math.Cos(δ2)*math.Cos(α2-α1),
+	))
+
+	if χ < 0 {
+		χ += 360
+	}
+
+	return χ
+}
+
+/*****************************************************************************************************************/
+
+/*
+the topocentric angular separation of a target from the limb of the Moon for a given datetime and observer, in
+degrees, which is negative when the target is behind the Moon, together with the topocentric directions of the
+Moon and the target
+*/
+func getLimbDistance(
+	datetime time.Time,
+	observer common.GeographicCoordinate,
+	target Target,
+) (float64, common.CartesianCoordinate, common.CartesianCoordinate) {
+	o, _ := coordinates.GetObserverPosition(datetime, observer)
+
+	m, ρ := getTopocentricDirection(moon.GetEquatorialCoordinate(datetime), moon.GetDistance(datetime), o)
+
+	eq, Δ := target.GetEquatorialCoordinate(datetime)
+
+	t, _ := getTopocentricDirection(eq, Δ, o)
+
+	separation := common.Degrees(math.Acos(math.Max(-1, math.Min(1, m.X*t.X+m.Y*t.Y+m.Z*t.Z))))
+
+	// the topocentric semi-diameter of the Moon:
+	s := common.Degrees(math.Asin(MOON_RADIUS_RATIO * common.EARTH_EQUATORIAL_RADIUS / ρ))
+
+	return separation - s, m, t
+}
+
+/*****************************************************************************************************************/
+
+/*
+the circumstances of the disappearance or reappearance of a target at a given datetime, where the direction of
+the Sun, which sets the bright limb and the altitude of the Sun, is from its apparent position
+*/
+func getEvent(datetime time.Time, eventType EventType, observer common.GeographicCoordinate, target Target) Event {
+	_, m, t := getLimbDistance(datetime, observer, target)
+
+	o, vertical := coordinates.GetObserverPosition(datetime, observer)
+
+	s, _ := getTopocentricDirection(sun.GetApparentEquatorialCoordinate(datetime), sun.GetDistance(datetime)*common.AU, o)
+
+	χ := getPositionAngle(m, t)
+
+	// the position angle of the midpoint of the Moon's bright limb, i.e., of the direction towards the Sun:
+	bright := getPositionAngle(m, s)
+
+	return Event{
+		Type:          eventType,
+		Datetime:      datetime,
+		PositionAngle: χ,
+		BrightLimb:    math.Abs(math.Remainder(χ-bright, 360)) < 90,
+		MoonAltitude:  common.Degrees(math.Asin(m.X*vertical.X + m.Y*vertical.Y + m.Z*vertical.Z)),
+		SunAltitude:   common.Degrees(math.Asin(s.X*vertical.X + s.Y*vertical.Y + s.Z*vertical.Z)),
+	}
+}
+
+/*****************************************************************************************************************/
+
+/*
+finds the occultations of the given targets by the Moon for an observer within a date range
+
+The geocentric separation of each target from the Moon is first sampled every half an hour, and wherever it is
+small enough for an occultation to be possible from somewhere on the Earth, the topocentric separation of the
+target from the limb of the Moon is sampled every minute. Each disappearance and reappearance is then refined
+by bisection, and any local minimum of the separation is refined by golden section search, so that the short
+occultations near the northern and southern limits of an occultation track are also found.
+
+An occultation is returned if its disappearance or reappearance is within the date range, and the Moon is
+above the observer's horizon at either event; the Sun may be above the horizon, in which case only the
+brightest targets are likely to be observable. The events are accurate to within around half a minute of time,
+as limited by the accuracy of the lunar ephemeris, and do not account for the irregular profile of the limb.
+*/
+func GetOccultations(
+	start time.Time,
+	end time.Time,
+	observer common.GeographicCoordinate,
+	targets []Target,
+) []Occultation {
+	occultations := []Occultation{}
+
+	// the occultations in progress at the start of the date range may have begun up to around two hours earlier:
+	from, to := start.Add(-2*time.Hour), end.Add(2*time.Hour)
+
+	for _, target := range targets {
+		f := func(datetime time.Time) float64 {
+			d, _, _ := getLimbDistance(datetime, observer, target)
+			return d
+		}
+
+		// the datetime up to which the topocentric separation has been sampled:
+		scanned := from
+
+		var disappearance time.Time
+
+		for datetime := from; datetime.Before(to); datetime = datetime.Add(30 * time.Minute) {
+			eq, _ := target.GetEquatorialCoordinate(datetime)
+
+			// the Moon may be displaced by up to around one degree by parallax, and moves by around a quarter of a
+			// degree in each half an hour:
+			if astrometry.GetAngularSeparation(moon.GetEquatorialCoordinate(datetime), eq) > 2 {
+				continue
+			}
+
+			a := datetime.Add(-time.Hour)
+
+			if a.Before(scanned) {
+				a = scanned
+			}
+
+			b := datetime.Add(time.Hour)
+
+			if b.After(to) {
+				b = to
+			}
+
+			fa, fb := f(a), f(a.Add(time.Minute))
+
+			for t := a.Add(time.Minute); !t.After(b); t = t.Add(time.Minute) {
+				fc := f(t.Add(time.Minute))
+
+				events := []time.Time{}
+
+				switch {
+				case fa > 0 && fb <= 0, fa <= 0 && fb > 0:
+					events = append(events, search.Bisect(t.Add(-time.Minute), t, f))
+				case fb < fa && fb < fc && fb > 0:
+					// a local minimum, which may conceal a short occultation between the samples:
+					if m := search.Minimise(t.Add(-time.Minute), t.Add(time.Minute), f); f(m) < 0 {
+						events = append(events, search.Bisect(t.Add(-time.Minute), m, f), search.Bisect(m, t.Add(time.Minute), f))
+					}
+				}
+
+				for _, event := range events {
+					if f(event.Add(-time.Second)) > 0 {
+						disappearance = event
+						continue
+					}
+
+					if disappearance.IsZero() || event.Before(start) && disappearance.Before(start) ||
+						event.After(end) && disappearance.After(end) {
+						disappearance = time.Time{}
+						continue
+					}
+
+					occultation := Occultation{
+						Target:        target.Name,
+						Disappearance: getEvent(disappearance, Disappearance, observer, target),
+						Reappearance:  getEvent(event, Reappearance, observer, target),
+					}
+
+					disappearance = time.Time{}
+
+					if occultation.Disappearance.MoonAltitude < 0 && occultation.Reappearance.MoonAltitude < 0 {
+						continue
+					}
+
+					occultations = append(occultations, occultation)
+				}
+
+				fa, fb = fb, fc
+			}
+
+			scanned = b
+		}
+	}
+
+	return occultations
+}
+
+/*****************************************************************************************************************/
@@ -0,0 +1,301 @@
+/*****************************************************************************************************************/
+
+//	@author		Michael Roberts <michael@observerly.com>
+//	@package	@observerly/sidera
+//	@license	Copyright © 2021-2024 observerly
+
+/*****************************************************************************************************************/
+
+package occultation
+
+/*****************************************************************************************************************/
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/observerly/sidera/pkg/common"
+	"github.com/observerly/sidera/pkg/coordinates"
+	moon "github.com/observerly/sidera/pkg/lunar"
+	"github.com/observerly/sidera/pkg/planets"
+	sun "github.com/observerly/sidera/pkg/solar"
+)
+
+/*****************************************************************************************************************/
+
+// the Mauna Kea Observatories, Hawaii:
+var observer common.GeographicCoordinate = common.GeographicCoordinate{
+	Latitude:  19.82067,
+	Longitude: -155.468094,
+	Elevation: 4205,
+}
+
+/*****************************************************************************************************************/
+
+// the evening of 12 April 2024 in Hawaii, when the Moon was a waxing crescent, high in the western sky:
+var datetime time.Time = time.Date(2024, 4, 13, 6, 0, 0, 0, time.UTC)
+
+/*****************************************************************************************************************/
+
+/*
+a star which lies exactly behind the centre of the Moon, as seen by the observer at the given datetime
+*/
+func getCentralStar(datetime time.Time, offset float64) Target {
+	o, _ := coordinates.GetObserverPosition(datetime, observer)
+
+	m, _ := getTopocentricDirection(moon.GetEquatorialCoordinate(datetime), moon.GetDistance(datetime), o)
+
+	eq := common.EquatorialCoordinate{
+		RightAscension: common.Degrees(math.Atan2(m.Y, m.X)),
+		Declination:    common.Degrees(math.Asin(m.Z)) + offset,
+	}
+
+	return Target{
+		Name: "Central",
+		GetEquatorialCoordinate: func(datetime time.Time) (common.EquatorialCoordinate, float64) {
+			return eq, math.Inf(1)
+		},
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetAberration(t *testing.T) {
+	// θ Persei on 2028 November 13.19, see Meeus, "Astronomical Algorithms", Example 23.a:
+	Δα, Δδ := getAberration(time.Date(2028, 11, 13, 4, 33, 36, 0, time.UTC), common.EquatorialCoordinate{
+		RightAscension: 41.5472,
+		Declination:    49.3485,
+	})
+
+	if math.Abs(Δα*3600-30.045) > 0.5 {
+		t.Errorf("got %f, wanted %f", Δα*3600, 30.045)
+	}
+
+	if math.Abs(Δδ*3600-6.697) > 0.5 {
+		t.Errorf("got %f, wanted %f", Δδ*3600, 6.697)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestNewStar(t *testing.T) {
+	regulus := common.EquatorialCoordinate{RightAscension: 152.092962, Declination: 11.967209}
+
+	eq, Δ := NewStar("Regulus", regulus).GetEquatorialCoordinate(datetime)
+
+	if !math.IsInf(Δ, 1) {
+		t.Errorf("got %f, wanted an infinite distance", Δ)
+	}
+
+	// the star is displaced from its precessed position by no more than the constant of aberration:
+	precessed := coordinates.GetPrecessedEquatorialCoordinate(datetime, regulus)
+
+	if d := math.Hypot((eq.RightAscension-precessed.RightAscension)*math.Cos(common.Radians(eq.Declination)), eq.Declination-precessed.Declination) * 3600; d > 20.6 {
+		t.Errorf("got a displacement of %f arcseconds, wanted less than 20.6", d)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestNewPlanet(t *testing.T) {
+	eq, Δ := NewPlanet(planets.Venus).GetEquatorialCoordinate(datetime)
+
+	want, distance := planets.GetEquatorialCoordinate(datetime, planets.Venus)
+
+	if math.Abs(Δ-distance*common.AU) > 1e-6 {
+		t.Errorf("got %f, wanted %f", Δ, distance*common.AU)
+	}
+
+	if math.Abs(eq.RightAscension-want.RightAscension) > 0.01 || math.Abs(eq.Declination-want.Declination) > 0.01 {
+		t.Errorf("got %+v, wanted %+v", eq, want)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetPositionAngle(t *testing.T) {
+	a := common.CartesianCoordinate{X: 1, Y: 0, Z: 0}
+
+	tests := []struct {
+		b    common.CartesianCoordinate
+		want float64
+	}{
+		{common.CartesianCoordinate{X: math.Cos(0.01), Y: 0, Z: math.Sin(0.01)}, 0},
+		{common.CartesianCoordinate{X: math.Cos(0.01), Y: math.Sin(0.01), Z: 0}, 90},
+		{common.CartesianCoordinate{X: math.Cos(0.01), Y: 0, Z: -math.Sin(0.01)}, 180},
+		{common.CartesianCoordinate{X: math.Cos(0.01), Y: -math.Sin(0.01), Z: 0}, 270},
+	}
+
+	for _, test := range tests {
+		if got := getPositionAngle(a, test.b); math.Abs(math.Remainder(got-test.want, 360)) > 1e-9 {
+			t.Errorf("got %f, wanted %f", got, test.want)
+		}
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetOccultationsCentral(t *testing.T) {
+	got := GetOccultations(datetime.Add(-3*time.Hour), datetime.Add(3*time.Hour), observer, []Target{getCentralStar(datetime, 0)})
+
+	if len(got) != 1 {
+		t.Fatalf("got %d occultations, wanted 1", len(got))
+	}
+
+	d, r := got[0].Disappearance, got[0].Reappearance
+
+	if d.Type != Disappearance || r.Type != Reappearance || !d.Datetime.Before(datetime) || !r.Datetime.After(datetime) {
+		t.Fatalf("got %+v, wanted a disappearance before and a reappearance after %v", got[0], datetime)
+	}
+
+	// a central occultation is roughly symmetric about the instant of conjunction, though the topocentric motion
+	// of the Moon changes over its course as the parallax changes:
+	if Δ := math.Abs(d.Datetime.Add(r.Datetime.Sub(d.Datetime) / 2).Sub(datetime).Seconds()); Δ > 180 {
+		t.Errorf("got a mid-time %f seconds from the conjunction", Δ)
+	}
+
+	if duration := r.Datetime.Sub(d.Datetime); duration < 40*time.Minute || duration > 100*time.Minute {
+		t.Errorf("got a duration of %v", duration)
+	}
+
+	// the Moon moves eastwards, so the star disappears on its eastern limb, and reappears on its western limb:
+	if d.PositionAngle < 30 || d.PositionAngle > 150 {
+		t.Errorf("got %f, wanted a position angle on the eastern limb", d.PositionAngle)
+	}
+
+	if r.PositionAngle < 210 || r.PositionAngle > 330 {
+		t.Errorf("got %f, wanted a position angle on the western limb", r.PositionAngle)
+	}
+
+	// the waxing crescent Moon is lit on its western limb, facing the Sun:
+	if d.BrightLimb || !r.BrightLimb {
+		t.Errorf("got %t, %t, wanted a dark limb disappearance and a bright limb reappearance", d.BrightLimb, r.BrightLimb)
+	}
+
+	if d.MoonAltitude < 20 || d.SunAltitude > -6 {
+		t.Errorf("got %f, %f, wanted the Moon high in a dark sky", d.MoonAltitude, d.SunAltitude)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetOccultationsGraze(t *testing.T) {
+	// a star just inside the northern limb of the Moon, which is occulted for only a few minutes:
+	got := GetOccultations(datetime.Add(-3*time.Hour), datetime.Add(3*time.Hour), observer, []Target{getCentralStar(datetime, 0.26)})
+
+	if len(got) != 1 {
+		t.Fatalf("got %d occultations, wanted 1", len(got))
+	}
+
+	if duration := got[0].Reappearance.Datetime.Sub(got[0].Disappearance.Datetime); duration > 30*time.Minute {
+		t.Errorf("got a duration of %v, wanted a short occultation", duration)
+	}
+
+	if got[0].Disappearance.PositionAngle > 90 || got[0].Reappearance.PositionAngle < 270 {
+		t.Errorf("got %+v, wanted events near the northern limb", got[0])
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetOccultationsNone(t *testing.T) {
+	// a star which passes a degree north of the Moon is not occulted:
+	got := GetOccultations(datetime.Add(-3*time.Hour), datetime.Add(3*time.Hour), observer, []Target{getCentralStar(datetime, 1)})
+
+	if len(got) != 0 {
+		t.Errorf("got %+v, wanted no occultations", got)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetOccultationsMars(t *testing.T) {
+	// Griffith Observatory, Los Angeles:
+	griffith := common.GeographicCoordinate{Latitude: 34.1184, Longitude: -118.3004, Elevation: 346}
+
+	// the occultation of Mars by the full Moon on the evening of 7 December 2022, local time, on the night of the
+	// opposition of Mars, which was widely observed across the western United States shortly after moonrise:
+	got := GetOccultations(
+		time.Date(2022, 12, 8, 0, 0, 0, 0, time.UTC),
+		time.Date(2022, 12, 8, 6, 0, 0, 0, time.UTC),
+		griffith,
+		[]Target{NewPlanet(planets.Mars)},
+	)
+
+	if len(got) != 1 {
+		t.Fatalf("got %d occultations, wanted 1", len(got))
+	}
+
+	d, r := got[0].Disappearance, got[0].Reappearance
+
+	// Mars disappeared at around 6:30 pm PST, and reappeared around an hour later:
+	if d.Datetime.Before(time.Date(2022, 12, 8, 2, 15, 0, 0, time.UTC)) || d.Datetime.After(time.Date(2022, 12, 8, 2, 45, 0, 0, time.UTC)) {
+		t.Errorf("got a disappearance at %v, wanted around 02:30 UTC", d.Datetime)
+	}
+
+	if r.Datetime.Before(time.Date(2022, 12, 8, 3, 15, 0, 0, time.UTC)) || r.Datetime.After(time.Date(2022, 12, 8, 3, 45, 0, 0, time.UTC)) {
+		t.Errorf("got a reappearance at %v, wanted around 03:30 UTC", r.Datetime)
+	}
+
+	// Mars disappeared behind the eastern limb of the Moon and reappeared from behind its western limb:
+	if d.PositionAngle < 0 || d.PositionAngle > 180 {
+		t.Errorf("got %f, wanted a position angle on the eastern limb", d.PositionAngle)
+	}
+
+	if r.PositionAngle < 180 || r.PositionAngle > 360 {
+		t.Errorf("got %f, wanted a position angle on the western limb", r.PositionAngle)
+	}
+
+	if d.MoonAltitude < 5 || d.MoonAltitude > 30 {
+		t.Errorf("got %f, wanted the Moon low in the east shortly after moonrise", d.MoonAltitude)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetOccultationsVenus(t *testing.T) {
+	// the Royal Observatory, Greenwich:
+	greenwich := common.GeographicCoordinate{Latitude: 51.4769, Longitude: -0.0005, Elevation: 46}
+
+	// the daytime occultation of Venus by the waning crescent Moon on the morning of 9 November 2023, which was
+	// visible across Europe:
+	got := GetOccultations(
+		time.Date(2023, 11, 9, 6, 0, 0, 0, time.UTC),
+		time.Date(2023, 11, 9, 14, 0, 0, 0, time.UTC),
+		greenwich,
+		[]Target{NewPlanet(planets.Venus)},
+	)
+
+	if len(got) != 1 {
+		t.Fatalf("got %d occultations, wanted 1", len(got))
+	}
+
+	d, r := got[0].Disappearance, got[0].Reappearance
+
+	// Venus disappeared at around a quarter to ten in the morning, and reappeared around an hour later:
+	if d.Datetime.Before(time.Date(2023, 11, 9, 9, 30, 0, 0, time.UTC)) || d.Datetime.After(time.Date(2023, 11, 9, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("got a disappearance at %v, wanted around 09:45 UTC", d.Datetime)
+	}
+
+	if r.Datetime.Before(time.Date(2023, 11, 9, 10, 30, 0, 0, time.UTC)) || r.Datetime.After(time.Date(2023, 11, 9, 11, 0, 0, 0, time.UTC)) {
+		t.Errorf("got a reappearance at %v, wanted around 10:45 UTC", r.Datetime)
+	}
+
+	// the waning crescent Moon is lit on its eastern limb, facing the Sun, so that Venus disappeared at the bright
+	// limb, and reappeared at the dark limb:
+	if !d.BrightLimb || r.BrightLimb {
+		t.Errorf("got %t, %t, wanted a bright limb disappearance and a dark limb reappearance", d.BrightLimb, r.BrightLimb)
+	}
+
+	if d.SunAltitude < 0 {
+		t.Errorf("got %f, wanted the occultation to be in daylight", d.SunAltitude)
+	}
+
+	// the altitude of the Sun is that of its apparent position, to within its parallax:
+	if h := sun.GetApparentHorizontalCoordinate(d.Datetime, greenwich).Altitude; math.Abs(d.SunAltitude-h) > 0.01 {
+		t.Errorf("got %f, wanted %f", d.SunAltitude, h)
+	}
+}
+
+/*****************************************************************************************************************/
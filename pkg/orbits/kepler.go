@@ -0,0 +1,145 @@
+/*****************************************************************************************************************/
+
+//	@author		Michael Roberts <michael@observerly.com>
+//	@package	@observerly/sidera
+//	@license	Copyright © 2021-2024 observerly
+
+/*****************************************************************************************************************/
+
+package orbits
+
+/*****************************************************************************************************************/
+
+import (
+	"math"
+)
+
+/*****************************************************************************************************************/
+
+/*
+solves Kepler's equation, M = E - e sin E, for the eccentric anomaly E of an elliptical orbit
+
+The mean anomaly M and the eccentric anomaly E are in radians, and the eccentricity e must lie in the range
+0 ≤ e < 1. The equation is solved by Newton-Raphson iteration from a starting value which is close to the
+root even for highly eccentric orbits, with each step limited in size to guarantee convergence.
+*/
+func SolveKeplerEquation(M float64, e float64) float64 {
+	// reduce the mean anomaly to the range -π to π, keeping track of the number of whole revolutions:
+	n := math.Round(M / (2 * math.Pi))
+
+	m := M - n*2*math.Pi
+
+	// the starting value, which for highly eccentric orbits is biased towards aphelion:
+	E := m + e*math.Sin(m)
+
+	if e > 0.8 {
+		E = math.Copysign(math.Pi, m)
+	}
+
+	for i := 0; i < 100; i++ {
+		ΔE := (E - e*math.Sin(E) - m) / (1 - e*math.Cos(E))
+
+		// limit the size of each step to avoid overshooting the root:
+		ΔE = math.Max(-1, math.Min(1, ΔE))
+
+		E -= ΔE
+
+		if math.Abs(ΔE) < 1e-15 {
+			break
+		}
+	}
+
+	return E + n*2*math.Pi
+}
+
+/*****************************************************************************************************************/
+
+/*
+solves the hyperbolic form of Kepler's equation, M = e sinh H - H, for the hyperbolic anomaly H
+
+The mean anomaly M and the hyperbolic anomaly H are in radians, and the eccentricity e must be greater
+than 1. The equation is solved by Newton-Raphson iteration from a logarithmic starting value, which is
+close to the root for large mean anomalies.
+*/
+func SolveHyperbolicKeplerEquation(M float64, e float64) float64 {
+	// the starting value:
+	H := math.Copysign(math.Log(2*math.Abs(M)/e+1.8), M)
+
+	for i := 0; i < 100; i++ {
+		ΔH := (e*math.Sinh(H) - H - M) / (e*math.Cosh(H) - 1)
+
+		// limit the size of each step to avoid overshooting the root:
+		ΔH = math.Max(-1, math.Min(1, ΔH))
+
+		H -= ΔH
+
+		if math.Abs(ΔH) < 1e-15*math.Max(1, math.Abs(H)) {
+			break
+		}
+	}
+
+	return H
+}
+
+/*****************************************************************************************************************/
+
+/*
+solves Barker's equation, W = 3s + s³, for s = tan(ν/2) of a parabolic orbit, where ν is the true anomaly
+
+For a parabolic orbit with perihelion distance q (AU), W = 3k(t - T) / √(2q³), where k is the Gaussian
+gravitational constant and t - T is the time since perihelion in days. The cubic equation has a single real
+root, which is found in closed form.
+*/
+func SolveBarkerEquation(W float64) float64 {
+	Y := math.Cbrt(W/2 + math.Sqrt(math.Pow(W, 2)/4+1))
+
+	return Y - 1/Y
+}
+
+/*****************************************************************************************************************/
+
+/*
+the Stumpff functions c0(x), c1(x), c2(x) and c3(x), used to express the universal form of Kepler's equation
+for elliptical (x > 0), parabolic (x = 0) and hyperbolic (x < 0) orbits alike
+*/
+func getStumpffFunctions(x float64) (c0 float64, c1 float64, c2 float64, c3 float64) {
+	// for small arguments, sum the power series to avoid the loss of precision near x = 0:
+	if math.Abs(x) < 0.1 {
+		term0, term1 := 1.0, 1.0
+
+		term2, term3 := 0.5, 1.0/6
+
+		for k := 1; k < 12; k++ {
+			c0 += term0
+			c1 += term1
+			c2 += term2
+			c3 += term3
+
+			term0 *= -x / float64((2*k-1)*(2*k))
+			term1 *= -x / float64((2*k)*(2*k+1))
+			term2 *= -x / float64((2*k+1)*(2*k+2))
+			term3 *= -x / float64((2*k+2)*(2*k+3))
+		}
+
+		return c0, c1, c2, c3
+	}
+
+	if x > 0 {
+		s := math.Sqrt(x)
+
+		c0 = math.Cos(s)
+		c1 = math.Sin(s) / s
+	} else {
+		s := math.Sqrt(-x)
+
+		c0 = math.Cosh(s)
+		c1 = math.Sinh(s) / s
+	}
+
+	c2 = (1 - c0) / x
+	c3 = (1 - c1) / x
+
+	return c0, c1, c2, c3
+}
+
+/*****************************************************************************************************************/
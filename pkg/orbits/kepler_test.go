@@ -0,0 +1,88 @@
+/*****************************************************************************************************************/
+
+//	@author		Michael Roberts <michael@observerly.com>
+//	@package	@observerly/sidera
+//	@license	Copyright © 2021-2024 observerly
+
+/*****************************************************************************************************************/
+
+package orbits
+
+/*****************************************************************************************************************/
+
+import (
+	"math"
+	"testing"
+)
+
+/*****************************************************************************************************************/
+
+func TestSolveKeplerEquation(t *testing.T) {
+	// Meeus, "Astronomical Algorithms", Example 30.a, where e = 0.1 and M = 5°:
+	E := SolveKeplerEquation(5*math.Pi/180, 0.1)
+
+	if math.Abs(E*180/math.Pi-5.554589) > 1e-6 {
+		t.Errorf("got %f, wanted %f", E*180/math.Pi, 5.554589)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestSolveKeplerEquationHighEccentricity(t *testing.T) {
+	for _, e := range []float64{0, 0.5, 0.9, 0.99, 0.999999} {
+		for _, M := range []float64{-20, -3, -1e-6, 0, 1e-6, 0.1, 1, 3.14, 7} {
+			E := SolveKeplerEquation(M, e)
+
+			if math.Abs(E-e*math.Sin(E)-M) > 1e-12 {
+				t.Errorf("e = %f, M = %f: got E = %f, which does not satisfy Kepler's equation", e, M, E)
+			}
+		}
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestSolveHyperbolicKeplerEquation(t *testing.T) {
+	for _, e := range []float64{1.000001, 1.1, 2, 10} {
+		for _, M := range []float64{-100, -1, -1e-6, 0, 1e-6, 0.5, 5, 1000} {
+			H := SolveHyperbolicKeplerEquation(M, e)
+
+			if math.Abs(e*math.Sinh(H)-H-M) > 1e-10*math.Max(1, math.Abs(M)) {
+				t.Errorf("e = %f, M = %f: got H = %f, which does not satisfy Kepler's equation", e, M, H)
+			}
+		}
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestSolveBarkerEquation(t *testing.T) {
+	for _, W := range []float64{-100, -1, 0, 0.5, 4, 1e4} {
+		s := SolveBarkerEquation(W)
+
+		if math.Abs(3*s+math.Pow(s, 3)-W) > 1e-9*math.Max(1, math.Abs(W)) {
+			t.Errorf("W = %f: got s = %f, which does not satisfy Barker's equation", W, s)
+		}
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetStumpffFunctions(t *testing.T) {
+	for _, x := range []float64{-4, -0.2, -0.05, 0, 0.05, 0.2, 4} {
+		c0, c1, c2, c3 := getStumpffFunctions(x)
+
+		// the Stumpff functions satisfy the recurrence c(k)(x) = 1/k! - x c(k+2)(x):
+		if math.Abs(c0-(1-x*c2)) > 1e-14 || math.Abs(c1-(1-x*c3)) > 1e-14 {
+			t.Errorf("x = %f: got c0 = %f, c1 = %f, c2 = %f, c3 = %f", x, c0, c1, c2, c3)
+		}
+	}
+
+	c0, c1, c2, c3 := getStumpffFunctions(0)
+
+	if c0 != 1 || c1 != 1 || c2 != 0.5 || c3 != 1.0/6 {
+		t.Errorf("got c0 = %f, c1 = %f, c2 = %f, c3 = %f, wanted 1, 1, 1/2, 1/6", c0, c1, c2, c3)
+	}
+}
+
+/*****************************************************************************************************************/
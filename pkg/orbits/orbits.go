@@ -0,0 +1,366 @@
+/*****************************************************************************************************************/
+
+//	@author		Michael Roberts <michael@observerly.com>
+//	@package	@observerly/sidera
+//	@license	Copyright © 2021-2024 observerly
+
+/*****************************************************************************************************************/
+
+package orbits
+
+/*****************************************************************************************************************/
+
+import (
+	"math"
+	"time"
+
+	"github.com/observerly/sidera/pkg/common"
+	"github.com/observerly/sidera/pkg/epoch"
+	moon "github.com/observerly/sidera/pkg/lunar"
+	"github.com/observerly/sidera/pkg/planets"
+)
+
+/*****************************************************************************************************************/
+
+// the Gaussian gravitational constant, k, in AU^(3/2) per day:
+const GAUSSIAN_GRAVITATIONAL_CONSTANT float64 = 0.01720209895
+
+/*****************************************************************************************************************/
+
+// the obliquity of the ecliptic at J2000.0, in degrees:
+const J2000_OBLIQUITY float64 = 23.4392911
+
+/*****************************************************************************************************************/
+
+// the ratio of the mass of the Earth to the mass of the Moon:
+const EARTH_MOON_MASS_RATIO float64 = 81.30056
+
+/*****************************************************************************************************************/
+
+/*
+the osculating heliocentric orbital elements of an asteroid or comet, referred to the mean ecliptic and
+equinox of J2000.0
+
+The orbit is described by its perihelion distance rather than its semi-major axis, so that parabolic (e = 1)
+and hyperbolic (e > 1) orbits may be described alongside elliptical (e < 1) orbits. The epoch of osculation
+and the time of perihelion passage are Julian Dates in Terrestrial Time (TT). All angles are in degrees.
+*/
+type OrbitalElements struct {
+	Epoch                    float64
+	PerihelionDistance       float64
+	Eccentricity             float64
+	Inclination              float64
+	LongitudeOfAscendingNode float64
+	ArgumentOfPerihelion     float64
+	TimeOfPerihelion         float64
+}
+
+/*****************************************************************************************************************/
+
+/*
+the osculating orbital elements of an elliptical orbit, given its semi-major axis (in AU) and its mean anomaly
+(in degrees) at the epoch of osculation, as is customary for the orbits of asteroids
+
+The time of perihelion passage is found from the mean anomaly and the mean daily motion, n = k / a^(3/2).
+*/
+func NewOrbitalElements(
+	epoch float64,
+	semiMajorAxis float64,
+	eccentricity float64,
+	inclination float64,
+	longitudeOfAscendingNode float64,
+	argumentOfPerihelion float64,
+	meanAnomaly float64,
+) OrbitalElements {
+	// the mean daily motion, in radians per day:
+	n := GAUSSIAN_GRAVITATIONAL_CONSTANT / math.Pow(semiMajorAxis, 1.5)
+
+	return OrbitalElements{
+		Epoch:                    epoch,
+		PerihelionDistance:       semiMajorAxis * (1 - eccentricity),
+		Eccentricity:             eccentricity,
+		Inclination:              inclination,
+		LongitudeOfAscendingNode: longitudeOfAscendingNode,
+		ArgumentOfPerihelion:     argumentOfPerihelion,
+		TimeOfPerihelion:         epoch - common.Radians(math.Remainder(meanAnomaly, 360))/n,
+	}
+}
+
+/*****************************************************************************************************************/
+
+/*
+solves the universal form of Kepler's equation for the universal anomaly s, for a given time since perihelion
+passage Δt (in days), perihelion distance q (in AU), eccentricity e and gravitational parameter μ = k²
+
+The starting value is found from the classical solution for the eccentric anomaly, hyperbolic anomaly or
+Barker's equation, and is then refined by Newton-Raphson iteration on the universal equation, which remains
+well conditioned for near-parabolic orbits where the classical forms lose precision.
+*/
+func solveUniversalKeplerEquation(Δt float64, q float64, e float64, μ float64) float64 {
+	// the reciprocal of the semi-major axis, scaled by μ (positive for elliptical orbits):
+	α := μ * (1 - e) / q
+
+	var s float64
+
+	switch {
+	case e < 1:
+		// the semi-major axis, and the mean motion (in radians per day):
+		a := q / (1 - e)
+
+		n := math.Sqrt(μ / math.Pow(a, 3))
+
+		s = SolveKeplerEquation(n*Δt, e) / math.Sqrt(α)
+	case e > 1:
+		// the semi-major axis (which is negative for a hyperbolic orbit), and the mean motion:
+		a := q / (1 - e)
+
+		n := math.Sqrt(μ / math.Pow(-a, 3))
+
+		s = SolveHyperbolicKeplerEquation(n*Δt, e) / math.Sqrt(-α)
+	default:
+		W := 3 * math.Sqrt(μ/(2*math.Pow(q, 3))) * Δt
+
+		s = math.Sqrt(2*q/μ) * SolveBarkerEquation(W)
+	}
+
+	for i := 0; i < 50; i++ {
+		_, c1, c2, c3 := getStumpffFunctions(α * math.Pow(s, 2))
+
+		// the universal form of Kepler's equation, and its derivative which is the heliocentric distance:
+		f := q*s*c1 + μ*math.Pow(s, 3)*c3 - Δt
+
+		r := q + μ*math.Pow(s, 2)*c2*e
+
+		Δs := f / r
+
+		s -= Δs
+
+		if math.Abs(Δs) < 1e-15*math.Max(1, math.Abs(s)) {
+			break
+		}
+	}
+
+	return s
+}
+
+/*****************************************************************************************************************/
+
+/*
+the heliocentric rectangular position (in AU) and velocity (in AU per day) of a body for a given Julian Date
+(in TT), referred to the mean ecliptic and equinox of J2000.0
+
+The motion is found by the method of universal variables, which treats elliptical, parabolic and hyperbolic
+orbits alike: the position and velocity at perihelion are propagated to the given time by the Lagrange f and
+g coefficients, and are then rotated into the ecliptic by the argument of perihelion, the inclination and the
+longitude of the ascending node.
+*/
+func getHeliocentricStateVector(
+	JD float64,
+	elements OrbitalElements,
+) (common.CartesianCoordinate, common.CartesianCoordinate) {
+	μ := math.Pow(GAUSSIAN_GRAVITATIONAL_CONSTANT, 2)
+
+	q := elements.PerihelionDistance
+
+	e := elements.Eccentricity
+
+	// the time since perihelion passage, in days:
+	Δt := JD - elements.TimeOfPerihelion
+
+	// the speed at perihelion, in AU per day:
+	v0 := math.Sqrt(μ * (1 + e) / q)
+
+	α := μ * (1 - e) / q
+
+	s := solveUniversalKeplerEquation(Δt, q, e, μ)
+
+	_, c1, c2, c3 := getStumpffFunctions(α * math.Pow(s, 2))
+
+	// the heliocentric distance:
+	r := q + μ*math.Pow(s, 2)*c2*e
+
+	// the Lagrange coefficients, and their time derivatives:
+	f := 1 - μ*math.Pow(s, 2)*c2/q
+	g := Δt - μ*math.Pow(s, 3)*c3
+	ḟ := -μ * s * c1 / (r * q)
+	ġ := 1 - μ*math.Pow(s, 2)*c2/r
+
+	// the position and velocity in the plane of the orbit, with the x-axis directed towards perihelion:
+	x, y := f*q, g*v0
+	ẋ, ẏ := ḟ*q, ġ*v0
+
+	ω := common.Radians(elements.ArgumentOfPerihelion)
+	i := common.Radians(elements.Inclination)
+	Ω := common.Radians(elements.LongitudeOfAscendingNode)
+
+	// the rotation from the plane of the orbit to the mean ecliptic and equinox of J2000.0:
+	Px := math.Cos(ω)*math.Cos(Ω) - math.Sin(ω)*math.Sin(Ω)*math.Cos(i)
+	Py := math.Cos(ω)*math.Sin(Ω) + math.Sin(ω)*math.Cos(Ω)*math.Cos(i)
+	Pz := math.Sin(ω) * math.Sin(i)
+	Qx := -math.Sin(ω)*math.Cos(Ω) - math.Cos(ω)*math.Sin(Ω)*math.Cos(i)
+	Qy := -math.Sin(ω)*math.Sin(Ω) + math.Cos(ω)*math.Cos(Ω)*math.Cos(i)
+	Qz := math.Cos(ω) * math.Sin(i)
+
+	return common.CartesianCoordinate{
+		X: Px*x + Qx*y,
+		Y: Py*x + Qy*y,
+		Z: Pz*x + Qz*y,
+	}, common.CartesianCoordinate{
+		X: Px*ẋ + Qx*ẏ,
+		Y: Py*ẋ + Qy*ẏ,
+		Z: Pz*ẋ + Qz*ẏ,
+	}
+}
+
+/*****************************************************************************************************************/
+
+/*
+the heliocentric rectangular position (in AU) and velocity (in AU per day) of an asteroid or comet for a given
+datetime, referred to the mean ecliptic and equinox of J2000.0
+
+The orbit is assumed to be a two-body (Keplerian) orbit about the Sun, so that the perturbations by the
+planets are neglected: the accuracy therefore degrades with the time from the epoch of osculation.
+*/
+func GetHeliocentricStateVector(
+	datetime time.Time,
+	elements OrbitalElements,
+) (common.CartesianCoordinate, common.CartesianCoordinate) {
+	return getHeliocentricStateVector(epoch.GetTerrestrialTimeJulianDate(datetime), elements)
+}
+
+/*****************************************************************************************************************/
+
+/*
+the heliocentric ecliptic coordinate of an asteroid or comet for a given datetime, and its distance from the
+Sun in AU, referred to the mean ecliptic and equinox of J2000.0
+*/
+func GetHeliocentricEclipticCoordinate(
+	datetime time.Time,
+	elements OrbitalElements,
+) (common.EclipticCoordinate, float64) {
+	position, _ := GetHeliocentricStateVector(datetime, elements)
+
+	r := math.Sqrt(math.Pow(position.X, 2) + math.Pow(position.Y, 2) + math.Pow(position.Z, 2))
+
+	λ := common.Degrees(math.Atan2(position.Y, position.X))
+
+	if λ < 0 {
+		λ += 360
+	}
+
+	return common.EclipticCoordinate{
+		Longitude: λ,
+		Latitude:  common.Degrees(math.Asin(position.Z / r)),
+	}, r
+}
+
+/*****************************************************************************************************************/
+
+/*
+the heliocentric rectangular position of the centre of the Earth for a given datetime, in AU, referred to the
+mean ecliptic and equinox of J2000.0
+
+The position of the Earth-Moon barycentre is corrected by the geocentric position of the Moon, scaled by the
+ratio of the masses of the Moon and the Earth-Moon system, which displaces the Earth by up to around 4,700 km.
+The small precession of the Moon's position from the equinox of date is neglected here.
+*/
+func getEarthHeliocentricPosition(datetime time.Time) common.CartesianCoordinate {
+	emb := planets.GetHeliocentricCartesianCoordinate(datetime, planets.Earth)
+
+	ec := moon.GetEclipticCoordinate(datetime)
+
+	// the distance of the Earth from the Earth-Moon barycentre, in AU:
+	d := moon.GetDistance(datetime) / common.AU / (1 + EARTH_MOON_MASS_RATIO)
+
+	λ := common.Radians(ec.Longitude)
+
+	β := common.Radians(ec.Latitude)
+
+	return common.CartesianCoordinate{
+		X: emb.X - d*math.Cos(β)*math.Cos(λ),
+		Y: emb.Y - d*math.Cos(β)*math.Sin(λ),
+		Z: emb.Z - d*math.Sin(β),
+	}
+}
+
+/*****************************************************************************************************************/
+
+/*
+the geocentric rectangular position of an asteroid or comet for a given datetime, in AU, referred to the mean
+ecliptic and equinox of J2000.0, corrected for light travel time
+
+The position of the body is computed at the time at which the light arriving at the Earth left the body,
+found by iterating on the light travel time over the geocentric distance of the body.
+*/
+func GetGeocentricCartesianCoordinate(datetime time.Time, elements OrbitalElements) common.CartesianCoordinate {
+	JD := epoch.GetTerrestrialTimeJulianDate(datetime)
+
+	// the heliocentric position of the Earth at the time of observation:
+	earth := getEarthHeliocentricPosition(datetime)
+
+	// the light travel time from the body to the Earth, in days:
+	τ := 0.0
+
+	position := common.CartesianCoordinate{}
+
+	for i := 0; i < 10; i++ {
+		p, _ := getHeliocentricStateVector(JD-τ, elements)
+
+		position = common.CartesianCoordinate{
+			X: p.X - earth.X,
+			Y: p.Y - earth.Y,
+			Z: p.Z - earth.Z,
+		}
+
+		Δ := math.Sqrt(math.Pow(position.X, 2) + math.Pow(position.Y, 2) + math.Pow(position.Z, 2))
+
+		if math.Abs(Δ*planets.LIGHT_TIME_PER_AU-τ) < 1e-9 {
+			break
+		}
+
+		τ = Δ * planets.LIGHT_TIME_PER_AU
+	}
+
+	return position
+}
+
+/*****************************************************************************************************************/
+
+/*
+the geocentric equatorial coordinate of an asteroid or comet for a given datetime, and its distance from the
+Earth in AU
+
+The equatorial coordinate is the astrometric position of the body as seen from the centre of the Earth,
+corrected for light travel time, and referred to the mean equator and equinox of J2000.0, i.e., in the frame of
+star catalogue positions. However, the position of the Earth is from the mean orbital elements of the Earth-Moon
+barycentre, which are accurate to around 15 arcseconds as seen from the Sun, so that the position of the body
+is accurate only to around an arcminute, and worse for a near-Earth object, as the error grows inversely with
+its distance from the Earth. It is suitable for finding and identifying a body, but not for astrometry to the
+precision of a star catalogue. For close approaches of near-Earth objects, the diurnal parallax of the observer
+may amount to many arcseconds, and is not included here.
+*/
+func GetEquatorialCoordinate(datetime time.Time, elements OrbitalElements) (common.EquatorialCoordinate, float64) {
+	position := GetGeocentricCartesianCoordinate(datetime, elements)
+
+	ε := common.Radians(J2000_OBLIQUITY)
+
+	// rotate the position from the ecliptic to the equator about the x-axis by the obliquity of the ecliptic:
+	x := position.X
+	y := position.Y*math.Cos(ε) - position.Z*math.Sin(ε)
+	z := position.Y*math.Sin(ε) + position.Z*math.Cos(ε)
+
+	Δ := math.Sqrt(math.Pow(x, 2) + math.Pow(y, 2) + math.Pow(z, 2))
+
+	α := common.Degrees(math.Atan2(y, x))
+
+	if α < 0 {
+		α += 360
+	}
+
+	return common.EquatorialCoordinate{
+		RightAscension: α,
+		Declination:    common.Degrees(math.Asin(z / Δ)),
+	}, Δ
+}
+
+/*****************************************************************************************************************/
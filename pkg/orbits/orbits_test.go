@@ -0,0 +1,165 @@
+/*****************************************************************************************************************/
+
+//	@author		Michael Roberts <michael@observerly.com>
+//	@package	@observerly/sidera
+//	@license	Copyright © 2021-2024 observerly
+
+/*****************************************************************************************************************/
+
+package orbits
+
+/*****************************************************************************************************************/
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/observerly/sidera/pkg/epoch"
+	"github.com/observerly/sidera/pkg/planets"
+)
+
+/*****************************************************************************************************************/
+
+// We define a datetime of 6 October 1990 (TD) for comparison against Meeus, "Astronomical Algorithms", Example 33.b:
+var datetime time.Time = time.Date(1990, 10, 6, 0, 0, 0, 0, time.UTC).Add(-57 * time.Second)
+
+/*****************************************************************************************************************/
+
+// the orbital elements of periodic comet 2P/Encke, referred to the mean ecliptic and equinox of J2000.0:
+var encke = NewOrbitalElements(2448193.04502, 2.2091404, 0.8502196, 11.94524, 334.75006, 186.23352, 0)
+
+/*****************************************************************************************************************/
+
+func getMagnitude(x, y, z float64) float64 {
+	return math.Sqrt(math.Pow(x, 2) + math.Pow(y, 2) + math.Pow(z, 2))
+}
+
+/*****************************************************************************************************************/
+
+func TestNewOrbitalElements(t *testing.T) {
+	if math.Abs(encke.PerihelionDistance-0.330886) > 1e-6 {
+		t.Errorf("got %f, wanted %f", encke.PerihelionDistance, 0.330886)
+	}
+
+	if encke.TimeOfPerihelion != 2448193.04502 {
+		t.Errorf("got %f, wanted %f", encke.TimeOfPerihelion, 2448193.04502)
+	}
+
+	// a quarter of the way around the orbit in mean anomaly is a quarter of the period after perihelion:
+	el := NewOrbitalElements(2451545.0, 1, 0.5, 0, 0, 0, 90)
+
+	if math.Abs(el.TimeOfPerihelion-(2451545.0-365.2568983/4)) > 1e-4 {
+		t.Errorf("got %f, wanted %f", el.TimeOfPerihelion, 2451545.0-365.2568983/4)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetHeliocentricStateVectorAtPerihelion(t *testing.T) {
+	for _, e := range []float64{0.5, 1, 3} {
+		el := OrbitalElements{PerihelionDistance: 0.8, Eccentricity: e, TimeOfPerihelion: 2451545.0}
+
+		p, v := getHeliocentricStateVector(2451545.0, el)
+
+		if math.Abs(p.X-0.8) > 1e-12 || math.Abs(p.Y) > 1e-12 || math.Abs(v.X) > 1e-12 {
+			t.Errorf("e = %f: got %+v, %+v, wanted the body to be at perihelion", e, p, v)
+		}
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetHeliocentricStateVectorEnergy(t *testing.T) {
+	μ := math.Pow(GAUSSIAN_GRAVITATIONAL_CONSTANT, 2)
+
+	for _, e := range []float64{0, 0.2, 0.9, 0.99999, 1, 1.00001, 1.5, 4} {
+		el := OrbitalElements{
+			PerihelionDistance:       1.2,
+			Eccentricity:             e,
+			Inclination:              30,
+			LongitudeOfAscendingNode: 80,
+			ArgumentOfPerihelion:     45,
+			TimeOfPerihelion:         2451545.0,
+		}
+
+		for _, Δt := range []float64{-5000, -100, -1, 0.5, 30, 1000, 20000} {
+			p, v := getHeliocentricStateVector(2451545.0+Δt, el)
+
+			r := getMagnitude(p.X, p.Y, p.Z)
+
+			// the vis-viva equation, v² = μ (2 / r - 1 / a), where 1 / a = (1 - e) / q:
+			want := μ * (2/r - (1-e)/el.PerihelionDistance)
+
+			if got := math.Pow(getMagnitude(v.X, v.Y, v.Z), 2); math.Abs(got-want) > 1e-12 {
+				t.Errorf("e = %f, Δt = %f: got v² = %e, wanted %e", e, Δt, got, want)
+			}
+
+			// the specific angular momentum, h² = μ q (1 + e):
+			hx := p.Y*v.Z - p.Z*v.Y
+			hy := p.Z*v.X - p.X*v.Z
+			hz := p.X*v.Y - p.Y*v.X
+
+			if got := math.Pow(getMagnitude(hx, hy, hz), 2); math.Abs(got-μ*1.2*(1+e)) > 1e-12 {
+				t.Errorf("e = %f, Δt = %f: got h² = %e, wanted %e", e, Δt, got, μ*1.2*(1+e))
+			}
+		}
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetHeliocentricStateVectorMars(t *testing.T) {
+	// the mean orbital elements of Mars at J2000.0, from the planets package:
+	el := planets.Mars.Elements
+
+	mars := NewOrbitalElements(
+		epoch.J2000,
+		el.SemiMajorAxis,
+		el.Eccentricity,
+		el.Inclination,
+		el.LongitudeOfAscendingNode,
+		el.LongitudeOfPerihelion-el.LongitudeOfAscendingNode,
+		el.MeanLongitude-el.LongitudeOfPerihelion,
+	)
+
+	p, _ := getHeliocentricStateVector(epoch.J2000, mars)
+
+	want := planets.GetHeliocentricCartesianCoordinate(time.Date(2000, 1, 1, 12, 0, 0, 0, time.UTC), planets.Mars)
+
+	if d := getMagnitude(p.X-want.X, p.Y-want.Y, p.Z-want.Z); d > 1e-6 {
+		t.Errorf("got %+v, wanted %+v", p, want)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetHeliocentricEclipticCoordinate(t *testing.T) {
+	_, r := GetHeliocentricEclipticCoordinate(datetime, encke)
+
+	// the heliocentric distance of the comet 22.5 days before perihelion:
+	if math.Abs(r-0.652474) > 0.0001 {
+		t.Errorf("got %f, wanted %f", r, 0.652474)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetEquatorialCoordinate(t *testing.T) {
+	eq, Δ := GetEquatorialCoordinate(datetime, encke)
+
+	// Meeus, "Astronomical Algorithms", Example 33.b, α = 10h34m13.7s, δ = +19°09'32":
+	if math.Abs(eq.RightAscension-158.55708) > 0.01 {
+		t.Errorf("got %f, wanted %f", eq.RightAscension, 158.55708)
+	}
+
+	if math.Abs(eq.Declination-19.15889) > 0.01 {
+		t.Errorf("got %f, wanted %f", eq.Declination, 19.15889)
+	}
+
+	if math.Abs(Δ-0.82420) > 0.0005 {
+		t.Errorf("got %f, wanted %f", Δ, 0.82420)
+	}
+}
+
+/*****************************************************************************************************************/
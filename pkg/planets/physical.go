@@ -0,0 +1,200 @@
+/*****************************************************************************************************************/
+
+//	@author		Michael Roberts <michael@observerly.com>
+//	@package	@observerly/sidera
+//	@license	Copyright © 2021-2024 observerly
+
+/*****************************************************************************************************************/
+
+package planets
+
+/*****************************************************************************************************************/
+
+import (
+	"math"
+	"time"
+
+	"github.com/observerly/sidera/pkg/common"
+	"github.com/observerly/sidera/pkg/epoch"
+)
+
+/*****************************************************************************************************************/
+
+/*
+the distances of a planet from the Sun (r) and the Earth (Δ), and of the Earth from the Sun (R), in AU
+
+The distance of the planet from the Sun is taken at the time at which the light arriving at the Earth left
+the planet, i.e., consistently with the geocentric position corrected for light travel time.
+*/
+func getDistances(datetime time.Time, planet Planet) (r float64, Δ float64, R float64) {
+	// get the Julian Date for the given datetime:
+	JD := epoch.GetJulianDate(datetime)
+
+	earth := getHeliocentricPosition(JD, Earth)
+
+	g := GetGeocentricCartesianCoordinate(datetime, planet)
+
+	Δ = math.Sqrt(math.Pow(g.X, 2) + math.Pow(g.Y, 2) + math.Pow(g.Z, 2))
+
+	R = math.Sqrt(math.Pow(earth.X, 2) + math.Pow(earth.Y, 2) + math.Pow(earth.Z, 2))
+
+	// the heliocentric position of the planet at the time the light left the planet:
+	r = math.Sqrt(math.Pow(g.X+earth.X, 2) + math.Pow(g.Y+earth.Y, 2) + math.Pow(g.Z+earth.Z, 2))
+
+	return r, Δ, R
+}
+
+/*****************************************************************************************************************/
+
+/*
+the phase angle of a planet for a given datetime, in degrees
+
+The phase angle is the angle between the Sun and the Earth as seen from the planet. It is zero when the
+planet is fully illuminated as seen from the Earth (e.g., at superior conjunction or opposition), and 180
+degrees when the unilluminated hemisphere faces the Earth (e.g., at inferior conjunction).
+*/
+func GetPhaseAngle(datetime time.Time, planet Planet) float64 {
+	r, Δ, R := getDistances(datetime, planet)
+
+	// the cosine of the phase angle, from the triangle Sun-Earth-planet:
+	cosi := (math.Pow(r, 2) + math.Pow(Δ, 2) - math.Pow(R, 2)) / (2 * r * Δ)
+
+	return common.Degrees(math.Acos(math.Max(-1, math.Min(1, cosi))))
+}
+
+/*****************************************************************************************************************/
+
+/*
+the illuminated fraction of the disk of a planet for a given datetime
+
+The illuminated fraction is the ratio of the illuminated area of the disk to the total area of the disk,
+as seen from the Earth, and ranges from 0 (new) to 1 (full).
+*/
+func GetIlluminatedFraction(datetime time.Time, planet Planet) float64 {
+	i := common.Radians(GetPhaseAngle(datetime, planet))
+
+	return (1 + math.Cos(i)) / 2
+}
+
+/*****************************************************************************************************************/
+
+/*
+the apparent equatorial angular diameter of a planet for a given datetime, in arcseconds
+*/
+func GetAngularDiameter(datetime time.Time, planet Planet) float64 {
+	_, Δ, _ := getDistances(datetime, planet)
+
+	return common.Degrees(2*math.Atan(planet.EquatorialRadius/(Δ*common.AU))) * 3600
+}
+
+/*****************************************************************************************************************/
+
+/*
+the elongation of a planet from the Sun for a given datetime, in degrees
+
+The elongation is the angle between the Sun and the planet as seen from the Earth. The inferior planets,
+Mercury and Venus, are never seen at elongations greater than about 28 and 47 degrees respectively.
+*/
+func GetElongation(datetime time.Time, planet Planet) float64 {
+	r, Δ, R := getDistances(datetime, planet)
+
+	// the cosine of the elongation, from the triangle Sun-Earth-planet:
+	cosψ := (math.Pow(R, 2) + math.Pow(Δ, 2) - math.Pow(r, 2)) / (2 * R * Δ)
+
+	return common.Degrees(math.Acos(math.Max(-1, math.Min(1, cosψ))))
+}
+
+/*****************************************************************************************************************/
+
+/*
+the tilt of the rings of Saturn for a given datetime, in degrees
+
+The ring tilt is the Saturnicentric latitude of the Earth referred to the plane of the rings, and is
+positive when the northern face of the rings is visible from the Earth. It varies between approximately
+-27 and +27 degrees over Saturn's 29.5 year orbital period, passing through zero at ring plane crossings.
+
+See Meeus, "Astronomical Algorithms", Chapter 45.
+*/
+func GetSaturnRingTilt(datetime time.Time) float64 {
+	// the number of centuries since J2000.0:
+	T := (epoch.GetJulianDate(datetime) - epoch.J2000) / 36525
+
+	// the inclination of the plane of the rings to the ecliptic of date:
+	i := common.Radians(28.075216 - 0.012998*T + 0.000004*math.Pow(T, 2))
+
+	// the longitude of the ascending node of the plane of the rings on the ecliptic of date:
+	Ω := common.Radians(169.508470 + 1.394681*T + 0.000412*math.Pow(T, 2))
+
+	// the geocentric ecliptic coordinate of Saturn, referred to the ecliptic of date:
+	ec, _ := GetGeocentricEclipticCoordinate(datetime, Saturn)
+
+	λ := common.Radians(ec.Longitude)
+
+	β := common.Radians(ec.Latitude)
+
+	B := math.Asin(math.Sin(i)*math.Cos(β)*math.Sin(λ-Ω) - math.Cos(i)*math.Sin(β))
+
+	return common.Degrees(B)
+}
+
+/*****************************************************************************************************************/
+
+/*
+the apparent visual (V band) magnitude of a planet for a given datetime
+
+The apparent magnitude is computed from the phase curves of A. Mallama and J.L. Hilton, "Computing apparent
+planetary magnitudes for The Astronomical Almanac" (2018), Astronomy and Computing, 25, 10-24. The magnitude
+of Saturn includes the contribution of the rings, which depends upon the ring tilt. The phase curves are
+extrapolated beyond the range of phase angles over which they were fitted, e.g., for Venus near inferior
+conjunction, and the magnitude of the Earth (as seen from the Earth) is undefined and returned as NaN.
+*/
+func GetApparentMagnitude(datetime time.Time, planet Planet) float64 {
+	r, Δ, _ := getDistances(datetime, planet)
+
+	// the phase angle, in degrees:
+	α := GetPhaseAngle(datetime, planet)
+
+	// the distance modulus, correcting the magnitude to the planet's distances from the Sun and Earth:
+	d := 5 * math.Log10(r*Δ)
+
+	switch planet.Name {
+	case Mercury.Name:
+		return d - 0.613 + 6.3280e-02*α - 1.6336e-03*math.Pow(α, 2) + 3.3644e-05*math.Pow(α, 3) -
+			3.4265e-07*math.Pow(α, 4) + 1.6893e-09*math.Pow(α, 5) - 3.0334e-12*math.Pow(α, 6)
+	case Venus.Name:
+		if α < 163.7 {
+			return d - 4.384 - 1.044e-03*α + 3.687e-04*math.Pow(α, 2) - 2.814e-06*math.Pow(α, 3) +
+				8.938e-09*math.Pow(α, 4)
+		}
+
+		return d + 236.05828 - 2.81914*α + 8.39034e-03*math.Pow(α, 2)
+	case Mars.Name:
+		if α <= 50 {
+			return d - 1.601 + 2.267e-02*α - 1.302e-04*math.Pow(α, 2)
+		}
+
+		return d - 0.367 - 2.573e-02*α + 3.445e-04*math.Pow(α, 2)
+	case Jupiter.Name:
+		if α <= 12 {
+			return d - 9.395 - 3.7e-04*α + 6.16e-04*math.Pow(α, 2)
+		}
+
+		x := α / 180
+
+		return d - 9.428 - 2.5*math.Log10(1.0-1.507*x-0.363*math.Pow(x, 2)-0.062*math.Pow(x, 3)+
+			2.809*math.Pow(x, 4)-1.876*math.Pow(x, 5))
+	case Saturn.Name:
+		// the sine of the (absolute) tilt of the rings as seen from the Earth:
+		sinB := math.Sin(common.Radians(math.Abs(GetSaturnRingTilt(datetime))))
+
+		return d - 8.914 - 1.825*sinB + 0.026*α - 0.378*sinB*math.Exp(-2.25*α)
+	case Uranus.Name:
+		return d - 7.110 + 6.587e-03*α + 1.045e-04*math.Pow(α, 2)
+	case Neptune.Name:
+		return d - 7.00 + 7.944e-03*α + 9.617e-05*math.Pow(α, 2)
+	}
+
+	return math.NaN()
+}
+
+/*****************************************************************************************************************/
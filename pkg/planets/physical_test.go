@@ -0,0 +1,117 @@
+/*****************************************************************************************************************/
+
+//	@author		Michael Roberts <michael@observerly.com>
+//	@package	@observerly/sidera
+//	@license	Copyright © 2021-2024 observerly
+
+/*****************************************************************************************************************/
+
+package planets
+
+/*****************************************************************************************************************/
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+/*****************************************************************************************************************/
+
+func TestGetPhaseAngle(t *testing.T) {
+	var got float64 = GetPhaseAngle(datetime, Venus)
+
+	// the phase angle of Venus on 20 December 1992, see Meeus, "Astronomical Algorithms", Example 41.a:
+	var want float64 = 72.96
+
+	if math.Abs(got-want) > 0.1 {
+		t.Errorf("got %f, wanted %f", got, want)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetIlluminatedFraction(t *testing.T) {
+	var got float64 = GetIlluminatedFraction(datetime, Venus)
+
+	var want float64 = 0.647
+
+	if math.Abs(got-want) > 0.001 {
+		t.Errorf("got %f, wanted %f", got, want)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetAngularDiameter(t *testing.T) {
+	var got float64 = GetAngularDiameter(datetime, Venus)
+
+	// the diameter of Venus (12,103.6 km) at a distance of 0.910947 AU:
+	var want float64 = 18.32
+
+	if math.Abs(got-want) > 0.05 {
+		t.Errorf("got %f, wanted %f", got, want)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetElongation(t *testing.T) {
+	var got float64 = GetElongation(datetime, Venus)
+
+	// Venus was approaching greatest eastern elongation (47.1 degrees) in January 1993:
+	if got < 44 || got > 47.1 {
+		t.Errorf("got %f, wanted an elongation between 44 and 47.1 degrees", got)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetSaturnRingTilt(t *testing.T) {
+	var got float64 = GetSaturnRingTilt(time.Date(1992, 12, 16, 0, 0, 0, 0, time.UTC))
+
+	// the ring tilt of Saturn on 16 December 1992, see Meeus, "Astronomical Algorithms", Example 45.a:
+	var want float64 = 16.442
+
+	if math.Abs(got-want) > 0.05 {
+		t.Errorf("got %f, wanted %f", got, want)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetApparentMagnitude(t *testing.T) {
+	var datetime time.Time = time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	// the approximate apparent magnitudes of the planets on 1 June 2024:
+	tests := []struct {
+		planet Planet
+		want   float64
+	}{
+		{Mercury, -0.9},
+		{Venus, -3.9},
+		{Mars, 1.1},
+		{Jupiter, -2.0},
+		{Saturn, 1.1},
+		{Uranus, 5.9},
+		{Neptune, 7.8},
+	}
+
+	for _, test := range tests {
+		got := GetApparentMagnitude(datetime, test.planet)
+
+		if math.Abs(got-test.want) > 0.2 {
+			t.Errorf("got %f for %s, wanted %f", got, test.planet.Name, test.want)
+		}
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetApparentMagnitudeOfTheEarth(t *testing.T) {
+	if got := GetApparentMagnitude(datetime, Earth); !math.IsNaN(got) {
+		t.Errorf("got %f, wanted NaN", got)
+	}
+}
+
+/*****************************************************************************************************************/
@@ -0,0 +1,371 @@
+/*****************************************************************************************************************/
+
+//	@author		Michael Roberts <michael@observerly.com>
+//	@package	@observerly/sidera
+//	@license	Copyright © 2021-2024 observerly
+
+/*****************************************************************************************************************/
+
+/*
+Package planets computes the positions of the major planets from their mean Keplerian orbital elements.
+
+Only this low-precision mode is provided: there is no higher-precision (VSOP87) series. The elements of Standish
+are valid from 1800 AD to 2050 AD, within which the heliocentric positions are accurate to between 15 arcseconds
+for the inner planets and 10 arcminutes for Saturn, so that geocentric positions are accurate to around an
+arcminute at best. The Earth is represented by the Earth-Moon barycentre, which is up to 4,700 kilometres from the
+centre of the Earth, i.e., up to around 25 arcseconds in the direction of Venus at its closest. Where arcsecond
+accuracy is needed, the positions should instead be read from a JPL ephemeris with the jpl package.
+*/
+package planets
+
+/*****************************************************************************************************************/
+
+import (
+	"math"
+	"time"
+
+	"github.com/observerly/sidera/pkg/common"
+	"github.com/observerly/sidera/pkg/coordinates"
+	"github.com/observerly/sidera/pkg/epoch"
+)
+
+/*****************************************************************************************************************/
+
+// the light travel time for one astronomical unit (AU), in days:
+const LIGHT_TIME_PER_AU float64 = 0.0057755183
+
+/*****************************************************************************************************************/
+
+/*
+the mean Keplerian orbital elements of a planet, referred to the mean ecliptic and equinox of J2000.0
+
+The elements are the semi-major axis a (AU), the eccentricity e, the inclination I (degrees), the mean
+longitude L (degrees), the longitude of perihelion ϖ (degrees) and the longitude of the ascending node
+Ω (degrees) at J2000.0, together with their rates of change per Julian century.
+*/
+type Elements struct {
+	SemiMajorAxis                float64
+	Eccentricity                 float64
+	Inclination                  float64
+	MeanLongitude                float64
+	LongitudeOfPerihelion        float64
+	LongitudeOfAscendingNode     float64
+	SemiMajorAxisRate            float64
+	EccentricityRate             float64
+	InclinationRate              float64
+	MeanLongitudeRate            float64
+	LongitudeOfPerihelionRate    float64
+	LongitudeOfAscendingNodeRate float64
+}
+
+/*****************************************************************************************************************/
+
+/*
+a major planet of the solar system, together with its mean orbital elements
+
+The orbital elements are taken from E.M. Standish, "Keplerian Elements for Approximate Positions of the
+Major Planets" (JPL), Table 1, which are fitted to the JPL DE405 ephemeris over the interval 1800 AD to
+2050 AD. Within this interval, the heliocentric positions are accurate to between 15 arcseconds (for the
+inner planets) and 10 arcminutes (for Saturn). The equatorial radius of the planet is given in kilometres.
+*/
+type Planet struct {
+	Name             string
+	Elements         Elements
+	EquatorialRadius float64
+}
+
+/*****************************************************************************************************************/
+
+var Mercury = Planet{
+	Name: "Mercury",
+	Elements: Elements{
+		0.38709927, 0.20563593, 7.00497902, 252.25032350, 77.45779628, 48.33076593,
+		0.00000037, 0.00001906, -0.00594749, 149472.67411175, 0.16047689, -0.12534081,
+	},
+	EquatorialRadius: 2440.53,
+}
+
+/*****************************************************************************************************************/
+
+var Venus = Planet{
+	Name: "Venus",
+	Elements: Elements{
+		0.72333566, 0.00677672, 3.39467605, 181.97909950, 131.60246718, 76.67984255,
+		0.00000390, -0.00004107, -0.00078890, 58517.81538729, 0.00268329, -0.27769418,
+	},
+	EquatorialRadius: 6051.8,
+}
+
+/*****************************************************************************************************************/
+
+/*
+the Earth-Moon barycentre, which orbits the Sun and about which the Earth and Moon revolve
+
+The Earth itself lies within around 4,700 kilometres (0.00003 AU) of the Earth-Moon barycentre, which is
+well within the accuracy of the mean orbital elements.
+*/
+var Earth = Planet{
+	Name: "Earth",
+	Elements: Elements{
+		1.00000261, 0.01671123, -0.00001531, 100.46457166, 102.93768193, 0.0,
+		0.00000562, -0.00004392, -0.01294668, 35999.37244981, 0.32327364, 0.0,
+	},
+	EquatorialRadius: common.EARTH_EQUATORIAL_RADIUS,
+}
+
+/*****************************************************************************************************************/
+
+var Mars = Planet{
+	Name: "Mars",
+	Elements: Elements{
+		1.52371034, 0.09339410, 1.84969142, -4.55343205, -23.94362959, 49.55953891,
+		0.00001847, 0.00007882, -0.00813131, 19140.30268499, 0.44441088, -0.29257343,
+	},
+	EquatorialRadius: 3396.19,
+}
+
+/*****************************************************************************************************************/
+
+var Jupiter = Planet{
+	Name: "Jupiter",
+	Elements: Elements{
+		5.20288700, 0.04838624, 1.30439695, 34.39644051, 14.72847983, 100.47390909,
+		-0.00011607, -0.00013253, -0.00183714, 3034.74612775, 0.21252668, 0.20469106,
+	},
+	EquatorialRadius: 71492,
+}
+
+/*****************************************************************************************************************/
+
+var Saturn = Planet{
+	Name: "Saturn",
+	Elements: Elements{
+		9.53667594, 0.05386179, 2.48599187, 49.95424423, 92.59887831, 113.66242448,
+		-0.00125060, -0.00050991, 0.00193609, 1222.49362201, -0.41897216, -0.28867794,
+	},
+	EquatorialRadius: 60268,
+}
+
+/*****************************************************************************************************************/
+
+var Uranus = Planet{
+	Name: "Uranus",
+	Elements: Elements{
+		19.18916464, 0.04725744, 0.77263783, 313.23810451, 170.95427630, 74.01692503,
+		-0.00196176, -0.00004397, -0.00242939, 428.48202785, 0.40805281, 0.04240589,
+	},
+	EquatorialRadius: 25559,
+}
+
+/*****************************************************************************************************************/
+
+var Neptune = Planet{
+	Name: "Neptune",
+	Elements: Elements{
+		30.06992276, 0.00859048, 1.77004347, -55.12002969, 44.96476227, 131.78422574,
+		0.00026291, 0.00005105, 0.00035372, 218.45945325, -0.32241464, -0.00508664,
+	},
+	EquatorialRadius: 24764,
+}
+
+/*****************************************************************************************************************/
+
+/*
+the heliocentric position of a planet for a given Julian Date, in AU, referred to the mean ecliptic and
+equinox of J2000.0
+
+The mean orbital elements are evaluated at the given Julian Date, Kepler's equation is solved for the
+eccentric anomaly by Newton-Raphson iteration, and the position of the planet in the plane of its orbit
+is then rotated into the ecliptic by the argument of perihelion, the inclination and the longitude of
+the ascending node.
+*/
+func getHeliocentricPosition(JD float64, planet Planet) common.CartesianCoordinate {
+	// the number of centuries since J2000.0:
+	T := (JD - epoch.J2000) / 36525
+
+	el := planet.Elements
+
+	a := el.SemiMajorAxis + el.SemiMajorAxisRate*T
+	e := el.Eccentricity + el.EccentricityRate*T
+	I := common.Radians(el.Inclination + el.InclinationRate*T)
+	L := el.MeanLongitude + el.MeanLongitudeRate*T
+	ϖ := el.LongitudeOfPerihelion + el.LongitudeOfPerihelionRate*T
+	Ω := common.Radians(el.LongitudeOfAscendingNode + el.LongitudeOfAscendingNodeRate*T)
+
+	// the argument of perihelion:
+	ω := common.Radians(ϖ) - Ω
+
+	// the mean anomaly, in radians, reduced to the range -π to π:
+	M := common.Radians(math.Remainder(L-ϖ, 360))
+
+	// solve Kepler's equation, M = E - e sin E, for the eccentric anomaly:
+	E := M + e*math.Sin(M)
+
+	for i := 0; i < 10; i++ {
+		ΔE := (M - (E - e*math.Sin(E))) / (1 - e*math.Cos(E))
+
+		E += ΔE
+
+		if math.Abs(ΔE) < 1e-12 {
+			break
+		}
+	}
+
+	// the position of the planet in the plane of its orbit:
+	x := a * (math.Cos(E) - e)
+	y := a * math.Sqrt(1-math.Pow(e, 2)) * math.Sin(E)
+
+	// the position of the planet referred to the mean ecliptic and equinox of J2000.0:
+	return common.CartesianCoordinate{
+		X: (math.Cos(ω)*math.Cos(Ω)-math.Sin(ω)*math.Sin(Ω)*math.Cos(I))*x +
+			(-math.Sin(ω)*math.Cos(Ω)-math.Cos(ω)*math.Sin(Ω)*math.Cos(I))*y,
+		Y: (math.Cos(ω)*math.Sin(Ω)+math.Sin(ω)*math.Cos(Ω)*math.Cos(I))*x +
+			(-math.Sin(ω)*math.Sin(Ω)+math.Cos(ω)*math.Cos(Ω)*math.Cos(I))*y,
+		Z: math.Sin(ω)*math.Sin(I)*x + math.Cos(ω)*math.Sin(I)*y,
+	}
+}
+
+/*****************************************************************************************************************/
+
+/*
+converts a rectangular position to spherical ecliptic coordinates (in degrees) and a distance (in AU)
+*/
+func convertCartesianToEclipticCoordinate(
+	position common.CartesianCoordinate,
+) (common.EclipticCoordinate, float64) {
+	r := math.Sqrt(math.Pow(position.X, 2) + math.Pow(position.Y, 2) + math.Pow(position.Z, 2))
+
+	λ := common.Degrees(math.Atan2(position.Y, position.X))
+
+	if λ < 0 {
+		λ += 360
+	}
+
+	β := common.Degrees(math.Asin(position.Z / r))
+
+	return common.EclipticCoordinate{
+		Longitude: λ,
+		Latitude:  β,
+	}, r
+}
+
+/*****************************************************************************************************************/
+
+/*
+the heliocentric rectangular position of a planet for a given datetime, in AU, referred to the mean ecliptic
+and equinox of J2000.0
+
+The x-axis points towards the vernal equinox of J2000.0, the z-axis towards the north ecliptic pole, and the
+y-axis completes the right-handed system.
+*/
+func GetHeliocentricCartesianCoordinate(datetime time.Time, planet Planet) common.CartesianCoordinate {
+	return getHeliocentricPosition(epoch.GetJulianDate(datetime), planet)
+}
+
+/*****************************************************************************************************************/
+
+/*
+the heliocentric ecliptic coordinate of a planet for a given datetime, and its distance from the Sun in AU
+
+The heliocentric ecliptic coordinate is the position of the planet as seen from the centre of the Sun,
+referred to the mean ecliptic and equinox of date.
+*/
+func GetHeliocentricEclipticCoordinate(datetime time.Time, planet Planet) (common.EclipticCoordinate, float64) {
+	// get the heliocentric ecliptic coordinate, referred to the mean ecliptic and equinox of J2000.0:
+	ec, r := convertCartesianToEclipticCoordinate(GetHeliocentricCartesianCoordinate(datetime, planet))
+
+	// precess the heliocentric ecliptic coordinate to the mean ecliptic and equinox of date:
+	return coordinates.GetPrecessedEclipticCoordinate(datetime, ec), r
+}
+
+/*****************************************************************************************************************/
+
+/*
+the geocentric rectangular position of a planet for a given datetime, in AU, referred to the mean ecliptic
+and equinox of J2000.0, corrected for light travel time
+
+The position of the planet is computed at the time at which the light arriving at the Earth left the planet,
+found by iterating on the light travel time over the geocentric distance of the planet.
+*/
+func GetGeocentricCartesianCoordinate(datetime time.Time, planet Planet) common.CartesianCoordinate {
+	// get the Julian Date for the given datetime:
+	JD := epoch.GetJulianDate(datetime)
+
+	// the heliocentric position of the Earth at the time of observation:
+	earth := getHeliocentricPosition(JD, Earth)
+
+	// the light travel time from the planet to the Earth, in days:
+	τ := 0.0
+
+	position := common.CartesianCoordinate{}
+
+	for i := 0; i < 5; i++ {
+		p := getHeliocentricPosition(JD-τ, planet)
+
+		position = common.CartesianCoordinate{
+			X: p.X - earth.X,
+			Y: p.Y - earth.Y,
+			Z: p.Z - earth.Z,
+		}
+
+		Δ := math.Sqrt(math.Pow(position.X, 2) + math.Pow(position.Y, 2) + math.Pow(position.Z, 2))
+
+		τ = Δ * LIGHT_TIME_PER_AU
+	}
+
+	return position
+}
+
+/*****************************************************************************************************************/
+
+/*
+the geocentric ecliptic coordinate of a planet for a given datetime, and its distance from the Earth in AU
+
+The geocentric ecliptic coordinate is the position of the planet as seen from the centre of the Earth,
+corrected for light travel time, and referred to the mean ecliptic and equinox of date.
+*/
+func GetGeocentricEclipticCoordinate(datetime time.Time, planet Planet) (common.EclipticCoordinate, float64) {
+	// get the geocentric ecliptic coordinate, referred to the mean ecliptic and equinox of J2000.0:
+	ec, Δ := convertCartesianToEclipticCoordinate(GetGeocentricCartesianCoordinate(datetime, planet))
+
+	// precess the geocentric ecliptic coordinate to the mean ecliptic and equinox of date:
+	return coordinates.GetPrecessedEclipticCoordinate(datetime, ec), Δ
+}
+
+/*****************************************************************************************************************/
+
+/*
+the geocentric equatorial coordinate of a planet for a given datetime, and its distance from the Earth in AU
+
+The geocentric equatorial coordinate is the position of the planet as seen from the centre of the Earth,
+corrected for light travel time, and referred to the mean equator and equinox of date.
+*/
+func GetEquatorialCoordinate(datetime time.Time, planet Planet) (common.EquatorialCoordinate, float64) {
+	// get the geocentric ecliptic coordinate:
+	ec, Δ := GetGeocentricEclipticCoordinate(datetime, planet)
+
+	// convert the geocentric ecliptic coordinate to the geocentric equatorial coordinate:
+	return coordinates.ConvertEclipticToEquatorialCoordinate(datetime, ec), Δ
+}
+
+/*****************************************************************************************************************/
+
+/*
+the horizontal coordinate of a planet for a given datetime and observer
+
+The horizontal coordinate is the position of the planet in the sky relative to the observer's local horizon,
+neglecting the small effects of diurnal parallax and atmospheric refraction.
+*/
+func GetHorizontalCoordinate(
+	datetime time.Time,
+	observer common.GeographicCoordinate,
+	planet Planet,
+) common.HorizontalCoordinate {
+	// get the geocentric equatorial coordinate:
+	eq, _ := GetEquatorialCoordinate(datetime, planet)
+
+	// convert the geocentric equatorial coordinate to the horizontal coordinate:
+	return coordinates.ConvertEquatorialToHorizontalCoordinate(datetime, observer, eq)
+}
+
+/*****************************************************************************************************************/
@@ -0,0 +1,116 @@
+/*****************************************************************************************************************/
+
+//	@author		Michael Roberts <michael@observerly.com>
+//	@package	@observerly/sidera
+//	@license	Copyright © 2021-2024 observerly
+
+/*****************************************************************************************************************/
+
+package planets
+
+/*****************************************************************************************************************/
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/observerly/sidera/pkg/common"
+)
+
+/*****************************************************************************************************************/
+
+// We define a datetime of 20 December 1992 for comparison against Meeus, "Astronomical Algorithms", Example 33.a:
+var datetime time.Time = time.Date(1992, 12, 20, 0, 0, 0, 0, time.UTC)
+
+/*****************************************************************************************************************/
+
+var observer common.GeographicCoordinate = common.GeographicCoordinate{
+	Latitude:  19.8207,
+	Longitude: -155.468094,
+	Elevation: 4205,
+}
+
+/*****************************************************************************************************************/
+
+func TestGetHeliocentricEclipticCoordinate(t *testing.T) {
+	ec, r := GetHeliocentricEclipticCoordinate(datetime, Venus)
+
+	if math.Abs(ec.Longitude-26.11428) > 0.01 {
+		t.Errorf("got %f, wanted %f", ec.Longitude, 26.11428)
+	}
+
+	if math.Abs(ec.Latitude+2.62070) > 0.01 {
+		t.Errorf("got %f, wanted %f", ec.Latitude, -2.62070)
+	}
+
+	if math.Abs(r-0.724603) > 0.0001 {
+		t.Errorf("got %f, wanted %f", r, 0.724603)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetGeocentricEclipticCoordinate(t *testing.T) {
+	ec, Δ := GetGeocentricEclipticCoordinate(datetime, Venus)
+
+	if math.Abs(ec.Longitude-313.08102) > 0.01 {
+		t.Errorf("got %f, wanted %f", ec.Longitude, 313.08102)
+	}
+
+	if math.Abs(ec.Latitude+2.08474) > 0.01 {
+		t.Errorf("got %f, wanted %f", ec.Latitude, -2.08474)
+	}
+
+	if math.Abs(Δ-0.910947) > 0.0002 {
+		t.Errorf("got %f, wanted %f", Δ, 0.910947)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetEquatorialCoordinate(t *testing.T) {
+	eq, _ := GetEquatorialCoordinate(datetime, Venus)
+
+	// α = 21h04m41.454s, δ = -18°53'16.84":
+	if math.Abs(eq.RightAscension-316.172725) > 0.01 {
+		t.Errorf("got %f, wanted %f", eq.RightAscension, 316.172725)
+	}
+
+	if math.Abs(eq.Declination+18.888011) > 0.01 {
+		t.Errorf("got %f, wanted %f", eq.Declination, -18.888011)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetHeliocentricDistances(t *testing.T) {
+	for _, planet := range []Planet{Mercury, Venus, Earth, Mars, Jupiter, Saturn, Uranus, Neptune} {
+		_, r := GetHeliocentricEclipticCoordinate(datetime, planet)
+
+		a := planet.Elements.SemiMajorAxis
+
+		e := planet.Elements.Eccentricity
+
+		// The heliocentric distance must lie between the perihelion and aphelion distances:
+		if r < a*(1-e)*0.999 || r > a*(1+e)*1.001 {
+			t.Errorf("got %f AU for %s, wanted between %f and %f AU", r, planet.Name, a*(1-e), a*(1+e))
+		}
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetHorizontalCoordinate(t *testing.T) {
+	hz := GetHorizontalCoordinate(datetime, observer, Jupiter)
+
+	if hz.Altitude < -90 || hz.Altitude > 90 {
+		t.Errorf("got %f, wanted an altitude between -90 and 90 degrees", hz.Altitude)
+	}
+
+	if hz.Azimuth < 0 || hz.Azimuth > 360 {
+		t.Errorf("got %f, wanted an azimuth between 0 and 360 degrees", hz.Azimuth)
+	}
+}
+
+/*****************************************************************************************************************/
@@ -0,0 +1,383 @@
+/*****************************************************************************************************************/
+
+//	@author		Michael Roberts <michael@observerly.com>
+//	@package	@observerly/sidera
+//	@license	Copyright © 2021-2024 observerly
+
+/*****************************************************************************************************************/
+
+package pointing
+
+/*****************************************************************************************************************/
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/observerly/sidera/pkg/astrometry"
+	"github.com/observerly/sidera/pkg/common"
+	"github.com/observerly/sidera/pkg/epoch"
+)
+
+/*****************************************************************************************************************/
+
+var (
+	// there are fewer equations of condition than terms in the model, so that the terms are undetermined:
+	ErrInsufficientObservations = errors.New("insufficient observations to fit the pointing model")
+	// the terms of the model cannot be separated by the observations, e.g., for observations at a single position:
+	ErrSingularModel = errors.New("pointing model terms are degenerate for the observations")
+)
+
+/*****************************************************************************************************************/
+
+type Term int
+
+/*****************************************************************************************************************/
+
+const (
+	// the index error in hour angle, i.e., the zero point of the hour angle axis:
+	IH Term = iota
+	// the index error in declination, i.e., the zero point of the declination axis:
+	ID
+	// the collimation error, i.e., the non-perpendicularity of the optical axis to the declination axis:
+	CH
+	// the non-perpendicularity of the declination axis to the polar axis:
+	NP
+	// the misalignment of the polar axis in azimuth, positive when the pole of the mount is east of the true pole:
+	MA
+	// the misalignment of the polar axis in elevation, positive when the pole of the mount is above the true pole:
+	ME
+	// the flexure of the tube, proportional to the sine of the zenith distance, with the sign of TPOINT, so that a
+	// positive coefficient places the mount's axes further from the zenith than the target:
+	TF
+	// the flexure of the fork, or of the declination axis, proportional to the cosine of the hour angle:
+	FO
+	// the flexure of the declination axis, proportional to the cosine of the zenith distance in hour angle:
+	DAF
+)
+
+/*****************************************************************************************************************/
+
+// the standard terms of an equatorial pointing model, in the order in which they are conventionally fitted:
+var Terms = []Term{IH, ID, CH, NP, MA, ME, TF, FO, DAF}
+
+/*****************************************************************************************************************/
+
+/*
+a pointing model, as the coefficients of its terms in degrees
+
+The terms follow the conventions of Wallace's TPOINT, where the position of the mount's axes is the true
+(topocentric apparent) position of the target plus the sum of the terms, each of which is its coefficient
+multiplied by a function of the hour angle and declination of the target and the latitude of the observer.
+Terms which are absent from the model are zero. Every term has the sign of the published TPOINT term, so that
+coefficients fitted in TPOINT may be applied unchanged, e.g., a positive tube flexure (TF) places the axes
+further from the zenith than the target, by TF multiplied by the sine of its zenith distance.
+*/
+type Model map[Term]float64
+
+/*****************************************************************************************************************/
+
+/*
+the position of the axes of an equatorial mount, as its hour angle and declination in degrees
+*/
+type MountCoordinate struct {
+	HourAngle   float64
+	Declination float64
+}
+
+/*****************************************************************************************************************/
+
+/*
+a pointing observation, i.e., the coordinate to which the mount was commanded and the coordinate at which the
+telescope was measured to be pointing, e.g., by plate solving, at a given datetime
+*/
+type Observation struct {
+	Datetime  time.Time
+	Commanded common.EquatorialCoordinate
+	Measured  common.EquatorialCoordinate
+}
+
+/*****************************************************************************************************************/
+
+/*
+the residual of a pointing observation after the removal of a pointing model, in degrees on the sky
+
+The residual in hour angle is multiplied by the cosine of the declination, so that both residuals are arcs of
+great circles, and may be combined into a total pointing error.
+*/
+type Residual struct {
+	HourAngle   float64
+	Declination float64
+}
+
+/*****************************************************************************************************************/
+
+/*
+the corrections to the hour angle and declination for a unit coefficient of a term, for a given hour angle,
+declination and latitude, all in radians
+
+The terms containing the secant or tangent of the declination are undefined at the celestial poles.
+*/
+func getTerm(term Term, h float64, δ float64, φ float64) (Δh float64, Δδ float64) {
+	switch term {
+	case IH:
+		return -1, 0
+	case ID:
+		return 0, -1
+	case CH:
+		return -1 / math.Cos(δ), 0
+	case NP:
+		return -math.Tan(δ), 0
+	case MA:
+		return -math.Cos(h) * math.Tan(δ), math.Sin(h)
+	case ME:
+		return math.Sin(h) * math.Tan(δ), math.Cos(h)
+	case TF:
+		return math.Cos(φ) * math.Sin(h) / math.Cos(δ), math.Cos(φ)*math.Cos(h)*math.Sin(δ) - math.Sin(φ)*math.Cos(δ)
+	case FO:
+		return 0, math.Cos(h)
+	case DAF:
+		return -(math.Cos(φ)*math.Cos(h) + math.Sin(φ)*math.Tan(δ)), 0
+	}
+
+	return 0, 0
+}
+
+/*****************************************************************************************************************/
+
+/*
+the corrections to the hour angle and declination, in degrees, of a pointing model for a target at a given true
+hour angle and declination, in degrees, for a given observer
+*/
+func GetCorrection(model Model, observer common.GeographicCoordinate, ha float64, dec float64) (Δh float64, Δδ float64) {
+	h := common.Radians(ha)
+
+	δ := common.Radians(dec)
+
+	φ := common.Radians(observer.Latitude)
+
+	for term, coefficient := range model {
+		x, y := getTerm(term, h, δ, φ)
+
+		Δh += coefficient * x
+
+		Δδ += coefficient * y
+	}
+
+	return Δh, Δδ
+}
+
+/*****************************************************************************************************************/
+
+/*
+converts the true (topocentric apparent) coordinate of a target to the position of the axes of an equatorial
+mount, at a given datetime and for a given observer, by applying a pointing model
+*/
+func ConvertEquatorialToMountCoordinate(
+	datetime time.Time,
+	observer common.GeographicCoordinate,
+	target common.EquatorialCoordinate,
+	model Model,
+) MountCoordinate {
+	ha := astrometry.GetHourAngle(datetime, observer, target)
+
+	Δh, Δδ := GetCorrection(model, observer, ha, target.Declination)
+
+	return MountCoordinate{
+		HourAngle:   math.Mod(ha+Δh+360, 360),
+		Declination: target.Declination + Δδ,
+	}
+}
+
+/*****************************************************************************************************************/
+
+/*
+converts the position of the axes of an equatorial mount to the true (topocentric apparent) coordinate at which
+the telescope is pointing, at a given datetime and for a given observer, by removing a pointing model
+
+The model is a function of the true position, and so is removed by iteration, which converges rapidly as the
+terms are small.
+*/
+func ConvertMountToEquatorialCoordinate(
+	datetime time.Time,
+	observer common.GeographicCoordinate,
+	mount MountCoordinate,
+	model Model,
+) common.EquatorialCoordinate {
+	ha, dec := mount.HourAngle, mount.Declination
+
+	for i := 0; i < 10; i++ {
+		Δh, Δδ := GetCorrection(model, observer, ha, dec)
+
+		ha, dec = mount.HourAngle-Δh, mount.Declination-Δδ
+	}
+
+	LST := epoch.GetLocalSiderealTime(datetime, observer)
+
+	return common.EquatorialCoordinate{
+		RightAscension: math.Mod(math.Mod(LST*15-ha, 360)+360, 360),
+		Declination:    dec,
+	}
+}
+
+/*****************************************************************************************************************/
+
+/*
+the differences between the commanded and measured hour angles and declinations of a pointing observation, in
+degrees, and the measured (true) hour angle and declination, in degrees
+*/
+func getObservation(observer common.GeographicCoordinate, observation Observation) (Δh float64, Δδ float64, ha float64, dec float64) {
+	ha = astrometry.GetHourAngle(observation.Datetime, observer, observation.Measured)
+
+	// the hour angle increases as the right ascension decreases:
+	Δh = math.Remainder(observation.Measured.RightAscension-observation.Commanded.RightAscension, 360)
+
+	Δδ = observation.Commanded.Declination - observation.Measured.Declination
+
+	return Δh, Δδ, ha, observation.Measured.Declination
+}
+
+/*****************************************************************************************************************/
+
+/*
+fits the coefficients of the given terms of a pointing model to a set of pointing observations, for a given
+observer, by linear least squares
+
+Each observation provides two equations of condition, one in hour angle and one in declination, and the hour angle
+equations are weighted by the cosine of the declination, so that the fit minimises the pointing errors on the sky.
+The observations should be well distributed across the sky, as terms whose effects are similar across the
+observations cannot be separated, e.g., IH and CH for observations close to the celestial equator.
+*/
+func FitModel(observer common.GeographicCoordinate, observations []Observation, terms []Term) (Model, error) {
+	n := len(terms)
+
+	if 2*len(observations) < n {
+		return nil, ErrInsufficientObservations
+	}
+
+	φ := common.Radians(observer.Latitude)
+
+	// the normal equations, A x = b, as an augmented matrix:
+	A := make([][]float64, n)
+
+	for i := range A {
+		A[i] = make([]float64, n+1)
+	}
+
+	for _, observation := range observations {
+		Δh, Δδ, ha, dec := getObservation(observer, observation)
+
+		h := common.Radians(ha)
+
+		δ := common.Radians(dec)
+
+		x := make([]float64, n)
+
+		y := make([]float64, n)
+
+		for i, term := range terms {
+			x[i], y[i] = getTerm(term, h, δ, φ)
+
+			x[i] *= math.Cos(δ)
+		}
+
+		for i := 0; i < n; i++ {
+			for j := 0; j < n; j++ {
+				A[i][j] += x[i]*x[j] + y[i]*y[j]
+			}
+
+			A[i][n] += x[i]*Δh*math.Cos(δ) + y[i]*Δδ
+		}
+	}
+
+	// the scale of the normal equations, against which a vanishing pivot is measured:
+	scale := 0.0
+
+	for i := 0; i < n; i++ {
+		scale = math.Max(scale, math.Abs(A[i][i]))
+	}
+
+	// Gaussian elimination with partial pivoting:
+	for i := 0; i < n; i++ {
+		p := i
+
+		for j := i + 1; j < n; j++ {
+			if math.Abs(A[j][i]) > math.Abs(A[p][i]) {
+				p = j
+			}
+		}
+
+		if math.Abs(A[p][i]) <= 1e-12*scale {
+			return nil, ErrSingularModel
+		}
+
+		A[i], A[p] = A[p], A[i]
+
+		for j := i + 1; j < n; j++ {
+			f := A[j][i] / A[i][i]
+
+			for k := i; k <= n; k++ {
+				A[j][k] -= f * A[i][k]
+			}
+		}
+	}
+
+	model := make(Model, n)
+
+	// back substitution:
+	for i := n - 1; i >= 0; i-- {
+		s := A[i][n]
+
+		for j := i + 1; j < n; j++ {
+			s -= A[i][j] * model[terms[j]]
+		}
+
+		model[terms[i]] = s / A[i][i]
+	}
+
+	return model, nil
+}
+
+/*****************************************************************************************************************/
+
+/*
+the residuals of a set of pointing observations after the removal of a pointing model, for a given observer
+*/
+func GetResiduals(observer common.GeographicCoordinate, observations []Observation, model Model) []Residual {
+	residuals := make([]Residual, len(observations))
+
+	for i, observation := range observations {
+		Δh, Δδ, ha, dec := getObservation(observer, observation)
+
+		h, δ := GetCorrection(model, observer, ha, dec)
+
+		residuals[i] = Residual{
+			HourAngle:   (Δh - h) * math.Cos(common.Radians(dec)),
+			Declination: Δδ - δ,
+		}
+	}
+
+	return residuals
+}
+
+/*****************************************************************************************************************/
+
+/*
+the root mean square of the total pointing errors of a set of residuals, in degrees on the sky
+*/
+func GetRMS(residuals []Residual) float64 {
+	if len(residuals) == 0 {
+		return 0
+	}
+
+	s := 0.0
+
+	for _, residual := range residuals {
+		s += math.Pow(residual.HourAngle, 2) + math.Pow(residual.Declination, 2)
+	}
+
+	return math.Sqrt(s / float64(len(residuals)))
+}
+
+/*****************************************************************************************************************/
@@ -0,0 +1,264 @@
+/*****************************************************************************************************************/
+
+//	@author		Michael Roberts <michael@observerly.com>
+//	@package	@observerly/sidera
+//	@license	Copyright © 2021-2024 observerly
+
+/*****************************************************************************************************************/
+
+package pointing
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/observerly/sidera/pkg/astrometry"
+	"github.com/observerly/sidera/pkg/common"
+	"github.com/observerly/sidera/pkg/epoch"
+)
+
+/*****************************************************************************************************************/
+
+// We define a datetime as some arbitrary date and time for testing purposes:
+var datetime time.Time = time.Date(2021, 5, 14, 0, 0, 0, 0, time.UTC)
+
+/*****************************************************************************************************************/
+
+var observer common.GeographicCoordinate = common.GeographicCoordinate{
+	Latitude:  19.8207,
+	Longitude: -155.468094,
+	Elevation: 4205,
+}
+
+/*****************************************************************************************************************/
+
+// a typical pointing model, with coefficients of tens of arcseconds:
+var model Model = Model{
+	IH:  45.0 / 3600,
+	ID:  -30.0 / 3600,
+	CH:  20.0 / 3600,
+	NP:  -12.0 / 3600,
+	MA:  60.0 / 3600,
+	ME:  -40.0 / 3600,
+	TF:  8.0 / 3600,
+	FO:  5.0 / 3600,
+	DAF: -6.0 / 3600,
+}
+
+/*****************************************************************************************************************/
+
+// simulates pointing observations across the sky for a given pointing model:
+func getObservations(model Model) []Observation {
+	observations := []Observation{}
+
+	LST := epoch.GetLocalSiderealTime(datetime, observer)
+
+	for ha := -75.0; ha <= 75; ha += 15 {
+		for dec := -30.0; dec <= 80; dec += 10 {
+			measured := common.EquatorialCoordinate{
+				RightAscension: math.Mod(LST*15-ha+360, 360),
+				Declination:    dec,
+			}
+
+			mount := ConvertEquatorialToMountCoordinate(datetime, observer, measured, model)
+
+			commanded := common.EquatorialCoordinate{
+				RightAscension: math.Mod(LST*15-mount.HourAngle+720, 360),
+				Declination:    mount.Declination,
+			}
+
+			observations = append(observations, Observation{
+				Datetime:  datetime,
+				Commanded: commanded,
+				Measured:  measured,
+			})
+		}
+	}
+
+	return observations
+}
+
+/*****************************************************************************************************************/
+
+func TestGetCorrection(t *testing.T) {
+	// the index errors are constant across the sky:
+	Δh, Δδ := GetCorrection(Model{IH: 0.01, ID: 0.02}, observer, 30, 45)
+
+	if math.Abs(Δh-(-0.01)) > 1e-12 {
+		t.Errorf("got %f, wanted %f", Δh, -0.01)
+	}
+
+	if math.Abs(Δδ-(-0.02)) > 1e-12 {
+		t.Errorf("got %f, wanted %f", Δδ, -0.02)
+	}
+
+	// the collimation error is proportional to the secant of the declination:
+	Δh, _ = GetCorrection(Model{CH: 0.01}, observer, 30, 60)
+
+	if math.Abs(Δh-(-0.02)) > 1e-12 {
+		t.Errorf("got %f, wanted %f", Δh, -0.02)
+	}
+
+	// an empty model makes no correction:
+	Δh, Δδ = GetCorrection(Model{}, observer, 30, 45)
+
+	if Δh != 0 || Δδ != 0 {
+		t.Errorf("got %f, %f, wanted no correction", Δh, Δδ)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetCorrectionTubeFlexure(t *testing.T) {
+	// the published TPOINT term, ΔH = +TF cos φ sin H sec δ and Δδ = +TF (cos φ cos H sin δ - sin φ cos δ), which
+	// on the meridian, south of the zenith, places the mount to the south, i.e., away from the zenith:
+	_, Δδ := GetCorrection(Model{TF: 0.01}, observer, 0, -10)
+
+	want := -0.01 * math.Sin(common.Radians(observer.Latitude+10))
+
+	if math.Abs(Δδ-want) > 1e-12 {
+		t.Errorf("got %f, wanted %f", Δδ, want)
+	}
+
+	// and north of the zenith, the mount is placed to the north:
+	if _, Δδ := GetCorrection(Model{TF: 0.01}, observer, 0, 60); Δδ <= 0 {
+		t.Errorf("got %f, wanted a positive correction away from the zenith", Δδ)
+	}
+
+	// east of the meridian, the mount is placed eastward, i.e., at a lesser hour angle:
+	if Δh, _ := GetCorrection(Model{TF: 0.01}, observer, -45, 20); Δh >= 0 {
+		t.Errorf("got %f, wanted a negative correction away from the zenith", Δh)
+	}
+
+	// the position of the mount's axes is further from the zenith than the true position:
+	target := common.EquatorialCoordinate{RightAscension: 88.7929583, Declination: 7.4070639}
+
+	mount := ConvertEquatorialToMountCoordinate(datetime, observer, target, Model{TF: 0.1})
+
+	LST := epoch.GetLocalSiderealTime(datetime, observer)
+
+	commanded := common.EquatorialCoordinate{
+		RightAscension: math.Mod(LST*15-mount.HourAngle+720, 360),
+		Declination:    mount.Declination,
+	}
+
+	zenith := common.EquatorialCoordinate{RightAscension: math.Mod(LST*15, 360), Declination: observer.Latitude}
+
+	if astrometry.GetAngularSeparation(commanded, zenith) <= astrometry.GetAngularSeparation(target, zenith) {
+		t.Errorf("got the position of the mount closer to the zenith than the true position")
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestConvertEquatorialToMountCoordinateRoundTrip(t *testing.T) {
+	target := common.EquatorialCoordinate{
+		RightAscension: 88.7929583,
+		Declination:    7.4070639,
+	}
+
+	mount := ConvertEquatorialToMountCoordinate(datetime, observer, target, model)
+
+	eq := ConvertMountToEquatorialCoordinate(datetime, observer, mount, model)
+
+	if math.Abs(eq.RightAscension-target.RightAscension) > 1e-9 {
+		t.Errorf("got %f, wanted %f", eq.RightAscension, target.RightAscension)
+	}
+
+	if math.Abs(eq.Declination-target.Declination) > 1e-9 {
+		t.Errorf("got %f, wanted %f", eq.Declination, target.Declination)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestFitModel(t *testing.T) {
+	observations := getObservations(model)
+
+	fit, err := FitModel(observer, observations, Terms)
+
+	if err != nil {
+		t.Fatalf("got error %v, wanted a fitted model", err)
+	}
+
+	// the coefficients are recovered to within a tenth of an arcsecond:
+	for _, term := range Terms {
+		if math.Abs(fit[term]-model[term])*3600 > 0.1 {
+			t.Errorf("term %d: got %f\", wanted %f\"", term, fit[term]*3600, model[term]*3600)
+		}
+	}
+
+	// the residuals after the removal of the model are negligible:
+	if rms := GetRMS(GetResiduals(observer, observations, fit)) * 3600; rms > 0.1 {
+		t.Errorf("got an RMS of %f\", wanted less than 0.1\"", rms)
+	}
+
+	// the residuals without the model are tens of arcseconds:
+	if rms := GetRMS(GetResiduals(observer, observations, Model{})) * 3600; rms < 30 {
+		t.Errorf("got an RMS of %f\", wanted more than 30\"", rms)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestFitModelSubsetOfTerms(t *testing.T) {
+	observations := getObservations(Model{IH: 0.01, ID: -0.005, MA: 0.002, ME: 0.003})
+
+	fit, err := FitModel(observer, observations, []Term{IH, ID, MA, ME})
+
+	if err != nil {
+		t.Fatalf("got error %v, wanted a fitted model", err)
+	}
+
+	if math.Abs(fit[IH]-0.01) > 1e-6 || math.Abs(fit[ID]-(-0.005)) > 1e-6 {
+		t.Errorf("got IH %f and ID %f, wanted %f and %f", fit[IH], fit[ID], 0.01, -0.005)
+	}
+
+	if math.Abs(fit[MA]-0.002) > 1e-6 || math.Abs(fit[ME]-0.003) > 1e-6 {
+		t.Errorf("got MA %f and ME %f, wanted %f and %f", fit[MA], fit[ME], 0.002, 0.003)
+	}
+
+	if _, ok := fit[CH]; ok {
+		t.Errorf("got a CH term, wanted only the fitted terms")
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestFitModelInsufficientObservations(t *testing.T) {
+	observations := getObservations(model)[:2]
+
+	if _, err := FitModel(observer, observations, Terms); err != ErrInsufficientObservations {
+		t.Errorf("got error %v, wanted %v", err, ErrInsufficientObservations)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestFitModelSingular(t *testing.T) {
+	observation := getObservations(model)[0]
+
+	// repeated observations of a single position cannot separate the terms:
+	observations := []Observation{observation, observation, observation, observation, observation}
+
+	if _, err := FitModel(observer, observations, Terms); err != ErrSingularModel {
+		t.Errorf("got error %v, wanted %v", err, ErrSingularModel)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetRMS(t *testing.T) {
+	rms := GetRMS([]Residual{{HourAngle: 3, Declination: 4}, {HourAngle: 0, Declination: 0}})
+
+	if math.Abs(rms-math.Sqrt(12.5)) > 1e-12 {
+		t.Errorf("got %f, wanted %f", rms, math.Sqrt(12.5))
+	}
+
+	if GetRMS([]Residual{}) != 0 {
+		t.Errorf("got %f, wanted zero for no residuals", GetRMS([]Residual{}))
+	}
+}
+
+/*****************************************************************************************************************/
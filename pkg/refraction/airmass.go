@@ -0,0 +1,171 @@
+/*****************************************************************************************************************/
+
+//	@author		Michael Roberts <michael@observerly.com>
+//	@package	@observerly/sidera
+//	@license	Copyright © 2021-2024 observerly
+
+/*****************************************************************************************************************/
+
+package refraction
+
+/*****************************************************************************************************************/
+
+import (
+	"math"
+
+	"github.com/observerly/sidera/pkg/common"
+)
+
+/*****************************************************************************************************************/
+
+/*
+a model of the airmass, which returns the relative optical path length through the atmosphere for a target at
+a given altitude, in degrees, normalised to unity at the zenith
+
+The airmass is infinite for a target below the horizon.
+*/
+type AirmassModel func(target common.HorizontalCoordinate) float64
+
+/*****************************************************************************************************************/
+
+/*
+the cosine of the zenith distance of a target, or false if the target is below the horizon
+*/
+func getCosineZenithDistance(target common.HorizontalCoordinate) (float64, bool) {
+	if target.Altitude < 0 {
+		return 0, false
+	}
+
+	return math.Sin(common.Radians(target.Altitude)), true
+}
+
+/*****************************************************************************************************************/
+
+/*
+the airmass of a target for a given apparent altitude, using Pickering's (2002) formula
+
+See GetPickeringAirmass for details of the formula, which is valid from the zenith to the horizon, where the
+airmass is around 38.7.
+*/
+func GetAirmass(target common.HorizontalCoordinate) float64 {
+	return GetPickeringAirmass(target)
+}
+
+/*****************************************************************************************************************/
+
+/*
+the airmass of a target for a given altitude, for a plane-parallel atmosphere, i.e., the secant of the zenith
+distance
+
+The plane-parallel airmass is accurate to around 1% for zenith distances of less than 70 degrees (altitudes above
+20 degrees), but neglects the curvature of the atmosphere, and becomes infinite at the horizon.
+*/
+func GetPlaneParallelAirmass(target common.HorizontalCoordinate) float64 {
+	cosz, ok := getCosineZenithDistance(target)
+
+	if !ok || cosz == 0 {
+		return math.Inf(1)
+	}
+
+	return 1 / cosz
+}
+
+/*****************************************************************************************************************/
+
+/*
+the airmass of a target for a given true altitude, using Hardie's (1962) polynomial in the secant of the zenith
+distance
+
+Hardie's formula agrees with Bemporad's tabulation to within 0.01 for zenith distances of less than 80 degrees
+(altitudes above 10 degrees), and is widely used in photometric reductions, but is unreliable closer to the
+horizon, and becomes negative within around one and a half degrees of it.
+*/
+func GetHardieAirmass(target common.HorizontalCoordinate) float64 {
+	cosz, ok := getCosineZenithDistance(target)
+
+	if !ok || cosz == 0 {
+		return math.Inf(1)
+	}
+
+	s := 1/cosz - 1
+
+	return 1/cosz - 0.0018167*s - 0.002875*math.Pow(s, 2) - 0.0008083*math.Pow(s, 3)
+}
+
+/*****************************************************************************************************************/
+
+/*
+the airmass of a target for a given true altitude, using Young's (1994) rational function of the cosine of the
+true zenith distance
+
+Young's formula is accurate to around 0.0037 (or 0.05% near the zenith) from the zenith to the horizon, where
+the airmass is around 31.7, as the true zenith distance is used rather than the apparent zenith distance.
+*/
+func GetYoungAirmass(target common.HorizontalCoordinate) float64 {
+	cosz, ok := getCosineZenithDistance(target)
+
+	if !ok {
+		return math.Inf(1)
+	}
+
+	return (1.002432*math.Pow(cosz, 2) + 0.148386*cosz + 0.0096467) /
+		(math.Pow(cosz, 3) + 0.149864*math.Pow(cosz, 2) + 0.0102963*cosz + 0.000303978)
+}
+
+/*****************************************************************************************************************/
+
+/*
+the airmass of a target for a given apparent altitude, using Kasten & Young's (1989) formula
+
+Kasten & Young's formula is fitted to the ISO standard atmosphere (1972), and is accurate to better than 0.5%
+from the zenith to the horizon, where the airmass is around 37.9.
+*/
+func GetKastenYoungAirmass(target common.HorizontalCoordinate) float64 {
+	cosz, ok := getCosineZenithDistance(target)
+
+	if !ok {
+		return math.Inf(1)
+	}
+
+	z := 90 - target.Altitude
+
+	return 1 / (cosz + 0.50572*math.Pow(96.07995-z, -1.6364))
+}
+
+/*****************************************************************************************************************/
+
+/*
+the airmass of a target for a given apparent altitude, using Pickering's (2002) formula
+
+Pickering's formula is fitted to the atmospheric model of Garfinkel (1967), and is accurate from the zenith to
+the horizon, where the airmass is around 38.7, and so is well suited to observations close to the horizon.
+*/
+func GetPickeringAirmass(target common.HorizontalCoordinate) float64 {
+	if _, ok := getCosineZenithDistance(target); !ok {
+		return math.Inf(1)
+	}
+
+	h := target.Altitude
+
+	return 1 / math.Sin(common.Radians(h+244/(165+47*math.Pow(h, 1.1))))
+}
+
+/*****************************************************************************************************************/
+
+/*
+the airmass of a target for a given apparent altitude, using Rozenberg's (1966) formula
+
+Rozenberg's formula is accurate from the zenith to the horizon, where the airmass is 40, and gives reasonable
+results for a target slightly below the horizon, though it is taken as infinite here for consistency.
+*/
+func GetRozenbergAirmass(target common.HorizontalCoordinate) float64 {
+	cosz, ok := getCosineZenithDistance(target)
+
+	if !ok {
+		return math.Inf(1)
+	}
+
+	return 1 / (cosz + 0.025*math.Exp(-11*cosz))
+}
+
+/*****************************************************************************************************************/
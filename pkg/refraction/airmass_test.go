@@ -0,0 +1,180 @@
+/*****************************************************************************************************************/
+
+//	@author		Michael Roberts <michael@observerly.com>
+//	@package	@observerly/sidera
+//	@license	Copyright © 2021-2024 observerly
+
+/*****************************************************************************************************************/
+
+package refraction
+
+import (
+	"math"
+	"testing"
+
+	"github.com/observerly/sidera/pkg/common"
+)
+
+/*****************************************************************************************************************/
+
+// Bemporad's (1904) tabulation of the airmass, by zenith distance in degrees:
+var bemporad = map[float64]float64{
+	0:  1.000,
+	60: 1.995,
+	70: 2.904,
+	75: 3.816,
+	80: 5.600,
+}
+
+/*****************************************************************************************************************/
+
+func TestAirmassAtTargetBelowHorizon(t *testing.T) {
+	// Test the airmass at a target below the horizon:
+	Z := GetAirmass(common.HorizontalCoordinate{
+		Altitude: -10.0,
+		Azimuth:  180.0,
+	})
+
+	if Z != math.Inf(1) {
+		t.Errorf("Expected airmass to be infinite, got %f", Z)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestAirmassAtTargetAboveHorizon(t *testing.T) {
+	// Test the airmass at a target above the horizon:
+	Z := GetAirmass(target)
+
+	if Z == math.Inf(1) {
+		t.Errorf("Expected airmass to be finite, got %f", Z)
+	}
+
+	if Z <= 0 {
+		t.Errorf("Expected airmass to be positive, got %f", Z)
+	}
+
+	if Z > 1.5 {
+		t.Errorf("Expected airmass to be less than 1.5, got %f", Z)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestAirmassAtTargetAtHorizon(t *testing.T) {
+	// Test the airmass at a target at the horizon, which is finite for an atmosphere of finite depth:
+	Z := GetAirmass(common.HorizontalCoordinate{
+		Altitude: 0.0,
+		Azimuth:  180.0,
+	})
+
+	if math.Abs(Z-38.7494) > 0.0001 {
+		t.Errorf("Expected airmass to be 38.7494, got %f", Z)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestAirmassModelsAgainstBemporad(t *testing.T) {
+	// the tolerance of each model, as a fraction of Bemporad's airmass, for zenith distances up to 80 degrees:
+	models := map[string]struct {
+		model     AirmassModel
+		tolerance float64
+	}{
+		"Hardie":       {GetHardieAirmass, 0.002},
+		"Young":        {GetYoungAirmass, 0.012},
+		"Kasten-Young": {GetKastenYoungAirmass, 0.005},
+		"Pickering":    {GetPickeringAirmass, 0.005},
+		"Rozenberg":    {GetRozenbergAirmass, 0.007},
+	}
+
+	for name, m := range models {
+		for z, want := range bemporad {
+			got := m.model(common.HorizontalCoordinate{Altitude: 90 - z, Azimuth: 180.0})
+
+			if math.Abs(got-want)/want > m.tolerance {
+				t.Errorf("%s: expected airmass at zenith distance %f to be %f, got %f", name, z, want, got)
+			}
+		}
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestPlaneParallelAirmass(t *testing.T) {
+	Z := GetPlaneParallelAirmass(common.HorizontalCoordinate{Altitude: 30.0, Azimuth: 180.0})
+
+	if math.Abs(Z-2) > 1e-12 {
+		t.Errorf("Expected airmass to be 2, got %f", Z)
+	}
+
+	Z = GetPlaneParallelAirmass(common.HorizontalCoordinate{Altitude: 0.0, Azimuth: 180.0})
+
+	if Z != math.Inf(1) {
+		t.Errorf("Expected airmass to be infinite, got %f", Z)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestAirmassModelsAtHorizon(t *testing.T) {
+	// the airmass at the horizon, as published for each model:
+	models := map[string]struct {
+		model AirmassModel
+		want  float64
+	}{
+		"Young":        {GetYoungAirmass, 31.7349},
+		"Kasten-Young": {GetKastenYoungAirmass, 37.9196},
+		"Pickering":    {GetPickeringAirmass, 38.7494},
+		"Rozenberg":    {GetRozenbergAirmass, 40.0000},
+	}
+
+	for name, m := range models {
+		got := m.model(common.HorizontalCoordinate{Altitude: 0.0, Azimuth: 180.0})
+
+		if math.Abs(got-m.want) > 0.0001 {
+			t.Errorf("%s: expected airmass at the horizon to be %f, got %f", name, m.want, got)
+		}
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestAirmassModelsBelowHorizon(t *testing.T) {
+	models := []AirmassModel{
+		GetPlaneParallelAirmass,
+		GetHardieAirmass,
+		GetYoungAirmass,
+		GetKastenYoungAirmass,
+		GetPickeringAirmass,
+		GetRozenbergAirmass,
+	}
+
+	for _, model := range models {
+		if Z := model(common.HorizontalCoordinate{Altitude: -1.0, Azimuth: 180.0}); Z != math.Inf(1) {
+			t.Errorf("Expected airmass to be infinite, got %f", Z)
+		}
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestAirmassModelsIncreaseTowardsHorizon(t *testing.T) {
+	models := []AirmassModel{GetYoungAirmass, GetKastenYoungAirmass, GetPickeringAirmass, GetRozenbergAirmass}
+
+	for _, model := range models {
+		previous := 0.0
+
+		for alt := 90.0; alt >= 0; alt -= 0.5 {
+			Z := model(common.HorizontalCoordinate{Altitude: alt, Azimuth: 180.0})
+
+			if Z < previous {
+				t.Errorf("Expected airmass at %f degrees to be at least %f, got %f", alt, previous, Z)
+			}
+
+			previous = Z
+		}
+	}
+}
+
+/*****************************************************************************************************************/
@@ -0,0 +1,105 @@
+/*****************************************************************************************************************/
+
+//	@author		Michael Roberts <michael@observerly.com>
+//	@package	@observerly/sidera
+//	@license	Copyright © 2021-2024 observerly
+
+/*****************************************************************************************************************/
+
+package refraction
+
+/*****************************************************************************************************************/
+
+import (
+	"github.com/observerly/sidera/pkg/common"
+)
+
+/*****************************************************************************************************************/
+
+/*
+a photometric band, with its effective wavelength in micrometres and its extinction coefficient in magnitudes
+per airmass
+
+The extinction coefficients of the standard bands are typical of a good, high altitude site on a clear night,
+and should be overridden with values measured at the observer's site where they are available, e.g.:
+
+	band := refraction.V
+	band.Extinction = 0.15
+*/
+type Band struct {
+	Name       string
+	Wavelength float64
+	Extinction float64
+}
+
+/*****************************************************************************************************************/
+
+// the Johnson-Cousins U band:
+var U = Band{Name: "U", Wavelength: 0.365, Extinction: 0.46}
+
+// the Johnson-Cousins B band:
+var B = Band{Name: "B", Wavelength: 0.445, Extinction: 0.22}
+
+// the Johnson-Cousins V band:
+var V = Band{Name: "V", Wavelength: 0.551, Extinction: 0.12}
+
+// the Johnson-Cousins R band:
+var R = Band{Name: "R", Wavelength: 0.658, Extinction: 0.08}
+
+// the Johnson-Cousins I band:
+var I = Band{Name: "I", Wavelength: 0.806, Extinction: 0.04}
+
+/*****************************************************************************************************************/
+
+// the Sloan u' band:
+var SloanU = Band{Name: "u'", Wavelength: 0.354, Extinction: 0.50}
+
+// the Sloan g' band:
+var SloanG = Band{Name: "g'", Wavelength: 0.475, Extinction: 0.18}
+
+// the Sloan r' band:
+var SloanR = Band{Name: "r'", Wavelength: 0.622, Extinction: 0.10}
+
+// the Sloan i' band:
+var SloanI = Band{Name: "i'", Wavelength: 0.763, Extinction: 0.06}
+
+// the Sloan z' band:
+var SloanZ = Band{Name: "z'", Wavelength: 0.905, Extinction: 0.06}
+
+/*****************************************************************************************************************/
+
+/*
+the atmospheric extinction, in magnitudes, for a given airmass in a given photometric band
+
+The extinction is the product of the extinction coefficient of the band and the airmass, i.e., the dimming of a
+target relative to its brightness above the atmosphere, and so is added to the magnitude of the target outside
+the atmosphere to give its observed magnitude.
+*/
+func GetExtinction(airmass float64, band Band) float64 {
+	return band.Extinction * airmass
+}
+
+/*****************************************************************************************************************/
+
+/*
+the atmospheric dispersion, in degrees, between two wavelengths (in micrometres) for a target at a given true
+altitude and atmosphere, i.e., the differential refraction of the first wavelength relative to the second
+
+The dispersion is positive when the first wavelength is refracted more than the second, i.e., when it is the
+shorter wavelength, in which case the image at the first wavelength is displaced towards the zenith relative to
+that at the second. The wavelength of the atmosphere is ignored, and the refraction is calculated with the
+rigorous model, which accounts for the variation of the refractivity of the air with wavelength.
+*/
+func GetDispersion(target common.HorizontalCoordinate, atmosphere Atmosphere, λ1 float64, λ2 float64) float64 {
+	a := atmosphere
+
+	a.Wavelength = λ1
+
+	b := atmosphere
+
+	b.Wavelength = λ2
+
+	return GetRigorousRefraction(target, a) - GetRigorousRefraction(target, b)
+}
+
+/*****************************************************************************************************************/
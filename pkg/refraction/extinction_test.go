@@ -0,0 +1,108 @@
+/*****************************************************************************************************************/
+
+//	@author		Michael Roberts <michael@observerly.com>
+//	@package	@observerly/sidera
+//	@license	Copyright © 2021-2024 observerly
+
+/*****************************************************************************************************************/
+
+package refraction
+
+import (
+	"math"
+	"testing"
+
+	"github.com/observerly/sidera/pkg/common"
+)
+
+/*****************************************************************************************************************/
+
+func TestGetExtinction(t *testing.T) {
+	// Test the extinction in the V band at an airmass of 2:
+	E := GetExtinction(2, V)
+
+	if math.Abs(E-0.24) > 1e-12 {
+		t.Errorf("Expected extinction to be 0.24, got %f", E)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetExtinctionWithOverride(t *testing.T) {
+	band := V
+
+	band.Extinction = 0.2
+
+	// Test that the extinction coefficient may be overridden for the observer's site:
+	E := GetExtinction(GetAirmass(common.HorizontalCoordinate{Altitude: 30.0, Azimuth: 180.0}), band)
+
+	if math.Abs(E-0.2*1.9942) > 0.001 {
+		t.Errorf("Expected extinction to be %f, got %f", 0.2*1.9942, E)
+	}
+
+	// Test that the default band is unchanged:
+	if V.Extinction != 0.12 {
+		t.Errorf("Expected the V band extinction coefficient to be unchanged, got %f", V.Extinction)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestBandsExtinctionDecreasesWithWavelength(t *testing.T) {
+	for _, bands := range [][]Band{{U, B, V, R, I}, {SloanU, SloanG, SloanR, SloanI, SloanZ}} {
+		for i := 1; i < len(bands); i++ {
+			if bands[i].Wavelength <= bands[i-1].Wavelength {
+				t.Errorf("Expected %s to be redder than %s", bands[i].Name, bands[i-1].Name)
+			}
+
+			if bands[i].Extinction > bands[i-1].Extinction {
+				t.Errorf("Expected the extinction of %s to be at most that of %s", bands[i].Name, bands[i-1].Name)
+			}
+		}
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetDispersion(t *testing.T) {
+	// Test the dispersion between 0.4 and 0.7 micrometres at 45 degrees, which is around 1.45 arcseconds:
+	D := GetDispersion(common.HorizontalCoordinate{Altitude: 45.0, Azimuth: 180.0}, StandardAtmosphere, 0.4, 0.7)
+
+	if math.Abs(D*3600-1.45) > 0.05 {
+		t.Errorf("Expected dispersion to be 1.45 arcseconds, got %f", D*3600)
+	}
+
+	// Test that the dispersion is antisymmetric in the wavelengths:
+	if E := GetDispersion(common.HorizontalCoordinate{Altitude: 45.0, Azimuth: 180.0}, StandardAtmosphere, 0.7, 0.4); E != -D {
+		t.Errorf("Expected dispersion to be %f, got %f", -D*3600, E*3600)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetDispersionAtZenith(t *testing.T) {
+	// Test that there is no dispersion at the zenith:
+	D := GetDispersion(common.HorizontalCoordinate{Altitude: 90.0, Azimuth: 180.0}, StandardAtmosphere, 0.4, 0.7)
+
+	if math.Abs(D*3600) > 0.001 {
+		t.Errorf("Expected dispersion to be zero, got %f", D*3600)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetDispersionWithPressure(t *testing.T) {
+	// Test that the dispersion at a high altitude site, e.g., Mauna Kea, is around 60% of that at sea level:
+	sea := GetDispersion(common.HorizontalCoordinate{Altitude: 45.0, Azimuth: 180.0}, StandardAtmosphere, 0.4, 0.7)
+
+	high := GetDispersion(common.HorizontalCoordinate{Altitude: 45.0, Azimuth: 180.0}, Atmosphere{
+		Pressure:    61500,
+		Temperature: 273.15,
+	}, 0.4, 0.7)
+
+	if high/sea < 0.55 || high/sea > 0.7 {
+		t.Errorf("Expected dispersion to be around 60%% of %f, got %f", sea*3600, high*3600)
+	}
+}
+
+/*****************************************************************************************************************/
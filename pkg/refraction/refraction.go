@@ -18,6 +18,69 @@ import (
 
 /*****************************************************************************************************************/
 
+/*
+the minimum true altitude at which the refraction is modelled, in degrees
+
+A target slightly below the geometric horizon may still be observed above it, as the refraction at the horizon
+exceeds half a degree. Below this altitude, the refraction is held at its value at this altitude, so that it may
+be applied and removed symmetrically.
+*/
+const MINIMUM_ALTITUDE float64 = -1
+
+/*****************************************************************************************************************/
+
+/*
+an atmosphere at the observer, for the purpose of calculating the refraction
+
+The pressure is in Pascals, the temperature is in Kelvin, the relative humidity is a fraction between zero and one,
+and the wavelength is in micrometres, where wavelengths longer than 100 micrometres are treated as radio.
+*/
+type Atmosphere struct {
+	Pressure         float64
+	Temperature      float64
+	RelativeHumidity float64
+	Wavelength       float64
+}
+
+/*****************************************************************************************************************/
+
+// the standard atmosphere at sea level, i.e., 1013.25 hPa and 10°C, dry, in the visual (V band):
+var StandardAtmosphere = Atmosphere{
+	Pressure:         101325,
+	Temperature:      283.15,
+	RelativeHumidity: 0,
+	Wavelength:       0.55,
+}
+
+/*****************************************************************************************************************/
+
+/*
+a model of the atmospheric refraction, which returns the refraction in degrees for a target at a given true
+(geometric) altitude, in degrees, and a given atmosphere
+
+The refraction is the amount by which the observed (apparent) altitude of the target exceeds its true altitude.
+*/
+type Model func(target common.HorizontalCoordinate, atmosphere Atmosphere) float64
+
+/*****************************************************************************************************************/
+
+/*
+the correction to a refraction formula for a standard atmosphere of 1013.25 hPa and 10°C, for the pressure and
+temperature of a given atmosphere
+*/
+func getPressureTemperatureCorrection(atmosphere Atmosphere) float64 {
+	return (atmosphere.Pressure / 101325) * (283.15 / atmosphere.Temperature)
+}
+
+/*****************************************************************************************************************/
+
+/*
+the atmospheric refraction for a target at a given true altitude, in degrees, using Saemundsson's formula,
+corrected for pressure (in Pascals) and temperature (in Kelvin)
+
+See GetSaemundssonRefraction for details of the formula. The refraction is infinite for a target more than one
+degree below the horizon, i.e., below the minimum altitude at which refraction is modelled.
+*/
 func GetRefraction(
 	target common.HorizontalCoordinate,
 	pressure float64,
@@ -25,44 +88,203 @@ func GetRefraction(
 ) float64 {
 	alt := target.Altitude
 
-	if alt < 0 {
+	if alt < MINIMUM_ALTITUDE {
 		return math.Inf(1)
 	}
 
-	// The pressure, in Pascals:
-	P := pressure
+	return GetSaemundssonRefraction(target, Atmosphere{
+		Pressure:    pressure,
+		Temperature: temperature,
+		Wavelength:  StandardAtmosphere.Wavelength,
+	})
+}
+
+/*****************************************************************************************************************/
+
+/*
+the atmospheric refraction for a target at a given true altitude, in degrees, using Saemundsson's formula
+
+Saemundsson's formula (Sky & Telescope, 1986) is the inverse of Bennett's formula, and gives the refraction from
+the true altitude to within around 4 arcseconds of Bennett's formula, for the visual and a standard atmosphere.
+It is corrected for the pressure and temperature of the atmosphere, but not its humidity or the wavelength.
+*/
+func GetSaemundssonRefraction(target common.HorizontalCoordinate, atmosphere Atmosphere) float64 {
+	h := target.Altitude
+
+	// the refraction in arcminutes, for a standard atmosphere:
+	R := 1.02 / math.Tan(common.Radians(h+10.3/(h+5.11)))
+
+	// get the atmospheric refraction in degrees, corrected for temperature and pressure:
+	return R / 60 * getPressureTemperatureCorrection(atmosphere)
+}
+
+/*****************************************************************************************************************/
+
+/*
+the atmospheric refraction for a target at a given true altitude, in degrees, using Bennett's formula
+
+Bennett's formula (Journal of Navigation, 1982) gives the refraction from the apparent altitude to within 0.07
+arcminutes between the horizon and the zenith, for the visual and a standard atmosphere, and so is solved for
+the apparent altitude corresponding to the given true altitude by iteration. It is corrected for the pressure
+and temperature of the atmosphere, but not its humidity or the wavelength.
+*/
+func GetBennettRefraction(target common.HorizontalCoordinate, atmosphere Atmosphere) float64 {
+	h := target.Altitude
+
+	k := getPressureTemperatureCorrection(atmosphere)
+
+	// the refraction, in degrees, for a given apparent altitude:
+	refraction := func(h0 float64) float64 {
+		return 1 / math.Tan(common.Radians(h0+7.31/(h0+4.4))) / 60 * k
+	}
 
-	// The temperature, in Kelvin:
-	T := temperature
+	R := refraction(h)
 
-	// Get the atmospheric refraction in degrees, corrected for temperature and pressure:
-	R := (1.02 / math.Tan(common.Radians(alt+10.3/(alt+5.11))) / 60) * (P / 101325) * (283.15 / T)
+	// iterate towards the apparent altitude, i.e., the true altitude plus the refraction:
+	for i := 0; i < 10; i++ {
+		R = refraction(h + R)
+	}
 
 	return R
 }
 
 /*****************************************************************************************************************/
 
-func GetAirmass(target common.HorizontalCoordinate) float64 {
-	alt := target.Altitude
+/*
+the refraction constants A and B, in radians, for a given atmosphere, such that the refraction is given by
+A tan z + B tan³ z, where z is the observed zenith distance
 
-	if alt < 0 {
-		return math.Inf(1)
+The constants are computed following the SOFA routine iauRefco, which models the troposphere as a polytropic
+layer above the observer with a given pressure, temperature and relative humidity, and uses the refractivity of
+Barrell & Sears (1939) in the optical and infrared, and of Rüeger (2002) in the radio, in which the refractivity
+is dominated by the water vapour content of the air.
+*/
+func getRefractionConstants(atmosphere Atmosphere) (A float64, B float64) {
+	// the wavelength, where wavelengths longer than 100 micrometres are treated as radio:
+	λ := math.Min(math.Max(atmosphere.Wavelength, 0.1), 1e6)
+
+	optical := λ <= 100
+
+	// the temperature, in degrees Celsius:
+	t := math.Min(math.Max(atmosphere.Temperature-273.15, -150), 200)
+
+	// the pressure, in hectopascals:
+	p := math.Min(math.Max(atmosphere.Pressure/100, 0), 10000)
+
+	r := math.Min(math.Max(atmosphere.RelativeHumidity, 0), 1)
+
+	// the partial pressure of water vapour, in hectopascals:
+	pw := 0.0
+
+	if p > 0 {
+		// the saturation vapour pressure of water, in hectopascals:
+		ps := math.Pow(10, (0.7859+0.03477*t)/(1+0.00412*t)) * (1 + p*(4.5e-6+6e-10*t*t))
+
+		pw = r * ps / (1 - (1-r)*ps/p)
 	}
 
-	// Convert the altitude to radians:
-	z := common.Radians(target.Altitude)
+	T := t + 273.15
 
-	// Get the tangent of the altitude:
-	tanZ := math.Tan(z)
+	// the refractivity of the air at the observer, less unity:
+	var γ float64
 
-	// Airmass approaches infinity as the altitude approaches 0 degrees (horizon):
-	if tanZ == 0 {
-		return math.Inf(1)
+	if optical {
+		γ = ((77.53484e-6+(4.39108e-7+3.666e-9/(λ*λ))/(λ*λ))*p - 11.2684e-6*pw) / T
+	} else {
+		γ = (77.6890e-6*p - (6.3938e-6-0.375463/T)*pw) / T
+	}
+
+	// the ratio of the scale height of the atmosphere to the radius of the Earth:
+	β := 4.4474e-6 * T
+
+	if !optical {
+		β -= 0.0074 * pw * β
+	}
+
+	return γ * (1 - β), -γ * (β - γ/2)
+}
+
+/*****************************************************************************************************************/
+
+/*
+the atmospheric refraction for a target at a given true altitude, in degrees, using the A tan z + B tan³ z model
+with constants for the pressure, temperature, relative humidity and wavelength of the atmosphere
+
+The model follows the SOFA routines iauRefco and iauAtioq, and is accurate to around 1 arcsecond above an altitude
+of 15 degrees, and to around 10 arcseconds at an altitude of 5 degrees, for optical, infrared and radio
+wavelengths. It becomes unreliable close to the horizon, where the refraction is held at its value for an
+altitude of around 3 degrees, as in iauAtioq.
+*/
+func GetRigorousRefraction(target common.HorizontalCoordinate, atmosphere Atmosphere) float64 {
+	A, B := getRefractionConstants(atmosphere)
+
+	h := common.Radians(target.Altitude)
+
+	// the sine and cosine of the true altitude, with the sine held above 0.05 (around 2.9 degrees):
+	z := math.Max(math.Sin(h), 0.05)
+
+	r := math.Max(math.Cos(h), 1e-6)
+
+	tz := r / z
+
+	w := B * tz * tz
+
+	// the refraction, with a Newton-Raphson step from the true towards the observed zenith distance:
+	return common.Degrees((A + w) * tz / (1 + (A+3*w)/(z*z)))
+}
+
+/*****************************************************************************************************************/
+
+/*
+the refraction for a target at a given true altitude, in degrees, for a given atmosphere and model, where the
+refraction below the minimum altitude is held at its value at the minimum altitude
+*/
+func getRefraction(altitude float64, atmosphere Atmosphere, model Model) float64 {
+	return model(common.HorizontalCoordinate{
+		Altitude: math.Max(altitude, MINIMUM_ALTITUDE),
+	}, atmosphere)
+}
+
+/*****************************************************************************************************************/
+
+/*
+the observed (apparent) altitude of a target for a given true (geometric) altitude, in degrees, for a given
+atmosphere and model of the refraction
+
+The observed altitude is the true altitude plus the refraction. Below the minimum altitude of one degree below
+the horizon, the refraction is held at its value at the minimum altitude.
+*/
+func GetObservedAltitude(target common.HorizontalCoordinate, atmosphere Atmosphere, model Model) float64 {
+	return target.Altitude + getRefraction(target.Altitude, atmosphere, model)
+}
+
+/*****************************************************************************************************************/
+
+/*
+the true (geometric) altitude of a target for a given observed (apparent) altitude, in degrees, for a given
+atmosphere and model of the refraction, e.g., as reported by the encoders of a telescope mount
+
+The true altitude is found by iteration, such that the observed altitude of the returned true altitude, as given
+by GetObservedAltitude, is equal to the given observed altitude to within 1e-9 degrees, i.e., the refraction is
+removed symmetrically to its application.
+*/
+func GetTrueAltitude(target common.HorizontalCoordinate, atmosphere Atmosphere, model Model) float64 {
+	h0 := target.Altitude
+
+	h := h0 - getRefraction(h0, atmosphere, model)
+
+	// the refraction changes by at most a fifth of the change in altitude, so the iteration converges rapidly:
+	for i := 0; i < 100; i++ {
+		next := h0 - getRefraction(h, atmosphere, model)
+
+		if math.Abs(next-h) < 1e-10 {
+			return next
+		}
+
+		h = next
 	}
 
-	// Get the airmass using the Ciddor (1996) equation of state for air:
-	return 1 / (math.Sin(z) + 0.0001184*(1/tanZ) + 0.003188*(1/tanZ*tanZ))
+	return h
 }
 
 /*****************************************************************************************************************/
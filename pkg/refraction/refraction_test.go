@@ -132,48 +132,198 @@ func TestRefractionAtTargetWithPressureAndTemperature(t *testing.T) {
 
 /*****************************************************************************************************************/
 
-func TestAirmassAtTargetBelowHorizon(t *testing.T) {
-	// Test the airmass at a target below the horizon:
-	Z := GetAirmass(common.HorizontalCoordinate{
-		Altitude: -10.0,
+func TestSaemundssonRefractionMatchesGetRefraction(t *testing.T) {
+	// Test that the refraction is unchanged from that of GetRefraction for a standard atmosphere:
+	R := GetSaemundssonRefraction(target, StandardAtmosphere)
+
+	if math.Abs(R-GetRefraction(target, 101325, 283.15)) > 1e-12 {
+		t.Errorf("Expected refraction to be %f, got %f", GetRefraction(target, 101325, 283.15), R)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestBennettRefractionMatchesSaemundssonRefraction(t *testing.T) {
+	// Test that Bennett's and Saemundsson's formulae agree to within 4 arcseconds above 5 degrees:
+	for alt := 5.0; alt <= 90; alt += 5 {
+		target := common.HorizontalCoordinate{Altitude: alt, Azimuth: 180.0}
+
+		B := GetBennettRefraction(target, StandardAtmosphere)
+
+		S := GetSaemundssonRefraction(target, StandardAtmosphere)
+
+		if math.Abs(B-S)*3600 > 4 {
+			t.Errorf("Expected refraction at %f degrees to agree, got %f and %f", alt, B*3600, S*3600)
+		}
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestBennettRefractionAtHorizon(t *testing.T) {
+	// Test the refraction at the horizon, which is around 29 arcminutes for a true altitude of zero:
+	R := GetBennettRefraction(common.HorizontalCoordinate{
+		Altitude: 0.0,
 		Azimuth:  180.0,
+	}, StandardAtmosphere)
+
+	if math.Abs(R*60-28.9) > 0.2 {
+		t.Errorf("Expected refraction to be 28.9 arcminutes, got %f", R*60)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestRigorousRefraction(t *testing.T) {
+	// Test the refraction at 45 degrees, which is the constant A of around 58.2 arcseconds at 10°C:
+	R := GetRigorousRefraction(common.HorizontalCoordinate{
+		Altitude: 45.0,
+		Azimuth:  180.0,
+	}, StandardAtmosphere)
+
+	if math.Abs(R*3600-58.2) > 0.3 {
+		t.Errorf("Expected refraction to be 58.2 arcseconds, got %f", R*3600)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestRigorousRefractionWithWavelength(t *testing.T) {
+	optical := GetRigorousRefraction(target, StandardAtmosphere)
+
+	// Test that the refraction in the near infrared (K band) is less than that in the visual:
+	infrared := GetRigorousRefraction(target, Atmosphere{
+		Pressure:    101325,
+		Temperature: 283.15,
+		Wavelength:  2.2,
 	})
 
-	if Z != math.Inf(1) {
-		t.Errorf("Expected airmass to be infinite, got %f", Z)
+	if infrared >= optical {
+		t.Errorf("Expected infrared refraction to be less than %f, got %f", optical, infrared)
+	}
+
+	// Test that the refraction in the radio, for humid air, is around 13% greater than that in the visual:
+	radio := GetRigorousRefraction(target, Atmosphere{
+		Pressure:         101325,
+		Temperature:      293.15,
+		RelativeHumidity: 0.5,
+		Wavelength:       1e4,
+	})
+
+	if radio/optical < 1.1 || radio/optical > 1.2 {
+		t.Errorf("Expected radio refraction to be around 1.13 times %f, got %f", optical, radio)
 	}
 }
 
 /*****************************************************************************************************************/
 
-func TestAirmassAtTargetAboveHorizon(t *testing.T) {
-	// Test the airmass at a target above the horizon:
-	Z := GetAirmass(target)
+func TestRigorousRefractionWithHumidity(t *testing.T) {
+	dry := GetRigorousRefraction(target, StandardAtmosphere)
 
-	if Z == math.Inf(1) {
-		t.Errorf("Expected airmass to be finite, got %f", Z)
+	humid := GetRigorousRefraction(target, Atmosphere{
+		Pressure:         101325,
+		Temperature:      283.15,
+		RelativeHumidity: 1,
+		Wavelength:       0.55,
+	})
+
+	// Test that water vapour slightly reduces the refraction in the optical:
+	if humid >= dry || dry-humid > 0.5/3600 {
+		t.Errorf("Expected humid refraction to be slightly less than %f, got %f", dry, humid)
 	}
+}
+
+/*****************************************************************************************************************/
 
-	if Z <= 0 {
-		t.Errorf("Expected airmass to be positive, got %f", Z)
+func TestModels(t *testing.T) {
+	models := []Model{GetBennettRefraction, GetSaemundssonRefraction, GetRigorousRefraction}
+
+	for _, model := range models {
+		R := model(target, StandardAtmosphere)
+
+		if R <= 0 || R*3600 > 60 {
+			t.Errorf("Expected refraction to be positive and less than 60 arcseconds, got %f", R*3600)
+		}
 	}
+}
+
+/*****************************************************************************************************************/
 
-	if Z > 1.5 {
-		t.Errorf("Expected airmass to be less than 1.5, got %f", Z)
+func TestRefractionAtTargetSlightlyBelowHorizon(t *testing.T) {
+	// Test the refraction at a target slightly below the horizon, which may still be observed above it:
+	R := GetRefraction(common.HorizontalCoordinate{
+		Altitude: -0.5,
+		Azimuth:  180.0,
+	}, 101325, 283.15)
+
+	if R == math.Inf(1) {
+		t.Errorf("Expected refraction to be finite, got %f", R)
+	}
+
+	if R <= 0.5 || R > 1 {
+		t.Errorf("Expected refraction to be between 0.5 and 1, got %f", R)
 	}
 }
 
 /*****************************************************************************************************************/
 
-func TestAirmassAtTargetAtHorizon(t *testing.T) {
-	// Test the airmass at a target below the horizon:
-	Z := GetAirmass(common.HorizontalCoordinate{
+func TestGetObservedAltitude(t *testing.T) {
+	// Test that a target on the true horizon is observed at around 29 arcminutes above it:
+	h := GetObservedAltitude(common.HorizontalCoordinate{
 		Altitude: 0.0,
 		Azimuth:  180.0,
-	})
+	}, StandardAtmosphere, GetSaemundssonRefraction)
+
+	if math.Abs(h-0.4831) > 0.001 {
+		t.Errorf("Expected observed altitude to be 0.4831, got %f", h)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetTrueAltitude(t *testing.T) {
+	// Test that a target observed on the horizon is around 35 arcminutes below it, i.e., Bennett's value:
+	h := GetTrueAltitude(common.HorizontalCoordinate{
+		Altitude: 0.0,
+		Azimuth:  180.0,
+	}, StandardAtmosphere, GetBennettRefraction)
+
+	if math.Abs(h+34.5/60) > 0.01 {
+		t.Errorf("Expected true altitude to be %f, got %f", -34.5/60, h)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetTrueAltitudeIsInverseOfGetObservedAltitude(t *testing.T) {
+	models := []Model{GetBennettRefraction, GetSaemundssonRefraction, GetRigorousRefraction}
+
+	for _, model := range models {
+		for alt := -3.0; alt <= 90; alt += 0.25 {
+			target := common.HorizontalCoordinate{Altitude: alt, Azimuth: 180.0}
+
+			observed := GetObservedAltitude(target, StandardAtmosphere, model)
+
+			h := GetTrueAltitude(common.HorizontalCoordinate{Altitude: observed, Azimuth: 180.0}, StandardAtmosphere, model)
+
+			if math.Abs(h-alt) > 1e-8 {
+				t.Errorf("Expected true altitude to be %f, got %f", alt, h)
+			}
+		}
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetObservedAltitudeBelowMinimumAltitude(t *testing.T) {
+	// Test that the refraction is held at its value at the minimum altitude below it:
+	R := GetObservedAltitude(common.HorizontalCoordinate{Altitude: MINIMUM_ALTITUDE}, StandardAtmosphere, GetSaemundssonRefraction) -
+		MINIMUM_ALTITUDE
+
+	h := GetObservedAltitude(common.HorizontalCoordinate{Altitude: -5}, StandardAtmosphere, GetSaemundssonRefraction)
 
-	if Z != math.Inf(1) {
-		t.Errorf("Expected airmass to be infinite, got %f", Z)
+	if math.Abs(h-(-5+R)) > 1e-12 {
+		t.Errorf("Expected observed altitude to be %f, got %f", -5+R, h)
 	}
 }
 
@@ -0,0 +1,605 @@
+/*****************************************************************************************************************/
+
+//	@author		Michael Roberts <michael@observerly.com>
+//	@package	@observerly/sidera
+//	@license	Copyright © 2021-2024 observerly
+
+/*****************************************************************************************************************/
+
+package satellite
+
+/*****************************************************************************************************************/
+
+import (
+	"math"
+)
+
+/*****************************************************************************************************************/
+
+const (
+	// the solar and lunar eccentricities, and mean motions in radians per minute:
+	zes float64 = 0.01675
+	zel float64 = 0.05490
+	zns float64 = 1.19459e-5
+	znl float64 = 1.5835218e-4
+	// the rotation rate of the Earth, in radians per minute:
+	rptim float64 = 4.37526908801129966e-3
+)
+
+/*****************************************************************************************************************/
+
+/*
+the coefficients of the deep-space (SDP4) model: the lunar-solar periodic terms, the secular rates, and the
+geopotential resonance terms for 12 hour (irez = 2) and 24 hour (irez = 1) orbits
+*/
+type deepSpace struct {
+	gsto                                                                 float64
+	e3, ee2                                                              float64
+	se2, se3, sgh2, sgh3, sgh4, sh2, sh3, si2, si3, sl2, sl3, sl4        float64
+	xgh2, xgh3, xgh4, xh2, xh3, xi2, xi3, xl2, xl3, xl4, zmol, zmos      float64
+	dedt, didt, dmdt, dnodt, domdt                                       float64
+	irez                                                                 int
+	d2201, d2211, d3210, d3222, d4410, d4422, d5220, d5232, d5421, d5433 float64
+	del1, del2, del3, xfact, xlamo                                       float64
+}
+
+/*****************************************************************************************************************/
+
+/*
+the lunar and solar terms computed by the deep-space common routine (dscom) at the epoch of the element set
+*/
+type lunisolarTerms struct {
+	sinim, cosim, emsq                           float64
+	s1, s2, s3, s4, s5                           float64
+	ss1, ss2, ss3, ss4, ss5                      float64
+	z1, z3, z11, z13, z21, z23, z31, z33         float64
+	sz1, sz3, sz11, sz13, sz21, sz23, sz31, sz33 float64
+}
+
+/*****************************************************************************************************************/
+
+/*
+initialises the deep-space model for a satellite, computing the lunar-solar terms (dscom) and the secular
+and resonance terms (dsinit) at the epoch of the element set
+*/
+func newDeepSpace(s *Satellite, eccsq float64, xpidot float64) *deepSpace {
+	d := &deepSpace{
+		gsto: getGreenwichMeanSiderealTime(s.jdsatepoch),
+	}
+
+	terms := d.initialiseLunisolarTerms(s.jdsatepoch-2433281.5, s.ecco, s.argpo, s.inclo, s.nodeo, s.no)
+
+	d.initialiseResonance(s, terms, eccsq, xpidot)
+
+	return d
+}
+
+/*****************************************************************************************************************/
+
+/*
+the deep-space common routine (dscom), which computes the lunar and solar perturbation coefficients for an
+epoch given in days since 1950 January 0.0
+*/
+func (d *deepSpace) initialiseLunisolarTerms(epoch, ep, argpp, inclp, nodep, np float64) lunisolarTerms {
+	const (
+		c1ss   = 2.9864797e-6
+		c1l    = 4.7968065e-7
+		zsinis = 0.39785416
+		zcosis = 0.91744867
+		zcosgs = 0.1945905
+		zsings = -0.98088458
+	)
+
+	var terms lunisolarTerms
+
+	nm := np
+	em := ep
+	snodm := math.Sin(nodep)
+	cnodm := math.Cos(nodep)
+	sinomm := math.Sin(argpp)
+	cosomm := math.Cos(argpp)
+	sinim := math.Sin(inclp)
+	cosim := math.Cos(inclp)
+	emsq := em * em
+	betasq := 1 - emsq
+	rtemsq := math.Sqrt(betasq)
+
+	// initialise the lunar and solar terms:
+	day := epoch + 18261.5
+	xnodce := math.Mod(4.5236020-9.2422029e-4*day, 2*math.Pi)
+	stem := math.Sin(xnodce)
+	ctem := math.Cos(xnodce)
+	zcosil := 0.91375164 - 0.03568096*ctem
+	zsinil := math.Sqrt(1 - zcosil*zcosil)
+	zsinhl := 0.089683511 * stem / zsinil
+	zcoshl := math.Sqrt(1 - zsinhl*zsinhl)
+	gam := 5.8351514 + 0.0019443680*day
+	zx := 0.39785416 * stem / zsinil
+	zy := zcoshl*ctem + 0.91744867*zsinhl*stem
+	zx = math.Atan2(zx, zy)
+	zx = gam + zx - xnodce
+	zcosgl := math.Cos(zx)
+	zsingl := math.Sin(zx)
+
+	// the solar terms are computed first, followed by the lunar terms:
+	zcosg := zcosgs
+	zsing := zsings
+	zcosi := zcosis
+	zsini := zsinis
+	zcosh := cnodm
+	zsinh := snodm
+	cc := c1ss
+	xnoi := 1 / nm
+
+	var s1, s2, s3, s4, s5, s6, s7 float64
+	var z1, z2, z3, z11, z12, z13, z21, z22, z23, z31, z32, z33 float64
+	var ss1, ss2, ss3, ss4, ss5, ss6, ss7 float64
+	var sz1, sz2, sz3, sz11, sz12, sz13, sz21, sz22, sz23, sz31, sz32, sz33 float64
+
+	for lsflg := 1; lsflg <= 2; lsflg++ {
+		a1 := zcosg*zcosh + zsing*zcosi*zsinh
+		a3 := -zsing*zcosh + zcosg*zcosi*zsinh
+		a7 := -zcosg*zsinh + zsing*zcosi*zcosh
+		a8 := zsing * zsini
+		a9 := zsing*zsinh + zcosg*zcosi*zcosh
+		a10 := zcosg * zsini
+		a2 := cosim*a7 + sinim*a8
+		a4 := cosim*a9 + sinim*a10
+		a5 := -sinim*a7 + cosim*a8
+		a6 := -sinim*a9 + cosim*a10
+
+		x1 := a1*cosomm + a2*sinomm
+		x2 := a3*cosomm + a4*sinomm
+		x3 := -a1*sinomm + a2*cosomm
+		x4 := -a3*sinomm + a4*cosomm
+		x5 := a5 * sinomm
+		x6 := a6 * sinomm
+		x7 := a5 * cosomm
+		x8 := a6 * cosomm
+
+		z31 = 12*x1*x1 - 3*x3*x3
+		z32 = 24*x1*x2 - 6*x3*x4
+		z33 = 12*x2*x2 - 3*x4*x4
+		z1 = 3*(a1*a1+a2*a2) + z31*emsq
+		z2 = 6*(a1*a3+a2*a4) + z32*emsq
+		z3 = 3*(a3*a3+a4*a4) + z33*emsq
+		z11 = -6*a1*a5 + emsq*(-24*x1*x7-6*x3*x5)
+		z12 = -6*(a1*a6+a3*a5) + emsq*(-24*(x2*x7+x1*x8)-6*(x3*x6+x4*x5))
+		z13 = -6*a3*a6 + emsq*(-24*x2*x8-6*x4*x6)
+		z21 = 6*a2*a5 + emsq*(24*x1*x5-6*x3*x7)
+		z22 = 6*(a4*a5+a2*a6) + emsq*(24*(x2*x5+x1*x6)-6*(x4*x7+x3*x8))
+		z23 = 6*a4*a6 + emsq*(24*x2*x6-6*x4*x8)
+		z1 = z1 + z1 + betasq*z31
+		z2 = z2 + z2 + betasq*z32
+		z3 = z3 + z3 + betasq*z33
+		s3 = cc * xnoi
+		s2 = -0.5 * s3 / rtemsq
+		s4 = s3 * rtemsq
+		s1 = -15 * em * s4
+		s5 = x1*x3 + x2*x4
+		s6 = x2*x3 + x1*x4
+		s7 = x2*x4 - x1*x3
+
+		// store the solar terms, and set up the lunar terms for the second pass:
+		if lsflg == 1 {
+			ss1, ss2, ss3, ss4, ss5, ss6, ss7 = s1, s2, s3, s4, s5, s6, s7
+			sz1, sz2, sz3 = z1, z2, z3
+			sz11, sz12, sz13 = z11, z12, z13
+			sz21, sz22, sz23 = z21, z22, z23
+			sz31, sz32, sz33 = z31, z32, z33
+			zcosg = zcosgl
+			zsing = zsingl
+			zcosi = zcosil
+			zsini = zsinil
+			zcosh = zcoshl*cnodm + zsinhl*snodm
+			zsinh = snodm*zcoshl - cnodm*zsinhl
+			cc = c1l
+		}
+	}
+
+	d.zmol = math.Mod(4.7199672+0.22997150*day-gam, 2*math.Pi)
+	d.zmos = math.Mod(6.2565837+0.017201977*day, 2*math.Pi)
+
+	// the solar terms:
+	d.se2 = 2 * ss1 * ss6
+	d.se3 = 2 * ss1 * ss7
+	d.si2 = 2 * ss2 * sz12
+	d.si3 = 2 * ss2 * (sz13 - sz11)
+	d.sl2 = -2 * ss3 * sz2
+	d.sl3 = -2 * ss3 * (sz3 - sz1)
+	d.sl4 = -2 * ss3 * (-21 - 9*emsq) * zes
+	d.sgh2 = 2 * ss4 * sz32
+	d.sgh3 = 2 * ss4 * (sz33 - sz31)
+	d.sgh4 = -18 * ss4 * zes
+	d.sh2 = -2 * ss2 * sz22
+	d.sh3 = -2 * ss2 * (sz23 - sz21)
+
+	// the lunar terms:
+	d.ee2 = 2 * s1 * s6
+	d.e3 = 2 * s1 * s7
+	d.xi2 = 2 * s2 * z12
+	d.xi3 = 2 * s2 * (z13 - z11)
+	d.xl2 = -2 * s3 * z2
+	d.xl3 = -2 * s3 * (z3 - z1)
+	d.xl4 = -2 * s3 * (-21 - 9*emsq) * zel
+	d.xgh2 = 2 * s4 * z32
+	d.xgh3 = 2 * s4 * (z33 - z31)
+	d.xgh4 = -18 * s4 * zel
+	d.xh2 = -2 * s2 * z22
+	d.xh3 = -2 * s2 * (z23 - z21)
+
+	terms = lunisolarTerms{
+		sinim: sinim, cosim: cosim, emsq: emsq,
+		s1: s1, s2: s2, s3: s3, s4: s4, s5: s5,
+		ss1: ss1, ss2: ss2, ss3: ss3, ss4: ss4, ss5: ss5,
+		z1: z1, z3: z3, z11: z11, z13: z13, z21: z21, z23: z23, z31: z31, z33: z33,
+		sz1: sz1, sz3: sz3, sz11: sz11, sz13: sz13, sz21: sz21, sz23: sz23, sz31: sz31, sz33: sz33,
+	}
+
+	return terms
+}
+
+/*****************************************************************************************************************/
+
+/*
+the deep-space initialisation routine (dsinit), which computes the secular rates due to the Moon and Sun, and
+the coefficients of the geopotential resonance terms for 12 hour and 24 hour orbits
+*/
+func (d *deepSpace) initialiseResonance(s *Satellite, terms lunisolarTerms, eccsq float64, xpidot float64) {
+	const (
+		q22    = 1.7891679e-6
+		q31    = 2.1460748e-6
+		q33    = 2.2123015e-7
+		root22 = 1.7891679e-6
+		root44 = 7.3636953e-9
+		root54 = 2.1765803e-9
+		root32 = 3.7393792e-7
+		root52 = 1.1428639e-7
+	)
+
+	nm := s.no
+	em := s.ecco
+	inclm := s.inclo
+	sinim := terms.sinim
+	cosim := terms.cosim
+	emsq := terms.emsq
+
+	// the resonance: 24 hour (geosynchronous) orbits, and 12 hour orbits of high eccentricity:
+	if nm < 0.0052359877 && nm > 0.0034906585 {
+		d.irez = 1
+	}
+
+	if nm >= 8.26e-3 && nm <= 9.24e-3 && em >= 0.5 {
+		d.irez = 2
+	}
+
+	// the solar terms:
+	ses := terms.ss1 * zns * terms.ss5
+	sis := terms.ss2 * zns * (terms.sz11 + terms.sz13)
+	sls := -zns * terms.ss3 * (terms.sz1 + terms.sz3 - 14 - 6*emsq)
+	sghs := terms.ss4 * zns * (terms.sz31 + terms.sz33 - 6)
+	shs := -zns * terms.ss2 * (terms.sz21 + terms.sz23)
+
+	// avoid the singularity for inclinations near 0 and 180 degrees:
+	if inclm < 5.2359877e-2 || inclm > math.Pi-5.2359877e-2 {
+		shs = 0
+	}
+
+	if sinim != 0 {
+		shs = shs / sinim
+	}
+
+	sgs := sghs - cosim*shs
+
+	// the lunar terms:
+	d.dedt = ses + terms.s1*znl*terms.s5
+	d.didt = sis + terms.s2*znl*(terms.z11+terms.z13)
+	d.dmdt = sls - znl*terms.s3*(terms.z1+terms.z3-14-6*emsq)
+	sghl := terms.s4 * znl * (terms.z31 + terms.z33 - 6)
+	shll := -znl * terms.s2 * (terms.z21 + terms.z23)
+
+	if inclm < 5.2359877e-2 || inclm > math.Pi-5.2359877e-2 {
+		shll = 0
+	}
+
+	d.domdt = sgs + sghl
+	d.dnodt = shs
+
+	if sinim != 0 {
+		d.domdt = d.domdt - cosim/sinim*shll
+		d.dnodt = d.dnodt + shll/sinim
+	}
+
+	if d.irez == 0 {
+		return
+	}
+
+	theta := math.Mod(d.gsto, 2*math.Pi)
+
+	aonv := math.Pow(nm/xke, 2.0/3)
+
+	// the geopotential resonance for 12 hour orbits:
+	if d.irez == 2 {
+		cosisq := cosim * cosim
+		em := s.ecco
+		emsq := eccsq
+		eoc := em * emsq
+		g201 := -0.306 - (em-0.64)*0.440
+
+		var g211, g310, g322, g410, g422, g520, g521, g532, g533 float64
+
+		if em <= 0.65 {
+			g211 = 3.616 - 13.2470*em + 16.2900*emsq
+			g310 = -19.302 + 117.3900*em - 228.4190*emsq + 156.5910*eoc
+			g322 = -18.9068 + 109.7927*em - 214.6334*emsq + 146.5816*eoc
+			g410 = -41.122 + 242.6940*em - 471.0940*emsq + 313.9530*eoc
+			g422 = -146.407 + 841.8800*em - 1629.014*emsq + 1083.4350*eoc
+			g520 = -532.114 + 3017.977*em - 5740.032*emsq + 3708.2760*eoc
+		} else {
+			g211 = -72.099 + 331.819*em - 508.738*emsq + 266.724*eoc
+			g310 = -346.844 + 1582.851*em - 2415.925*emsq + 1246.113*eoc
+			g322 = -342.585 + 1554.908*em - 2366.899*emsq + 1215.972*eoc
+			g410 = -1052.797 + 4758.686*em - 7193.992*emsq + 3651.957*eoc
+			g422 = -3581.690 + 16178.110*em - 24462.770*emsq + 12422.520*eoc
+
+			if em > 0.715 {
+				g520 = -5149.66 + 29936.92*em - 54087.36*emsq + 31324.56*eoc
+			} else {
+				g520 = 1464.74 - 4664.75*em + 3763.64*emsq
+			}
+		}
+
+		if em < 0.7 {
+			g533 = -919.22770 + 4988.6100*em - 9064.7700*emsq + 5542.21*eoc
+			g521 = -822.71072 + 4568.6173*em - 8491.4146*emsq + 5337.524*eoc
+			g532 = -853.66600 + 4690.2500*em - 8624.7700*emsq + 5341.4*eoc
+		} else {
+			g533 = -37995.780 + 161616.52*em - 229838.20*emsq + 109377.94*eoc
+			g521 = -51752.104 + 218913.95*em - 309468.16*emsq + 146349.42*eoc
+			g532 = -40023.880 + 170470.89*em - 242699.48*emsq + 115605.82*eoc
+		}
+
+		sini2 := sinim * sinim
+		f220 := 0.75 * (1 + 2*cosim + cosisq)
+		f221 := 1.5 * sini2
+		f321 := 1.875 * sinim * (1 - 2*cosim - 3*cosisq)
+		f322 := -1.875 * sinim * (1 + 2*cosim - 3*cosisq)
+		f441 := 35 * sini2 * f220
+		f442 := 39.3750 * sini2 * sini2
+		f522 := 9.84375 * sinim * (sini2*(1-2*cosim-5*cosisq) + 0.33333333*(-2+4*cosim+6*cosisq))
+		f523 := sinim * (4.92187512*sini2*(-2-4*cosim+10*cosisq) + 6.56250012*(1+2*cosim-3*cosisq))
+		f542 := 29.53125 * sinim * (2 - 8*cosim + cosisq*(-12+8*cosim+10*cosisq))
+		f543 := 29.53125 * sinim * (-2 - 8*cosim + cosisq*(12+8*cosim-10*cosisq))
+
+		xno2 := nm * nm
+		ainv2 := aonv * aonv
+		temp1 := 3 * xno2 * ainv2
+		temp := temp1 * root22
+		d.d2201 = temp * f220 * g201
+		d.d2211 = temp * f221 * g211
+		temp1 = temp1 * aonv
+		temp = temp1 * root32
+		d.d3210 = temp * f321 * g310
+		d.d3222 = temp * f322 * g322
+		temp1 = temp1 * aonv
+		temp = 2 * temp1 * root44
+		d.d4410 = temp * f441 * g410
+		d.d4422 = temp * f442 * g422
+		temp1 = temp1 * aonv
+		temp = temp1 * root52
+		d.d5220 = temp * f522 * g520
+		d.d5232 = temp * f523 * g532
+		temp = 2 * temp1 * root54
+		d.d5421 = temp * f542 * g521
+		d.d5433 = temp * f543 * g533
+		d.xlamo = math.Mod(s.mo+s.nodeo+s.nodeo-theta-theta, 2*math.Pi)
+		d.xfact = s.mdot + d.dmdt + 2*(s.nodedot+d.dnodt-rptim) - s.no
+	}
+
+	// the synchronous resonance terms for 24 hour orbits:
+	if d.irez == 1 {
+		g200 := 1 + emsq*(-2.5+0.8125*emsq)
+		g310 := 1 + 2*emsq
+		g300 := 1 + emsq*(-6+6.60937*emsq)
+		f220 := 0.75 * (1 + cosim) * (1 + cosim)
+		f311 := 0.9375*sinim*sinim*(1+3*cosim) - 0.75*(1+cosim)
+		f330 := 1.875 * math.Pow(1+cosim, 3)
+		d.del1 = 3 * nm * nm * aonv * aonv
+		d.del2 = 2 * d.del1 * f220 * g200 * q22
+		d.del3 = 3 * d.del1 * f330 * g300 * q33 * aonv
+		d.del1 = d.del1 * f311 * g310 * q31 * aonv
+		d.xlamo = math.Mod(s.mo+s.nodeo+s.argpo-theta, 2*math.Pi)
+		d.xfact = s.mdot + xpidot - rptim + d.dmdt + d.domdt + d.dnodt - s.no
+	}
+}
+
+/*****************************************************************************************************************/
+
+/*
+the deep-space secular effects (dspace), including the numerical integration of the resonance terms from the
+epoch, returning the updated mean eccentricity, argument of perigee, inclination, mean anomaly, longitude of
+the ascending node and mean motion
+*/
+func (d *deepSpace) getSecularEffects(
+	s *Satellite,
+	t float64,
+	em, argpm, inclm, mm, nodem float64,
+) (float64, float64, float64, float64, float64, float64) {
+	const (
+		fasx2 = 0.13130908
+		fasx4 = 2.8843198
+		fasx6 = 0.37448087
+		g22   = 5.7686396
+		g32   = 0.95240898
+		g44   = 1.8014998
+		g52   = 1.0508330
+		g54   = 4.4108898
+		stepp = 720.0
+		stepn = -720.0
+		step2 = 259200.0
+	)
+
+	theta := math.Mod(d.gsto+t*rptim, 2*math.Pi)
+
+	em = em + d.dedt*t
+	inclm = inclm + d.didt*t
+	argpm = argpm + d.domdt*t
+	nodem = nodem + d.dnodt*t
+	mm = mm + d.dmdt*t
+
+	nm := s.no
+
+	if d.irez == 0 {
+		return em, argpm, inclm, mm, nodem, nm
+	}
+
+	// integrate the resonance terms from the epoch by the Euler-Maclaurin method, in steps of 720 minutes:
+	atime := 0.0
+	xni := s.no
+	xli := d.xlamo
+
+	delt := stepp
+
+	if t < 0 {
+		delt = stepn
+	}
+
+	var xndt, xldot, xnddt, ft float64
+
+	for {
+		if d.irez != 2 {
+			// the near-synchronous resonance terms:
+			xndt = d.del1*math.Sin(xli-fasx2) + d.del2*math.Sin(2*(xli-fasx4)) + d.del3*math.Sin(3*(xli-fasx6))
+			xldot = xni + d.xfact
+			xnddt = d.del1*math.Cos(xli-fasx2) + 2*d.del2*math.Cos(2*(xli-fasx4)) + 3*d.del3*math.Cos(3*(xli-fasx6))
+			xnddt = xnddt * xldot
+		} else {
+			// the near-half-day resonance terms:
+			xomi := s.argpo + s.argpdot*atime
+			x2omi := xomi + xomi
+			x2li := xli + xli
+			xndt = d.d2201*math.Sin(x2omi+xli-g22) + d.d2211*math.Sin(xli-g22) +
+				d.d3210*math.Sin(xomi+xli-g32) + d.d3222*math.Sin(-xomi+xli-g32) +
+				d.d4410*math.Sin(x2omi+x2li-g44) + d.d4422*math.Sin(x2li-g44) +
+				d.d5220*math.Sin(xomi+xli-g52) + d.d5232*math.Sin(-xomi+xli-g52) +
+				d.d5421*math.Sin(xomi+x2li-g54) + d.d5433*math.Sin(-xomi+x2li-g54)
+			xldot = xni + d.xfact
+			xnddt = d.d2201*math.Cos(x2omi+xli-g22) + d.d2211*math.Cos(xli-g22) +
+				d.d3210*math.Cos(xomi+xli-g32) + d.d3222*math.Cos(-xomi+xli-g32) +
+				d.d5220*math.Cos(xomi+xli-g52) + d.d5232*math.Cos(-xomi+xli-g52) +
+				2*(d.d4410*math.Cos(x2omi+x2li-g44)+d.d4422*math.Cos(x2li-g44)+
+					d.d5421*math.Cos(xomi+x2li-g54)+d.d5433*math.Cos(-xomi+x2li-g54))
+			xnddt = xnddt * xldot
+		}
+
+		if math.Abs(t-atime) < stepp {
+			ft = t - atime
+			break
+		}
+
+		xli = xli + xldot*delt + xndt*step2
+		xni = xni + xndt*delt + xnddt*step2
+		atime = atime + delt
+	}
+
+	nm = xni + xndt*ft + xnddt*ft*ft*0.5
+	xl := xli + xldot*ft + xndt*ft*ft*0.5
+
+	if d.irez != 1 {
+		mm = xl - 2*nodem + 2*theta
+	} else {
+		mm = xl - nodem - argpm + theta
+	}
+
+	return em, argpm, inclm, mm, nodem, nm
+}
+
+/*****************************************************************************************************************/
+
+/*
+the deep-space lunar-solar periodic effects (dpper), returning the perturbed eccentricity, inclination,
+longitude of the ascending node, argument of perigee and mean anomaly
+*/
+func (d *deepSpace) getPeriodicEffects(
+	t float64,
+	ep, inclp, nodep, argpp, mp float64,
+) (float64, float64, float64, float64, float64) {
+	// the solar periodics:
+	zm := d.zmos + zns*t
+	zf := zm + 2*zes*math.Sin(zm)
+	sinzf := math.Sin(zf)
+	f2 := 0.5*sinzf*sinzf - 0.25
+	f3 := -0.5 * sinzf * math.Cos(zf)
+	ses := d.se2*f2 + d.se3*f3
+	sis := d.si2*f2 + d.si3*f3
+	sls := d.sl2*f2 + d.sl3*f3 + d.sl4*sinzf
+	sghs := d.sgh2*f2 + d.sgh3*f3 + d.sgh4*sinzf
+	shs := d.sh2*f2 + d.sh3*f3
+
+	// the lunar periodics:
+	zm = d.zmol + znl*t
+	zf = zm + 2*zel*math.Sin(zm)
+	sinzf = math.Sin(zf)
+	f2 = 0.5*sinzf*sinzf - 0.25
+	f3 = -0.5 * sinzf * math.Cos(zf)
+	sel := d.ee2*f2 + d.e3*f3
+	sil := d.xi2*f2 + d.xi3*f3
+	sll := d.xl2*f2 + d.xl3*f3 + d.xl4*sinzf
+	sghl := d.xgh2*f2 + d.xgh3*f3 + d.xgh4*sinzf
+	shll := d.xh2*f2 + d.xh3*f3
+
+	pe := ses + sel
+	pinc := sis + sil
+	pl := sls + sll
+	pgh := sghs + sghl
+	ph := shs + shll
+
+	inclp = inclp + pinc
+	ep = ep + pe
+
+	sinip := math.Sin(inclp)
+	cosip := math.Cos(inclp)
+
+	// apply the periodics directly for inclinations above 0.2 radians:
+	if inclp >= 0.2 {
+		ph = ph / sinip
+		pgh = pgh - cosip*ph
+		argpp = argpp + pgh
+		nodep = nodep + ph
+		mp = mp + pl
+
+		return ep, inclp, nodep, argpp, mp
+	}
+
+	// otherwise, apply the periodics with the Lyddane modification:
+	sinop := math.Sin(nodep)
+	cosop := math.Cos(nodep)
+	alfdp := sinip * sinop
+	betdp := sinip * cosop
+	dalf := ph*cosop + pinc*cosip*sinop
+	dbet := -ph*sinop + pinc*cosip*cosop
+	alfdp = alfdp + dalf
+	betdp = betdp + dbet
+	nodep = math.Mod(nodep, 2*math.Pi)
+
+	xls := mp + argpp + cosip*nodep
+	dls := pl + pgh - pinc*nodep*sinip
+	xls = math.Mod(xls+dls, 2*math.Pi)
+	xnoh := nodep
+	nodep = math.Atan2(alfdp, betdp)
+
+	if math.Abs(xnoh-nodep) > math.Pi {
+		if nodep < xnoh {
+			nodep = nodep + 2*math.Pi
+		} else {
+			nodep = nodep - 2*math.Pi
+		}
+	}
+
+	mp = mp + pl
+	argpp = xls - mp - cosip*nodep
+
+	return ep, inclp, nodep, argpp, mp
+}
+
+/*****************************************************************************************************************/
@@ -0,0 +1,361 @@
+/*****************************************************************************************************************/
+
+//	@author		Michael Roberts <michael@observerly.com>
+//	@package	@observerly/sidera
+//	@license	Copyright © 2021-2024 observerly
+
+/*****************************************************************************************************************/
+
+package satellite
+
+/*****************************************************************************************************************/
+
+import (
+	"math"
+	"time"
+
+	"github.com/observerly/sidera/pkg/common"
+	"github.com/observerly/sidera/pkg/epoch"
+	"github.com/observerly/sidera/pkg/horizon"
+	sun "github.com/observerly/sidera/pkg/solar"
+)
+
+/*****************************************************************************************************************/
+
+/*
+a pass of a satellite over an observer, from its acquisition of signal (AOS), when it rises above the minimum
+elevation, through its maximum elevation, to its loss of signal (LOS), when it sets below the minimum elevation
+
+The satellite is sunlit if it is illuminated by the Sun at any point during the pass, i.e., if it may leave a
+trail in a long exposure taken from the night side of the Earth.
+*/
+type Pass struct {
+	AOS                  time.Time
+	AOSAzimuth           float64
+	MaximumElevationTime time.Time
+	MaximumElevation     float64
+	LOS                  time.Time
+	LOSAzimuth           float64
+	Sunlit               bool
+}
+
+/*****************************************************************************************************************/
+
+/*
+converts a position in the True Equator, Mean Equinox (TEME) frame to the Earth-fixed frame, by a rotation about
+the z-axis by the Greenwich Mean Sidereal Time (the small effect of polar motion is neglected)
+*/
+func convertTEMEToEarthFixedCoordinate(datetime time.Time, position common.CartesianCoordinate) common.CartesianCoordinate {
+	θ := common.Radians(epoch.GetGreenwichSiderealTime(datetime) * 15)
+
+	return common.CartesianCoordinate{
+		X: position.X*math.Cos(θ) + position.Y*math.Sin(θ),
+		Y: -position.X*math.Sin(θ) + position.Y*math.Cos(θ),
+		Z: position.Z,
+	}
+}
+
+/*****************************************************************************************************************/
+
+/*
+the Earth-fixed position of an observer on the WGS-84 ellipsoid, in kilometres, for a given geographic
+coordinate, with the elevation above the ellipsoid given in metres
+*/
+func getObserverEarthFixedCoordinate(observer common.GeographicCoordinate) common.CartesianCoordinate {
+	φ := common.Radians(observer.Latitude)
+
+	λ := common.Radians(observer.Longitude)
+
+	h := observer.Elevation / 1000
+
+	e2 := common.EARTH_FLATTENING * (2 - common.EARTH_FLATTENING)
+
+	// the radius of curvature in the prime vertical:
+	N := common.EARTH_EQUATORIAL_RADIUS / math.Sqrt(1-e2*math.Pow(math.Sin(φ), 2))
+
+	return common.CartesianCoordinate{
+		X: (N + h) * math.Cos(φ) * math.Cos(λ),
+		Y: (N + h) * math.Cos(φ) * math.Sin(λ),
+		Z: (N*(1-e2) + h) * math.Sin(φ),
+	}
+}
+
+/*****************************************************************************************************************/
+
+/*
+the horizontal coordinate of the satellite for a given datetime and observer, and its range from the observer
+in kilometres
+
+The position of the satellite is rotated from the TEME frame into the Earth-fixed frame by the Greenwich Sidereal
+Time, and the observer's position on the WGS-84 ellipsoid is subtracted to give the topocentric position, which
+is then resolved into the observer's local south, east and zenith directions. Atmospheric refraction is not
+included.
+*/
+func (s *Satellite) GetHorizontalCoordinate(
+	datetime time.Time,
+	observer common.GeographicCoordinate,
+) (common.HorizontalCoordinate, float64, error) {
+	position, _, err := s.GetStateVector(datetime)
+
+	if err != nil {
+		return common.HorizontalCoordinate{}, 0, err
+	}
+
+	p := convertTEMEToEarthFixedCoordinate(datetime, position)
+
+	o := getObserverEarthFixedCoordinate(observer)
+
+	// the topocentric position of the satellite, in the Earth-fixed frame:
+	x, y, z := p.X-o.X, p.Y-o.Y, p.Z-o.Z
+
+	φ := common.Radians(observer.Latitude)
+
+	λ := common.Radians(observer.Longitude)
+
+	// the topocentric position, resolved into the local south, east and zenith directions:
+	S := math.Sin(φ)*math.Cos(λ)*x + math.Sin(φ)*math.Sin(λ)*y - math.Cos(φ)*z
+	E := -math.Sin(λ)*x + math.Cos(λ)*y
+	Z := math.Cos(φ)*math.Cos(λ)*x + math.Cos(φ)*math.Sin(λ)*y + math.Sin(φ)*z
+
+	ρ := math.Sqrt(x*x + y*y + z*z)
+
+	az := common.Degrees(math.Atan2(E, -S))
+
+	if az < 0 {
+		az += 360
+	}
+
+	return common.HorizontalCoordinate{
+		Azimuth:  az,
+		Altitude: common.Degrees(math.Asin(Z / ρ)),
+	}, ρ, nil
+}
+
+/*****************************************************************************************************************/
+
+/*
+whether a position, in kilometres, in the equatorial frame, is illuminated by the Sun at a given datetime, where
+the direction of the Sun is from its apparent position, rather than its mean longitude, which is up to two
+degrees out, i.e., over 200 km at the distance of a satellite in low Earth orbit
+*/
+func isSunlit(datetime time.Time, position common.CartesianCoordinate) bool {
+	eq := sun.GetApparentEquatorialCoordinate(datetime)
+
+	α := common.Radians(eq.RightAscension)
+
+	δ := common.Radians(eq.Declination)
+
+	// the unit vector towards the Sun, in the equatorial frame:
+	ux, uy, uz := math.Cos(δ)*math.Cos(α), math.Cos(δ)*math.Sin(α), math.Sin(δ)
+
+	// the distance of the position along the direction towards the Sun:
+	d := position.X*ux + position.Y*uy + position.Z*uz
+
+	if d > 0 {
+		return true
+	}
+
+	// the perpendicular distance of the position from the Earth-Sun line:
+	r := math.Sqrt(math.Pow(position.X-d*ux, 2) + math.Pow(position.Y-d*uy, 2) + math.Pow(position.Z-d*uz, 2))
+
+	return r > EARTH_RADIUS
+}
+
+/*****************************************************************************************************************/
+
+/*
+whether the satellite is illuminated by the Sun at a given datetime
+
+The Earth's shadow is modelled as a cylinder of the Earth's radius extending away from the Sun, which is
+accurate to within a few seconds of the true (penumbral) shadow entry and exit for satellites in low Earth
+orbit.
+*/
+func (s *Satellite) IsSunlit(datetime time.Time) (bool, error) {
+	position, _, err := s.GetStateVector(datetime)
+
+	if err != nil {
+		return false, err
+	}
+
+	return isSunlit(datetime, position), nil
+}
+
+/*****************************************************************************************************************/
+
+/*
+finds the passes of the satellite over an observer, above a minimum elevation (in degrees), within a date range
+
+The elevation of the satellite is scanned in steps of 30 seconds, and each rise and set is refined by bisection
+to within a tenth of a second, and the maximum elevation by golden section search. A pass which is already in
+progress at the start of the date range has its AOS at the start, and a pass still in progress at the end has
+its LOS at the end.
+*/
+func (s *Satellite) GetPasses(
+	start time.Time,
+	end time.Time,
+	observer common.GeographicCoordinate,
+	elevation float64,
+) ([]Pass, error) {
+	return s.GetPassesAboveHorizon(start, end, observer, horizon.NewFlatProfile(elevation))
+}
+
+/*****************************************************************************************************************/
+
+/*
+finds the passes of the satellite over an observer, above a local horizon profile, within a date range
+
+The AOS and LOS of each pass are the instants at which the satellite rises above and sets below the profile at
+its azimuth, e.g., from behind the trees and buildings around an observatory, and are otherwise found as for
+GetPasses. The maximum elevation is that of the pass, regardless of the profile.
+*/
+func (s *Satellite) GetPassesAboveHorizon(
+	start time.Time,
+	end time.Time,
+	observer common.GeographicCoordinate,
+	profile horizon.Profile,
+) ([]Pass, error) {
+	passes := []Pass{}
+
+	step := 30 * time.Second
+
+	var err error
+
+	// the elevation of the satellite above the local horizon profile, at a given datetime:
+	f := func(datetime time.Time) float64 {
+		hz, _, e := s.GetHorizontalCoordinate(datetime, observer)
+
+		if e != nil && err == nil {
+			err = e
+		}
+
+		return horizon.GetClearance(profile, hz)
+	}
+
+	// refine a change of sign of the elevation between two datetimes by bisection:
+	bisect := func(a time.Time, b time.Time) time.Time {
+		fa := f(a)
+
+		for b.Sub(a) > 100*time.Millisecond {
+			mid := a.Add(b.Sub(a) / 2)
+
+			if fm := f(mid); fa*fm <= 0 {
+				b = mid
+			} else {
+				a, fa = mid, fm
+			}
+		}
+
+		return a.Add(b.Sub(a) / 2)
+	}
+
+	var pass *Pass
+
+	sunlit := false
+
+	a := start
+
+	fa := f(a)
+
+	if fa > 0 {
+		pass = &Pass{AOS: start}
+	}
+
+	for a.Before(end) && err == nil {
+		b := a.Add(step)
+
+		if b.After(end) {
+			b = end
+		}
+
+		fb := f(b)
+
+		if pass != nil {
+			lit, e := s.IsSunlit(a)
+
+			if e != nil {
+				return nil, e
+			}
+
+			sunlit = sunlit || lit
+		}
+
+		// the satellite rises above the minimum elevation:
+		if fa <= 0 && fb > 0 {
+			pass = &Pass{AOS: bisect(a, b)}
+		}
+
+		// the satellite sets below the minimum elevation, or the end of the date range is reached:
+		if pass != nil && (fa > 0 && fb <= 0 || !b.Before(end)) {
+			pass.LOS = b
+
+			if fb <= 0 {
+				pass.LOS = bisect(a, b)
+			}
+
+			lit, e := s.IsSunlit(pass.LOS)
+
+			if e != nil {
+				return nil, e
+			}
+
+			pass.Sunlit = sunlit || lit
+
+			s.setMaximumElevation(pass, observer)
+
+			passes = append(passes, *pass)
+
+			pass, sunlit = nil, false
+		}
+
+		a, fa = b, fb
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return passes, nil
+}
+
+/*****************************************************************************************************************/
+
+/*
+sets the AOS and LOS azimuths and the maximum elevation of a pass, finding the time of maximum elevation by
+golden section search between the AOS and LOS
+*/
+func (s *Satellite) setMaximumElevation(pass *Pass, observer common.GeographicCoordinate) {
+	altitude := func(datetime time.Time) float64 {
+		hz, _, _ := s.GetHorizontalCoordinate(datetime, observer)
+		return hz.Altitude
+	}
+
+	aos, _, _ := s.GetHorizontalCoordinate(pass.AOS, observer)
+
+	los, _, _ := s.GetHorizontalCoordinate(pass.LOS, observer)
+
+	pass.AOSAzimuth = aos.Azimuth
+
+	pass.LOSAzimuth = los.Azimuth
+
+	ϕ := (math.Sqrt(5) - 1) / 2
+
+	a, b := pass.AOS, pass.LOS
+
+	for b.Sub(a) > 100*time.Millisecond {
+		c := b.Add(-time.Duration(ϕ * float64(b.Sub(a))))
+
+		d := a.Add(time.Duration(ϕ * float64(b.Sub(a))))
+
+		if altitude(c) > altitude(d) {
+			b = d
+		} else {
+			a = c
+		}
+	}
+
+	pass.MaximumElevationTime = a.Add(b.Sub(a) / 2)
+
+	pass.MaximumElevation = altitude(pass.MaximumElevationTime)
+}
+
+/*****************************************************************************************************************/
@@ -0,0 +1,265 @@
+/*****************************************************************************************************************/
+
+//	@author		Michael Roberts <michael@observerly.com>
+//	@package	@observerly/sidera
+//	@license	Copyright © 2021-2024 observerly
+
+/*****************************************************************************************************************/
+
+package satellite
+
+/*****************************************************************************************************************/
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/observerly/sidera/pkg/common"
+	"github.com/observerly/sidera/pkg/horizon"
+	sun "github.com/observerly/sidera/pkg/solar"
+)
+
+/*****************************************************************************************************************/
+
+// the Mauna Kea Observatories, Hawaii:
+var observer common.GeographicCoordinate = common.GeographicCoordinate{
+	Latitude:  19.82067,
+	Longitude: -155.468094,
+	Elevation: 4205,
+}
+
+/*****************************************************************************************************************/
+
+func TestGetObserverEarthFixedCoordinate(t *testing.T) {
+	// an observer on the equator at the prime meridian, at sea level:
+	p := getObserverEarthFixedCoordinate(common.GeographicCoordinate{Latitude: 0, Longitude: 0, Elevation: 0})
+
+	if math.Abs(p.X-common.EARTH_EQUATORIAL_RADIUS) > 1e-9 || math.Abs(p.Y) > 1e-9 || math.Abs(p.Z) > 1e-9 {
+		t.Errorf("got %+v, wanted (%f, 0, 0)", p, common.EARTH_EQUATORIAL_RADIUS)
+	}
+
+	// an observer at the north pole, at sea level, lies at the semi-minor axis:
+	p = getObserverEarthFixedCoordinate(common.GeographicCoordinate{Latitude: 90, Longitude: 0, Elevation: 0})
+
+	if b := common.EARTH_EQUATORIAL_RADIUS * (1 - common.EARTH_FLATTENING); math.Abs(p.Z-b) > 1e-9 {
+		t.Errorf("got %f, wanted %f", p.Z, b)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetHorizontalCoordinateSubSatellitePoint(t *testing.T) {
+	s := newSatellite(t, [2]string{iss[1], iss[2]})
+
+	datetime := s.TLE.Epoch.Add(time.Hour)
+
+	position, _, err := s.GetStateVector(datetime)
+
+	if err != nil {
+		t.Fatalf("got error %v", err)
+	}
+
+	p := convertTEMEToEarthFixedCoordinate(datetime, position)
+
+	e2 := common.EARTH_FLATTENING * (2 - common.EARTH_FLATTENING)
+
+	r := math.Hypot(p.X, p.Y)
+
+	// the geodetic latitude of the sub-satellite point, by iteration from the geocentric latitude:
+	φ := math.Atan2(p.Z, r)
+
+	for i := 0; i < 10; i++ {
+		N := common.EARTH_EQUATORIAL_RADIUS / math.Sqrt(1-e2*math.Pow(math.Sin(φ), 2))
+		φ = math.Atan2(p.Z+e2*N*math.Sin(φ), r)
+	}
+
+	// an observer directly beneath the satellite:
+	o := common.GeographicCoordinate{
+		Latitude:  common.Degrees(φ),
+		Longitude: common.Degrees(math.Atan2(p.Y, p.X)),
+		Elevation: 0,
+	}
+
+	hz, ρ, err := s.GetHorizontalCoordinate(datetime, o)
+
+	if err != nil {
+		t.Fatalf("got error %v", err)
+	}
+
+	if hz.Altitude < 89.99 {
+		t.Errorf("got %f, wanted 90 degrees", hz.Altitude)
+	}
+
+	// the ISS orbits at an altitude of roughly 400 km:
+	if ρ < 350 || ρ > 450 {
+		t.Errorf("got %f km, wanted a range of ~400 km", ρ)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestIsSunlit(t *testing.T) {
+	s := newSatellite(t, [2]string{iss[1], iss[2]})
+
+	sunlit, eclipsed := 0, 0
+
+	// sample a single orbit of ~92 minutes, in which the ISS spends roughly a third in the Earth's shadow:
+	for minutes := 0; minutes < 92; minutes++ {
+		lit, err := s.IsSunlit(s.TLE.Epoch.Add(time.Duration(minutes) * time.Minute))
+
+		if err != nil {
+			t.Fatalf("got error %v", err)
+		}
+
+		if lit {
+			sunlit++
+		} else {
+			eclipsed++
+		}
+	}
+
+	if eclipsed < 20 || eclipsed > 40 {
+		t.Errorf("got %d minutes sunlit and %d minutes eclipsed", sunlit, eclipsed)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestIsSunlitApparentSun(t *testing.T) {
+	// early April, when the mean longitude of the Sun is almost two degrees from its apparent longitude:
+	datetime := time.Date(2024, 4, 3, 0, 0, 0, 0, time.UTC)
+
+	unit := func(eq common.EquatorialCoordinate) common.CartesianCoordinate {
+		α, δ := common.Radians(eq.RightAscension), common.Radians(eq.Declination)
+		return common.CartesianCoordinate{X: math.Cos(δ) * math.Cos(α), Y: math.Cos(δ) * math.Sin(α), Z: math.Sin(δ)}
+	}
+
+	u, m := unit(sun.GetApparentEquatorialCoordinate(datetime)), unit(sun.GetEquatorialCoordinate(datetime))
+
+	// the direction perpendicular to the apparent Sun, towards the mean Sun:
+	d := u.X*m.X + u.Y*m.Y + u.Z*m.Z
+
+	p := common.CartesianCoordinate{X: m.X - d*u.X, Y: m.Y - d*u.Y, Z: m.Z - d*u.Z}
+
+	n := math.Sqrt(p.X*p.X + p.Y*p.Y + p.Z*p.Z)
+
+	// a position 7,000 km behind the Earth, 80 km inside the edge of the shadow of the apparent Sun, which is over
+	// 100 km outside the edge of the shadow of the mean Sun:
+	position := common.CartesianCoordinate{
+		X: -7000*u.X + (EARTH_RADIUS-80)*p.X/n,
+		Y: -7000*u.Y + (EARTH_RADIUS-80)*p.Y/n,
+		Z: -7000*u.Z + (EARTH_RADIUS-80)*p.Z/n,
+	}
+
+	if isSunlit(datetime, position) {
+		t.Errorf("got sunlit, wanted the position to be within the Earth's shadow")
+	}
+
+	// a position 80 km outside the edge of the shadow:
+	position = common.CartesianCoordinate{
+		X: -7000*u.X - (EARTH_RADIUS+80)*p.X/n,
+		Y: -7000*u.Y - (EARTH_RADIUS+80)*p.Y/n,
+		Z: -7000*u.Z - (EARTH_RADIUS+80)*p.Z/n,
+	}
+
+	if !isSunlit(datetime, position) {
+		t.Errorf("got eclipsed, wanted the position to be outside the Earth's shadow")
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetPasses(t *testing.T) {
+	s := newSatellite(t, [2]string{iss[1], iss[2]})
+
+	start := s.TLE.Epoch
+
+	end := start.Add(24 * time.Hour)
+
+	passes, err := s.GetPasses(start, end, observer, 10)
+
+	if err != nil {
+		t.Fatalf("got error %v", err)
+	}
+
+	if len(passes) == 0 {
+		t.Fatalf("expected at least one pass of the ISS within a day")
+	}
+
+	for i, pass := range passes {
+		if !pass.AOS.Before(pass.MaximumElevationTime) || !pass.MaximumElevationTime.Before(pass.LOS) {
+			t.Errorf("pass %d: got AOS %v, maximum %v, LOS %v", i, pass.AOS, pass.MaximumElevationTime, pass.LOS)
+		}
+
+		if d := pass.LOS.Sub(pass.AOS); d > 15*time.Minute {
+			t.Errorf("pass %d: got a duration of %v", i, d)
+		}
+
+		if pass.MaximumElevation < 10 || pass.MaximumElevation > 90 {
+			t.Errorf("pass %d: got a maximum elevation of %f", i, pass.MaximumElevation)
+		}
+
+		if i > 0 && !passes[i-1].LOS.Before(pass.AOS) {
+			t.Errorf("pass %d: overlaps the previous pass", i)
+		}
+
+		// the satellite is at the minimum elevation at the AOS and LOS, unless clipped by the date range:
+		for _, datetime := range []time.Time{pass.AOS, pass.LOS} {
+			if datetime.Equal(start) || datetime.Equal(end) {
+				continue
+			}
+
+			hz, _, _ := s.GetHorizontalCoordinate(datetime, observer)
+
+			if math.Abs(hz.Altitude-10) > 0.01 {
+				t.Errorf("pass %d: got an altitude of %f at %v, wanted 10", i, hz.Altitude, datetime)
+			}
+		}
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetPassesAboveHorizon(t *testing.T) {
+	s := newSatellite(t, [2]string{iss[1], iss[2]})
+
+	start := s.TLE.Epoch
+
+	end := start.Add(24 * time.Hour)
+
+	// a horizon obstructed to 30 degrees in the east, and open to 5 degrees in the west:
+	profile := horizon.NewProfile([]horizon.Point{
+		{Azimuth: 0, Altitude: 5},
+		{Azimuth: 45, Altitude: 30},
+		{Azimuth: 135, Altitude: 30},
+		{Azimuth: 180, Altitude: 5},
+	})
+
+	passes, err := s.GetPassesAboveHorizon(start, end, observer, profile)
+
+	if err != nil {
+		t.Fatalf("got error %v", err)
+	}
+
+	if len(passes) == 0 {
+		t.Fatalf("expected at least one pass of the ISS above the horizon profile within a day")
+	}
+
+	for i, pass := range passes {
+		// the satellite is on the horizon profile at the AOS and LOS, unless clipped by the date range:
+		for _, datetime := range []time.Time{pass.AOS, pass.LOS} {
+			if datetime.Equal(start) || datetime.Equal(end) {
+				continue
+			}
+
+			hz, _, _ := s.GetHorizontalCoordinate(datetime, observer)
+
+			if c := horizon.GetClearance(profile, hz); math.Abs(c) > 0.01 {
+				t.Errorf("pass %d: got a clearance of %f at %v, wanted 0", i, c, datetime)
+			}
+		}
+	}
+}
+
+/*****************************************************************************************************************/
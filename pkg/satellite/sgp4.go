@@ -0,0 +1,488 @@
+/*****************************************************************************************************************/
+
+//	@author		Michael Roberts <michael@observerly.com>
+//	@package	@observerly/sidera
+//	@license	Copyright © 2021-2024 observerly
+
+/*****************************************************************************************************************/
+
+package satellite
+
+/*****************************************************************************************************************/
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/observerly/sidera/pkg/common"
+	"github.com/observerly/sidera/pkg/epoch"
+)
+
+/*****************************************************************************************************************/
+
+// the equatorial radius of the Earth in the WGS-72 model used by SGP4, in kilometres:
+const EARTH_RADIUS float64 = 6378.135
+
+/*****************************************************************************************************************/
+
+// the gravitational parameter of the Earth in the WGS-72 model used by SGP4, in km³/s²:
+const EARTH_GRAVITATIONAL_PARAMETER float64 = 398600.8
+
+/*****************************************************************************************************************/
+
+// the zonal harmonic coefficients of the Earth's gravitational field in the WGS-72 model:
+const (
+	J2 float64 = 0.001082616
+	J3 float64 = -0.00000253881
+	J4 float64 = -0.00000165597
+)
+
+/*****************************************************************************************************************/
+
+var (
+	// the square root of the gravitational parameter, in Earth radii^(3/2) per minute:
+	xke = 60 / math.Sqrt(math.Pow(EARTH_RADIUS, 3)/EARTH_GRAVITATIONAL_PARAMETER)
+
+	j3oj2 = J3 / J2
+)
+
+/*****************************************************************************************************************/
+
+var (
+	// the satellite's orbit has decayed, or its eccentricity has become invalid:
+	ErrDecayed = errors.New("satellite has decayed")
+	// the satellite's mean motion or eccentricity has become invalid during propagation:
+	ErrInvalidElements = errors.New("invalid mean elements during propagation")
+)
+
+/*****************************************************************************************************************/
+
+/*
+a satellite, initialised from a two-line element set for propagation by the SGP4/SDP4 models
+
+The SGP4 model is used for near-Earth orbits, with periods of less than 225 minutes, and the SDP4 model, which
+includes the lunar and solar perturbations and the resonances of the Earth's gravitational field with 12 hour
+and 24 hour orbits, is used for deep-space orbits. The implementation follows Vallado et al., "Revisiting
+Spacetrack Report #3" (2006), using the WGS-72 constants and the "improved" operation mode.
+*/
+type Satellite struct {
+	TLE TLE
+	// the epoch of the element set, as a Julian Date:
+	jdsatepoch float64
+	// the mean elements at epoch, in radians and radians per minute:
+	ecco, inclo, nodeo, argpo, mo, no, bstar float64
+	// the near-Earth coefficients:
+	isimp                                              bool
+	a, aycof, con41, cc1, cc4, cc5, d2, d3, d4, delmo  float64
+	eta, argpdot, omgcof, sinmao, t2cof, t3cof, t4cof  float64
+	t5cof, x1mth2, x7thm1, mdot, nodedot, xlcof, xmcof float64
+	nodecf                                             float64
+	// the deep-space coefficients, which are nil for a near-Earth orbit:
+	deep *deepSpace
+}
+
+/*****************************************************************************************************************/
+
+/*
+the Greenwich Mean Sidereal Time, in radians, for a given Julian Date (UT1), as used by SGP4 to orient the
+deep-space resonance terms
+*/
+func getGreenwichMeanSiderealTime(JD float64) float64 {
+	T := (JD - epoch.J2000) / 36525
+
+	θ := -6.2e-6*math.Pow(T, 3) + 0.093104*math.Pow(T, 2) + (876600.0*3600+8640184.812866)*T + 67310.54841
+
+	θ = math.Mod(common.Radians(θ/240), 2*math.Pi)
+
+	if θ < 0 {
+		θ += 2 * math.Pi
+	}
+
+	return θ
+}
+
+/*****************************************************************************************************************/
+
+/*
+initialises a satellite from a two-line element set for propagation by the SGP4/SDP4 models
+
+The mean motion of the element set is first "un-Kozai'd" to recover the Brouwer mean motion, from which the
+secular rates due to the zonal harmonics and the atmospheric drag coefficients are then computed. An orbit with
+a period of 225 minutes or more is initialised for the deep-space (SDP4) model.
+*/
+func NewSatellite(tle TLE) (*Satellite, error) {
+	// the number of minutes in a day, divided by 2π:
+	xpdotp := 1440 / (2 * math.Pi)
+
+	s := &Satellite{
+		TLE:        tle,
+		jdsatepoch: float64(tle.Epoch.UnixNano())/86400e9 + epoch.J1970,
+		ecco:       tle.Eccentricity,
+		inclo:      common.Radians(tle.Inclination),
+		nodeo:      common.Radians(tle.RightAscensionOfAscendingNode),
+		argpo:      common.Radians(tle.ArgumentOfPerigee),
+		mo:         common.Radians(tle.MeanAnomaly),
+		bstar:      tle.BStar,
+	}
+
+	if tle.MeanMotion <= 0 || tle.Eccentricity < 0 || tle.Eccentricity >= 1 {
+		return nil, fmt.Errorf("invalid element set: mean motion %f, eccentricity %f", tle.MeanMotion, tle.Eccentricity)
+	}
+
+	// the Kozai mean motion, in radians per minute:
+	noKozai := tle.MeanMotion / xpdotp
+
+	// the perigee height parameters, in Earth radii:
+	ss := 78/EARTH_RADIUS + 1
+
+	qzms2t := math.Pow((120-78)/EARTH_RADIUS, 4)
+
+	x2o3 := 2.0 / 3.0
+
+	// recover the Brouwer mean motion and semi-major axis from the Kozai mean motion:
+	eccsq := math.Pow(s.ecco, 2)
+	omeosq := 1 - eccsq
+	rteosq := math.Sqrt(omeosq)
+	cosio := math.Cos(s.inclo)
+	cosio2 := math.Pow(cosio, 2)
+
+	ak := math.Pow(xke/noKozai, x2o3)
+	d1 := 0.75 * J2 * (3*cosio2 - 1) / (rteosq * omeosq)
+	del := d1 / math.Pow(ak, 2)
+	adel := ak * (1 - del*del - del*(1.0/3+134*del*del/81))
+	del = d1 / math.Pow(adel, 2)
+
+	s.no = noKozai / (1 + del)
+
+	ao := math.Pow(xke/s.no, x2o3)
+	sinio := math.Sin(s.inclo)
+	po := ao * omeosq
+	con42 := 1 - 5*cosio2
+	s.con41 = -con42 - cosio2 - cosio2
+	posq := po * po
+	rp := ao * (1 - s.ecco)
+
+	s.a = math.Pow(s.no/xke, -x2o3)
+
+	// orbits with a perigee below 220 km use a simplified drag model:
+	s.isimp = rp < 220/EARTH_RADIUS+1
+
+	sfour := ss
+
+	qzms24 := qzms2t
+
+	perige := (rp - 1) * EARTH_RADIUS
+
+	// for perigees below 156 km, the atmospheric density parameters are altered:
+	if perige < 156 {
+		sfour = perige - 78
+
+		if perige < 98 {
+			sfour = 20
+		}
+
+		qzms24 = math.Pow((120-sfour)/EARTH_RADIUS, 4)
+
+		sfour = sfour/EARTH_RADIUS + 1
+	}
+
+	pinvsq := 1 / posq
+
+	tsi := 1 / (ao - sfour)
+	s.eta = ao * s.ecco * tsi
+	etasq := s.eta * s.eta
+	eeta := s.ecco * s.eta
+	psisq := math.Abs(1 - etasq)
+	coef := qzms24 * math.Pow(tsi, 4)
+	coef1 := coef / math.Pow(psisq, 3.5)
+
+	cc2 := coef1 * s.no * (ao*(1+1.5*etasq+eeta*(4+etasq)) + 0.375*J2*tsi/psisq*s.con41*(8+3*etasq*(8+etasq)))
+
+	s.cc1 = s.bstar * cc2
+
+	cc3 := 0.0
+
+	if s.ecco > 1e-4 {
+		cc3 = -2 * coef * tsi * j3oj2 * s.no * sinio / s.ecco
+	}
+
+	s.x1mth2 = 1 - cosio2
+
+	s.cc4 = 2 * s.no * coef1 * ao * omeosq * (s.eta*(2+0.5*etasq) + s.ecco*(0.5+2*etasq) -
+		J2*tsi/(ao*psisq)*(-3*s.con41*(1-2*eeta+etasq*(1.5-0.5*eeta))+
+			0.75*s.x1mth2*(2*etasq-eeta*(1+etasq))*math.Cos(2*s.argpo)))
+
+	s.cc5 = 2 * coef1 * ao * omeosq * (1 + 2.75*(etasq+eeta) + eeta*etasq)
+
+	cosio4 := cosio2 * cosio2
+	temp1 := 1.5 * J2 * pinvsq * s.no
+	temp2 := 0.5 * temp1 * J2 * pinvsq
+	temp3 := -0.46875 * J4 * pinvsq * pinvsq * s.no
+
+	// the secular rates of the mean anomaly, argument of perigee and right ascension of the ascending node:
+	s.mdot = s.no + 0.5*temp1*rteosq*s.con41 + 0.0625*temp2*rteosq*(13-78*cosio2+137*cosio4)
+
+	s.argpdot = -0.5*temp1*con42 + 0.0625*temp2*(7-114*cosio2+395*cosio4) + temp3*(3-36*cosio2+49*cosio4)
+
+	xhdot1 := -temp1 * cosio
+
+	s.nodedot = xhdot1 + (0.5*temp2*(4-19*cosio2)+2*temp3*(3-7*cosio2))*cosio
+
+	xpidot := s.argpdot + s.nodedot
+
+	s.omgcof = s.bstar * cc3 * math.Cos(s.argpo)
+
+	if s.ecco > 1e-4 {
+		s.xmcof = -x2o3 * coef * s.bstar / eeta
+	}
+
+	s.nodecf = 3.5 * omeosq * xhdot1 * s.cc1
+	s.t2cof = 1.5 * s.cc1
+
+	// avoid a division by zero for an inclination of 180 degrees:
+	s.xlcof = -0.25 * j3oj2 * sinio * (3 + 5*cosio) / math.Max(1+cosio, 1.5e-12)
+	s.aycof = -0.5 * j3oj2 * sinio
+
+	s.delmo = math.Pow(1+s.eta*math.Cos(s.mo), 3)
+	s.sinmao = math.Sin(s.mo)
+	s.x7thm1 = 7*cosio2 - 1
+
+	// orbits with a period of 225 minutes or more are propagated with the deep-space model:
+	if 2*math.Pi/s.no >= 225 {
+		s.isimp = true
+
+		s.deep = newDeepSpace(s, eccsq, xpidot)
+	}
+
+	if !s.isimp {
+		cc1sq := s.cc1 * s.cc1
+		s.d2 = 4 * ao * tsi * cc1sq
+		temp := s.d2 * tsi * s.cc1 / 3
+		s.d3 = (17*ao + sfour) * temp
+		s.d4 = 0.5 * temp * ao * tsi * (221*ao + 31*sfour) * s.cc1
+		s.t3cof = s.d2 + 2*cc1sq
+		s.t4cof = 0.25 * (3*s.d3 + s.cc1*(12*s.d2+10*cc1sq))
+		s.t5cof = 0.2 * (3*s.d4 + 12*s.cc1*s.d3 + 6*s.d2*s.d2 + 15*cc1sq*(2*s.d2+cc1sq))
+	}
+
+	if _, _, err := s.Propagate(0); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+/*****************************************************************************************************************/
+
+/*
+propagates the satellite to a given number of minutes since the epoch of its element set, returning its
+position (in km) and velocity (in km/s) in the True Equator, Mean Equinox (TEME) frame
+
+An error is returned if the orbit has decayed, i.e., if the satellite would be below the surface of the
+Earth, or if the mean elements have become invalid, which may happen long after the epoch for a satellite
+with a large drag term.
+*/
+func (s *Satellite) Propagate(minutes float64) (common.CartesianCoordinate, common.CartesianCoordinate, error) {
+	t := minutes
+
+	// update for the secular effects of gravity and atmospheric drag:
+	xmdf := s.mo + s.mdot*t
+	argpdf := s.argpo + s.argpdot*t
+	nodedf := s.nodeo + s.nodedot*t
+
+	argpm := argpdf
+	mm := xmdf
+	t2 := t * t
+	nodem := nodedf + s.nodecf*t2
+	tempa := 1 - s.cc1*t
+	tempe := s.bstar * s.cc4 * t
+	templ := s.t2cof * t2
+
+	if !s.isimp {
+		delomg := s.omgcof * t
+		delm := s.xmcof * (math.Pow(1+s.eta*math.Cos(xmdf), 3) - s.delmo)
+		temp := delomg + delm
+		mm = xmdf + temp
+		argpm = argpdf - temp
+		t3 := t2 * t
+		t4 := t3 * t
+		tempa = tempa - s.d2*t2 - s.d3*t3 - s.d4*t4
+		tempe = tempe + s.bstar*s.cc5*(math.Sin(mm)-s.sinmao)
+		templ = templ + s.t3cof*t3 + t4*(s.t4cof+t*s.t5cof)
+	}
+
+	nm := s.no
+	em := s.ecco
+	inclm := s.inclo
+
+	// the deep-space secular and resonance effects:
+	if s.deep != nil {
+		em, argpm, inclm, mm, nodem, nm = s.deep.getSecularEffects(s, t, em, argpm, inclm, mm, nodem)
+	}
+
+	if nm <= 0 {
+		return common.CartesianCoordinate{}, common.CartesianCoordinate{}, ErrInvalidElements
+	}
+
+	am := math.Pow(xke/nm, 2.0/3) * tempa * tempa
+	nm = xke / math.Pow(am, 1.5)
+	em = em - tempe
+
+	if em >= 1 || em < -0.001 {
+		return common.CartesianCoordinate{}, common.CartesianCoordinate{}, ErrInvalidElements
+	}
+
+	// avoid a division by zero for a circular orbit:
+	em = math.Max(em, 1e-6)
+
+	mm = mm + s.no*templ
+	xlm := mm + argpm + nodem
+
+	nodem = math.Mod(nodem, 2*math.Pi)
+	argpm = math.Mod(argpm, 2*math.Pi)
+	xlm = math.Mod(xlm, 2*math.Pi)
+	mm = math.Mod(xlm-argpm-nodem, 2*math.Pi)
+
+	ep := em
+	xincp := inclm
+	argpp := argpm
+	nodep := nodem
+	mp := mm
+	sinip := math.Sin(inclm)
+	cosip := math.Cos(inclm)
+
+	aycof := s.aycof
+	xlcof := s.xlcof
+	con41 := s.con41
+	x1mth2 := s.x1mth2
+	x7thm1 := s.x7thm1
+
+	// the lunar-solar periodics of the deep-space model:
+	if s.deep != nil {
+		ep, xincp, nodep, argpp, mp = s.deep.getPeriodicEffects(t, ep, xincp, nodep, argpp, mp)
+
+		if xincp < 0 {
+			xincp = -xincp
+			nodep = nodep + math.Pi
+			argpp = argpp - math.Pi
+		}
+
+		if ep < 0 || ep > 1 {
+			return common.CartesianCoordinate{}, common.CartesianCoordinate{}, ErrInvalidElements
+		}
+
+		sinip = math.Sin(xincp)
+		cosip = math.Cos(xincp)
+
+		aycof = -0.5 * j3oj2 * sinip
+		xlcof = -0.25 * j3oj2 * sinip * (3 + 5*cosip) / math.Max(1+cosip, 1.5e-12)
+
+		con41 = 3*cosip*cosip - 1
+		x1mth2 = 1 - cosip*cosip
+		x7thm1 = 7*cosip*cosip - 1
+	}
+
+	// the long period periodics:
+	axnl := ep * math.Cos(argpp)
+	temp := 1 / (am * (1 - ep*ep))
+	aynl := ep*math.Sin(argpp) + temp*aycof
+	xl := mp + argpp + nodep + temp*xlcof*axnl
+
+	// solve Kepler's equation for the eccentric longitude:
+	u := math.Mod(xl-nodep, 2*math.Pi)
+
+	eo1 := u
+
+	sineo1, coseo1 := math.Sin(eo1), math.Cos(eo1)
+
+	for i := 0; i < 10; i++ {
+		sineo1, coseo1 = math.Sin(eo1), math.Cos(eo1)
+
+		Δ := (u - aynl*coseo1 + axnl*sineo1 - eo1) / (1 - coseo1*axnl - sineo1*aynl)
+
+		// limit the size of each correction to ensure convergence:
+		Δ = math.Max(-0.95, math.Min(0.95, Δ))
+
+		eo1 += Δ
+
+		if math.Abs(Δ) < 1e-12 {
+			break
+		}
+	}
+
+	// the short period preliminary quantities:
+	ecose := axnl*coseo1 + aynl*sineo1
+	esine := axnl*sineo1 - aynl*coseo1
+	el2 := axnl*axnl + aynl*aynl
+	pl := am * (1 - el2)
+
+	if pl < 0 {
+		return common.CartesianCoordinate{}, common.CartesianCoordinate{}, ErrInvalidElements
+	}
+
+	rl := am * (1 - ecose)
+	rdotl := math.Sqrt(am) * esine / rl
+	rvdotl := math.Sqrt(pl) / rl
+	betal := math.Sqrt(1 - el2)
+	temp = esine / (1 + betal)
+	sinu := am / rl * (sineo1 - aynl - axnl*temp)
+	cosu := am / rl * (coseo1 - axnl + aynl*temp)
+	su := math.Atan2(sinu, cosu)
+	sin2u := (cosu + cosu) * sinu
+	cos2u := 1 - 2*sinu*sinu
+	temp = 1 / pl
+	temp1 := 0.5 * J2 * temp
+	temp2 := temp1 * temp
+
+	// update for the short period periodics:
+	mrt := rl*(1-1.5*temp2*betal*con41) + 0.5*temp1*x1mth2*cos2u
+	su = su - 0.25*temp2*x7thm1*sin2u
+	xnode := nodep + 1.5*temp2*cosip*sin2u
+	xinc := xincp + 1.5*temp2*cosip*sinip*cos2u
+	mvt := rdotl - nm*temp1*x1mth2*sin2u/xke
+	rvdot := rvdotl + nm*temp1*(x1mth2*cos2u+1.5*con41)/xke
+
+	// the orientation vectors:
+	sinsu, cossu := math.Sin(su), math.Cos(su)
+	snod, cnod := math.Sin(xnode), math.Cos(xnode)
+	sini, cosi := math.Sin(xinc), math.Cos(xinc)
+	xmx := -snod * cosi
+	xmy := cnod * cosi
+	ux := xmx*sinsu + cnod*cossu
+	uy := xmy*sinsu + snod*cossu
+	uz := sini * sinsu
+	vx := xmx*cossu - cnod*sinsu
+	vy := xmy*cossu - snod*sinsu
+	vz := sini * cossu
+
+	if mrt < 1 {
+		return common.CartesianCoordinate{}, common.CartesianCoordinate{}, ErrDecayed
+	}
+
+	// the velocity unit, in km/s:
+	vkmpersec := EARTH_RADIUS * xke / 60
+
+	return common.CartesianCoordinate{
+		X: mrt * ux * EARTH_RADIUS,
+		Y: mrt * uy * EARTH_RADIUS,
+		Z: mrt * uz * EARTH_RADIUS,
+	}, common.CartesianCoordinate{
+		X: (mvt*ux + rvdot*vx) * vkmpersec,
+		Y: (mvt*uy + rvdot*vy) * vkmpersec,
+		Z: (mvt*uz + rvdot*vz) * vkmpersec,
+	}, nil
+}
+
+/*****************************************************************************************************************/
+
+/*
+the position (in km) and velocity (in km/s) of the satellite at a given datetime, in the True Equator, Mean
+Equinox (TEME) frame
+*/
+func (s *Satellite) GetStateVector(datetime time.Time) (common.CartesianCoordinate, common.CartesianCoordinate, error) {
+	return s.Propagate(datetime.Sub(s.TLE.Epoch).Minutes())
+}
+
+/*****************************************************************************************************************/
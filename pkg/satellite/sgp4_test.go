@@ -0,0 +1,263 @@
+/*****************************************************************************************************************/
+
+//	@author		Michael Roberts <michael@observerly.com>
+//	@package	@observerly/sidera
+//	@license	Copyright © 2021-2024 observerly
+
+/*****************************************************************************************************************/
+
+package satellite
+
+/*****************************************************************************************************************/
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/observerly/sidera/pkg/common"
+)
+
+/*****************************************************************************************************************/
+
+// the near-Earth test case from Vallado et al., "Revisiting Spacetrack Report #3" (Vanguard 1):
+var vanguard = [2]string{
+	"1 00005U 58002B   00179.78495062  .00000023  00000-0  28098-4 0  4753",
+	"2 00005  34.2682 348.7242 1859667 331.7664  19.3264 10.82419157413667",
+}
+
+/*****************************************************************************************************************/
+
+// the deep-space test case from Spacetrack Report #3:
+var deep = [2]string{
+	"1 11801U          80230.29629788  .01431103  00000-0  14311-1 0    13",
+	"2 11801  46.7916 230.4354 7318036  47.4722  10.4117  2.28537848    13",
+}
+
+/*****************************************************************************************************************/
+
+// a geosynchronous orbit, which is subject to the 24 hour resonance:
+var geosynchronous = [2]string{
+	"1 99991U 24001A   24061.50000000  .00000000  00000-0  00000-0 0  9992",
+	"2 99991   0.0500  90.0000 0002000  45.0000 180.0000  1.00270000    14",
+}
+
+/*****************************************************************************************************************/
+
+// a Molniya orbit, which is subject to the 12 hour resonance:
+var molniya = [2]string{
+	"1 99992U 24001B   24061.50000000  .00000000  00000-0  00000-0 0  9993",
+	"2 99992  63.4000 120.0000 7000000 270.0000  30.0000  2.00600000    14",
+}
+
+/*****************************************************************************************************************/
+
+func newSatellite(t *testing.T, lines [2]string) *Satellite {
+	tle, err := ParseTLE("", lines[0], lines[1])
+
+	if err != nil {
+		t.Fatalf("got error %v", err)
+	}
+
+	s, err := NewSatellite(tle)
+
+	if err != nil {
+		t.Fatalf("got error %v", err)
+	}
+
+	return s
+}
+
+/*****************************************************************************************************************/
+
+func assertStateVector(t *testing.T, s *Satellite, minutes float64, r [3]float64, v [3]float64) {
+	p, ṗ, err := s.Propagate(minutes)
+
+	if err != nil {
+		t.Fatalf("got error %v", err)
+	}
+
+	if math.Abs(p.X-r[0]) > 1e-6 || math.Abs(p.Y-r[1]) > 1e-6 || math.Abs(p.Z-r[2]) > 1e-6 {
+		t.Errorf("t = %f: got %+v, wanted %v", minutes, p, r)
+	}
+
+	if math.Abs(ṗ.X-v[0]) > 1e-8 || math.Abs(ṗ.Y-v[1]) > 1e-8 || math.Abs(ṗ.Z-v[2]) > 1e-8 {
+		t.Errorf("t = %f: got %+v, wanted %v", minutes, ṗ, v)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestPropagateNearEarth(t *testing.T) {
+	s := newSatellite(t, vanguard)
+
+	if s.deep != nil {
+		t.Errorf("expected a near-Earth orbit")
+	}
+
+	assertStateVector(t, s, 0,
+		[3]float64{7022.46529266, -1400.08296755, 0.03995155},
+		[3]float64{1.893841015, 6.405893759, 4.534807250},
+	)
+
+	assertStateVector(t, s, 360,
+		[3]float64{-7154.03120202, -3783.17682504, -3536.19412294},
+		[3]float64{4.741887409, -4.151817765, -2.093935425},
+	)
+}
+
+/*****************************************************************************************************************/
+
+func TestPropagateDeepSpace(t *testing.T) {
+	s := newSatellite(t, deep)
+
+	if s.deep == nil || s.deep.irez != 0 {
+		t.Errorf("expected a non-resonant deep-space orbit")
+	}
+
+	assertStateVector(t, s, 0,
+		[3]float64{7473.37102491, 428.94748312, 5828.74846783},
+		[3]float64{5.107155391, 6.444680305, -0.186133297},
+	)
+
+	assertStateVector(t, s, 360,
+		[3]float64{-3305.22148694, 32410.84323331, -24697.16974954},
+		[3]float64{-1.301137319, -1.151315600, -0.283335823},
+	)
+}
+
+/*****************************************************************************************************************/
+
+func TestPropagateResonant(t *testing.T) {
+	tests := []struct {
+		lines   [2]string
+		irez    int
+		perigee float64
+		apogee  float64
+	}{
+		{geosynchronous, 1, 42100, 42240},
+		{molniya, 2, 7500, 46000},
+	}
+
+	for _, test := range tests {
+		s := newSatellite(t, test.lines)
+
+		if s.deep == nil || s.deep.irez != test.irez {
+			t.Fatalf("expected a deep-space orbit with resonance %d", test.irez)
+		}
+
+		// the radius must remain within the bounds of the orbit over ten days, either side of the epoch:
+		for minutes := -14400.0; minutes <= 14400; minutes += 37 {
+			p, _, err := s.Propagate(minutes)
+
+			if err != nil {
+				t.Fatalf("t = %f: got error %v", minutes, err)
+			}
+
+			if r := math.Sqrt(p.X*p.X + p.Y*p.Y + p.Z*p.Z); r < test.perigee || r > test.apogee {
+				t.Errorf("t = %f: got radius %f km, wanted between %f and %f km", minutes, r, test.perigee, test.apogee)
+				break
+			}
+		}
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestPropagateResonantVerification(t *testing.T) {
+	// the resonant deep-space test cases of Vallado et al., "Revisiting Spacetrack Report #3", SGP4-VER.TLE, with
+	// their state vectors at the epoch from tcppver.out:
+	tests := []struct {
+		lines [2]string
+		irez  int
+		r     [3]float64
+		v     [3]float64
+	}{
+		// Molniya 2-14, 12 hour resonant, with an eccentricity in the range 0.65 to 0.7:
+		{
+			[2]string{
+				"1 08195U 75081A   06176.33215444  .00000099  00000-0  11873-3 0   813",
+				"2 08195  64.1586 279.0717 6877146 264.7651  20.2257  2.00491383225656",
+			},
+			2,
+			[3]float64{2349.89483350, -14785.93811562, 0.02119378},
+			[3]float64{2.721488096, -3.256811655, 4.498416672},
+		},
+		// Molniya 1-36, 12 hour resonant, with an eccentricity in the range 0.7 to 0.715:
+		{
+			[2]string{
+				"1 09880U 77021A   06176.56157475  .00000421  00000-0  10000-3 0  9814",
+				"2 09880  64.5968 349.3786 7069051 270.0229  16.3320  2.00813614112380",
+			},
+			2,
+			[3]float64{13020.06750784, -2449.07193500, 1.15896030},
+			[3]float64{4.247363935, 1.597178501, 4.956708611},
+		},
+		// AMC-4, 24 hour resonant, in a geostationary orbit:
+		{
+			[2]string{
+				"1 25954U 99060A   04039.68057285 -.00000108  00000-0  00000-0 0  6847",
+				"2 25954   0.0004 243.8136 0001765  15.5294  22.7134  1.00271289 15615",
+			},
+			1,
+			[3]float64{8827.15660472, -41223.00971237, 3.63482963},
+			[3]float64{3.007087319, 0.643701323, 0.000941663},
+		},
+	}
+
+	for _, test := range tests {
+		s := newSatellite(t, test.lines)
+
+		if s.deep == nil || s.deep.irez != test.irez {
+			t.Fatalf("%s: expected a deep-space orbit with resonance %d", test.lines[0][2:7], test.irez)
+		}
+
+		assertStateVector(t, s, 0, test.r, test.v)
+	}
+
+	// the first step of the resonance integrator, for Molniya 2-14 at 120 minutes, from tcppver.out:
+	s := newSatellite(t, tests[0].lines)
+
+	p, _, err := s.Propagate(120)
+
+	if err != nil {
+		t.Fatalf("got error %v", err)
+	}
+
+	if math.Abs(p.X-15223.91713658) > 1e-6 || math.Abs(p.Y-(-17852.95881713)) > 1e-6 {
+		t.Errorf("t = 120: got %+v, wanted X = 15223.91713658, Y = -17852.95881713", p)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestPropagateGeosynchronousLongitude(t *testing.T) {
+	s := newSatellite(t, geosynchronous)
+
+	// a geosynchronous satellite remains close to a fixed longitude over a day:
+	longitude := func(minutes float64) float64 {
+		p, _, _ := s.Propagate(minutes)
+
+		e := convertTEMEToEarthFixedCoordinate(s.TLE.Epoch.Add(time.Duration(minutes*float64(time.Minute))), p)
+
+		return common.Degrees(math.Atan2(e.Y, e.X))
+	}
+
+	if Δ := math.Abs(math.Remainder(longitude(1440)-longitude(0), 360)); Δ > 0.5 {
+		t.Errorf("got a drift in longitude of %f degrees per day", Δ)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestNewSatelliteInvalid(t *testing.T) {
+	tle, _ := ParseTLE("", vanguard[0], vanguard[1])
+
+	tle.MeanMotion = 0
+
+	if _, err := NewSatellite(tle); err == nil {
+		t.Errorf("expected an error for a mean motion of zero")
+	}
+}
+
+/*****************************************************************************************************************/
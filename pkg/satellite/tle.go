@@ -0,0 +1,289 @@
+/*****************************************************************************************************************/
+
+//	@author		Michael Roberts <michael@observerly.com>
+//	@package	@observerly/sidera
+//	@license	Copyright © 2021-2024 observerly
+
+/*****************************************************************************************************************/
+
+package satellite
+
+/*****************************************************************************************************************/
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+/*****************************************************************************************************************/
+
+/*
+a two-line element set (TLE), as published by NORAD and CelesTrak
+
+The mean elements are the SGP4 mean elements at the epoch of the element set, referred to the True Equator,
+Mean Equinox (TEME) frame. The angles are in degrees and the mean motion is in revolutions per day. The first
+and second derivatives of the mean motion (divided by two and six, respectively, as printed) are in
+revolutions per day² and day³, and the drag term B* is in inverse Earth radii.
+*/
+type TLE struct {
+	Name                          string
+	CatalogNumber                 int
+	Classification                string
+	InternationalDesignator       string
+	Epoch                         time.Time
+	MeanMotionDot                 float64
+	MeanMotionDDot                float64
+	BStar                         float64
+	ElementSetNumber              int
+	Inclination                   float64
+	RightAscensionOfAscendingNode float64
+	Eccentricity                  float64
+	ArgumentOfPerigee             float64
+	MeanAnomaly                   float64
+	MeanMotion                    float64
+	RevolutionNumber              int
+}
+
+/*****************************************************************************************************************/
+
+/*
+the modulo 10 checksum of the first 68 characters of a TLE line, in which digits count their value, minus
+signs count one, and all other characters count zero
+*/
+func getChecksum(line string) int {
+	sum := 0
+
+	for _, c := range line[:68] {
+		switch {
+		case c >= '0' && c <= '9':
+			sum += int(c - '0')
+		case c == '-':
+			sum++
+		}
+	}
+
+	return sum % 10
+}
+
+/*****************************************************************************************************************/
+
+/*
+parses a value written in the TLE "assumed decimal point" exponential notation, e.g., " 12345-3" is 0.12345e-3
+*/
+func parseExponential(value string) (float64, error) {
+	value = strings.TrimSpace(value)
+
+	// a blank or all zero value, which is occasionally written without an exponent:
+	if strings.Trim(value, "0+- ") == "" {
+		return 0, nil
+	}
+
+	sign := 1.0
+
+	if value[0] == '-' || value[0] == '+' {
+		if value[0] == '-' {
+			sign = -1
+		}
+
+		value = value[1:]
+	}
+
+	// the exponent is the trailing signed digit:
+	i := strings.LastIndexAny(value, "+-")
+
+	if i < 1 {
+		return 0, fmt.Errorf("invalid exponential value: %q", value)
+	}
+
+	mantissa, err := strconv.ParseFloat("0."+strings.TrimSpace(value[:i]), 64)
+
+	if err != nil {
+		return 0, fmt.Errorf("invalid exponential value: %q", value)
+	}
+
+	exponent, err := strconv.Atoi(value[i:])
+
+	if err != nil {
+		return 0, fmt.Errorf("invalid exponential value: %q", value)
+	}
+
+	return sign * mantissa * math.Pow(10, float64(exponent)), nil
+}
+
+/*****************************************************************************************************************/
+
+/*
+parses the floating point value in the given (one-based, inclusive) columns of a TLE line
+*/
+func parseFloat(line string, start int, end int, field string) (float64, error) {
+	value, err := strconv.ParseFloat(strings.TrimSpace(line[start-1:end]), 64)
+
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s: %q", field, line[start-1:end])
+	}
+
+	return value, nil
+}
+
+/*****************************************************************************************************************/
+
+/*
+parses a two-line element set, with an optional name (the "title line" of the three-line format)
+
+Each line must be at least 68 characters long, and begin with its line number. When a line has a checksum in
+column 69, it is verified. The epoch year is interpreted as 1957-2056, following the NORAD convention.
+*/
+func ParseTLE(name string, line1 string, line2 string) (TLE, error) {
+	line1 = strings.TrimRight(line1, " \r\n")
+	line2 = strings.TrimRight(line2, " \r\n")
+
+	if len(line1) < 68 || line1[0] != '1' {
+		return TLE{}, fmt.Errorf("invalid TLE line 1: %q", line1)
+	}
+
+	if len(line2) < 68 || line2[0] != '2' {
+		return TLE{}, fmt.Errorf("invalid TLE line 2: %q", line2)
+	}
+
+	for i, line := range []string{line1, line2} {
+		if len(line) >= 69 && line[68] >= '0' && line[68] <= '9' && int(line[68]-'0') != getChecksum(line) {
+			return TLE{}, fmt.Errorf("invalid checksum for TLE line %d: got %c, wanted %d", i+1, line[68], getChecksum(line))
+		}
+	}
+
+	tle := TLE{
+		Name:                    strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(name), "0 ")),
+		Classification:          strings.TrimSpace(line1[7:8]),
+		InternationalDesignator: strings.TrimSpace(line1[9:17]),
+	}
+
+	var err error
+
+	if tle.CatalogNumber, err = strconv.Atoi(strings.TrimSpace(line1[2:7])); err != nil {
+		return TLE{}, fmt.Errorf("invalid catalog number: %q", line1[2:7])
+	}
+
+	if n, err := strconv.Atoi(strings.TrimSpace(line2[2:7])); err != nil || n != tle.CatalogNumber {
+		return TLE{}, fmt.Errorf("catalog numbers of TLE lines 1 and 2 do not match: %q, %q", line1[2:7], line2[2:7])
+	}
+
+	// the epoch, as a two digit year and a fractional day of the year:
+	year, err := strconv.Atoi(strings.TrimSpace(line1[18:20]))
+
+	if err != nil {
+		return TLE{}, fmt.Errorf("invalid epoch year: %q", line1[18:20])
+	}
+
+	day, err := parseFloat(line1, 21, 32, "epoch day")
+
+	if err != nil {
+		return TLE{}, err
+	}
+
+	if year < 57 {
+		year += 2000
+	} else {
+		year += 1900
+	}
+
+	tle.Epoch = time.Date(year, 1, 1, 0, 0, 0, 0, time.UTC).Add(time.Duration(math.Round((day-1)*86400*1e6)) * time.Microsecond)
+
+	if tle.MeanMotionDot, err = parseFloat(line1, 34, 43, "first derivative of the mean motion"); err != nil {
+		return TLE{}, err
+	}
+
+	if tle.MeanMotionDDot, err = parseExponential(line1[44:52]); err != nil {
+		return TLE{}, err
+	}
+
+	if tle.BStar, err = parseExponential(line1[53:61]); err != nil {
+		return TLE{}, err
+	}
+
+	tle.ElementSetNumber, _ = strconv.Atoi(strings.TrimSpace(line1[64:68]))
+
+	for _, field := range []struct {
+		value      *float64
+		start, end int
+		name       string
+	}{
+		{&tle.Inclination, 9, 16, "inclination"},
+		{&tle.RightAscensionOfAscendingNode, 18, 25, "right ascension of the ascending node"},
+		{&tle.ArgumentOfPerigee, 35, 42, "argument of perigee"},
+		{&tle.MeanAnomaly, 44, 51, "mean anomaly"},
+		{&tle.MeanMotion, 53, 63, "mean motion"},
+	} {
+		if *field.value, err = parseFloat(line2, field.start, field.end, field.name); err != nil {
+			return TLE{}, err
+		}
+	}
+
+	// the eccentricity, with an assumed leading decimal point:
+	if tle.Eccentricity, err = strconv.ParseFloat("0."+strings.TrimSpace(line2[26:33]), 64); err != nil {
+		return TLE{}, fmt.Errorf("invalid eccentricity: %q", line2[26:33])
+	}
+
+	tle.RevolutionNumber, _ = strconv.Atoi(strings.TrimSpace(line2[63:68]))
+
+	return tle, nil
+}
+
+/*****************************************************************************************************************/
+
+/*
+reads the two-line element sets from a file in either the two-line or three-line (named) format, e.g., as
+published by CelesTrak
+
+Blank lines are skipped. An error is returned for the first element set which cannot be parsed.
+*/
+func ReadTLEs(r io.Reader) ([]TLE, error) {
+	tles := []TLE{}
+
+	scanner := bufio.NewScanner(r)
+
+	name := ""
+
+	line1 := ""
+
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimRight(scanner.Text(), " \r")
+
+		switch {
+		case strings.TrimSpace(line) == "":
+			continue
+		case strings.HasPrefix(line, "1 ") && line1 == "":
+			line1 = line
+		case strings.HasPrefix(line, "2 ") && line1 != "":
+			tle, err := ParseTLE(name, line1, line)
+
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", n, err)
+			}
+
+			tles = append(tles, tle)
+
+			name, line1 = "", ""
+		case line1 == "":
+			name = line
+		default:
+			return nil, fmt.Errorf("line %d: expected TLE line 2, got %q", n, line)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if line1 != "" {
+		return nil, fmt.Errorf("incomplete TLE: missing line 2 for %q", line1)
+	}
+
+	return tles, nil
+}
+
+/*****************************************************************************************************************/
@@ -0,0 +1,132 @@
+/*****************************************************************************************************************/
+
+//	@author		Michael Roberts <michael@observerly.com>
+//	@package	@observerly/sidera
+//	@license	Copyright © 2021-2024 observerly
+
+/*****************************************************************************************************************/
+
+package satellite
+
+/*****************************************************************************************************************/
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+/*****************************************************************************************************************/
+
+var iss = [3]string{
+	"ISS (ZARYA)",
+	"1 25544U 98067A   24061.54791667  .00016717  00000-0  30306-3 0  9995",
+	"2 25544  51.6416 247.4627 0006703 130.5360 325.0288 15.49815872439656",
+}
+
+/*****************************************************************************************************************/
+
+func TestParseTLE(t *testing.T) {
+	tle, err := ParseTLE(iss[0], iss[1], iss[2])
+
+	if err != nil {
+		t.Fatalf("got error %v", err)
+	}
+
+	if tle.Name != "ISS (ZARYA)" || tle.CatalogNumber != 25544 || tle.Classification != "U" || tle.InternationalDesignator != "98067A" {
+		t.Errorf("got %+v", tle)
+	}
+
+	// day 61.54791667 of 2024 is 1 March 2024, 13:09:00 UTC:
+	if want := time.Date(2024, 3, 1, 13, 9, 0, 0, time.UTC); math.Abs(tle.Epoch.Sub(want).Seconds()) > 0.001 {
+		t.Errorf("got %v, wanted %v", tle.Epoch, want)
+	}
+
+	if tle.MeanMotionDot != 0.00016717 || tle.MeanMotionDDot != 0 || math.Abs(tle.BStar-0.30306e-3) > 1e-15 {
+		t.Errorf("got %f, %f, %f", tle.MeanMotionDot, tle.MeanMotionDDot, tle.BStar)
+	}
+
+	if tle.Inclination != 51.6416 || tle.RightAscensionOfAscendingNode != 247.4627 || tle.Eccentricity != 0.0006703 {
+		t.Errorf("got %+v", tle)
+	}
+
+	if tle.ArgumentOfPerigee != 130.5360 || tle.MeanAnomaly != 325.0288 || tle.MeanMotion != 15.49815872 {
+		t.Errorf("got %+v", tle)
+	}
+
+	if tle.ElementSetNumber != 999 || tle.RevolutionNumber != 43965 {
+		t.Errorf("got %d, %d, wanted 999, 43965", tle.ElementSetNumber, tle.RevolutionNumber)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestParseTLEInvalid(t *testing.T) {
+	if _, err := ParseTLE("", iss[1][:60], iss[2]); err == nil {
+		t.Errorf("expected an error for a truncated line")
+	}
+
+	if _, err := ParseTLE("", iss[2], iss[1]); err == nil {
+		t.Errorf("expected an error for lines in the wrong order")
+	}
+
+	if _, err := ParseTLE("", iss[1][:68]+"4", iss[2]); err == nil {
+		t.Errorf("expected an error for an invalid checksum")
+	}
+
+	if _, err := ParseTLE("", iss[1], strings.Replace(iss[2], "25544", "25545", 1)); err == nil {
+		t.Errorf("expected an error for mismatched catalog numbers")
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestParseExponential(t *testing.T) {
+	tests := map[string]float64{
+		" 28098-4": 0.28098e-4,
+		"-11606-4": -0.11606e-4,
+		" 00000-0": 0,
+		" 00000+0": 0,
+		"        ": 0,
+		" 12345+1": 1.2345,
+	}
+
+	for value, want := range tests {
+		got, err := parseExponential(value)
+
+		if err != nil {
+			t.Errorf("%q: got error %v", value, err)
+		}
+
+		if math.Abs(got-want) > 1e-15 {
+			t.Errorf("%q: got %e, wanted %e", value, got, want)
+		}
+	}
+
+	if _, err := parseExponential(" 1x345-4"); err == nil {
+		t.Errorf("expected an error for an invalid mantissa")
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestReadTLEs(t *testing.T) {
+	file := strings.Join([]string{iss[0], iss[1], iss[2], "", vanguard[0], vanguard[1]}, "\r\n")
+
+	tles, err := ReadTLEs(strings.NewReader(file))
+
+	if err != nil {
+		t.Fatalf("got error %v", err)
+	}
+
+	if len(tles) != 2 || tles[0].Name != "ISS (ZARYA)" || tles[1].Name != "" || tles[1].CatalogNumber != 5 {
+		t.Errorf("got %+v, wanted the ISS and Vanguard 1", tles)
+	}
+
+	if _, err := ReadTLEs(strings.NewReader(iss[0] + "\n" + iss[1] + "\n")); err == nil {
+		t.Errorf("expected an error for an incomplete element set")
+	}
+}
+
+/*****************************************************************************************************************/
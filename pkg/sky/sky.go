@@ -0,0 +1,227 @@
+/*****************************************************************************************************************/
+
+//	@author		Michael Roberts <michael@observerly.com>
+//	@package	@observerly/sidera
+//	@license	Copyright © 2021-2024 observerly
+
+/*****************************************************************************************************************/
+
+package sky
+
+/*****************************************************************************************************************/
+
+import (
+	"math"
+	"time"
+
+	"github.com/observerly/sidera/pkg/astrometry"
+	"github.com/observerly/sidera/pkg/common"
+	"github.com/observerly/sidera/pkg/coordinates"
+	moon "github.com/observerly/sidera/pkg/lunar"
+	sun "github.com/observerly/sidera/pkg/solar"
+)
+
+/*****************************************************************************************************************/
+
+// the V-band surface brightness of the dark sky at the zenith, in magnitudes per square arcsecond, at Mauna Kea:
+const DARK_SKY_BRIGHTNESS float64 = 21.587
+
+/*****************************************************************************************************************/
+
+// the V-band extinction coefficient, in magnitudes per airmass, at Mauna Kea:
+const EXTINCTION_COEFFICIENT float64 = 0.172
+
+/*****************************************************************************************************************/
+
+/*
+the conditions which determine the brightness of the sky at the position of a target
+
+The phase angle of the Moon, the separation of the Moon from the target and the altitudes of the Moon, the target
+and the Sun are in degrees. The dark sky brightness is the V-band surface brightness of the moonless night sky at
+the zenith, in magnitudes per square arcsecond, and the extinction coefficient is in magnitudes per airmass.
+*/
+type Conditions struct {
+	MoonPhaseAngle        float64
+	MoonTargetSeparation  float64
+	MoonAltitude          float64
+	TargetAltitude        float64
+	SunAltitude           float64
+	DarkSkyBrightness     float64
+	ExtinctionCoefficient float64
+}
+
+/*****************************************************************************************************************/
+
+/*
+converts a surface brightness in V magnitudes per square arcsecond to nanolamberts
+*/
+func ConvertMagnitudeToNanolamberts(magnitude float64) float64 {
+	return 34.08 * math.Exp(20.7233-0.92104*magnitude)
+}
+
+/*****************************************************************************************************************/
+
+/*
+converts a surface brightness in nanolamberts to V magnitudes per square arcsecond
+*/
+func ConvertNanolambertsToMagnitude(brightness float64) float64 {
+	return (20.7233 - math.Log(brightness/34.08)) / 0.92104
+}
+
+/*****************************************************************************************************************/
+
+/*
+the optical pathlength through the atmosphere, in airmasses, for a given altitude, in degrees
+
+The pathlength is that of Krisciunas & Schaefer (1991), for a homogeneous spherical atmosphere, which is finite
+(around 5) at the horizon, and so is suited to the scattering of moonlight from close to the horizon.
+*/
+func getOpticalPathlength(altitude float64) float64 {
+	z := common.Radians(90 - math.Max(altitude, 0))
+
+	return 1 / math.Sqrt(1-0.96*math.Pow(math.Sin(z), 2))
+}
+
+/*****************************************************************************************************************/
+
+/*
+the illuminance of the Moon outside the atmosphere, in foot-candles, for a given phase angle, in degrees,
+including the opposition effect within 7 degrees of full Moon
+*/
+func getMoonIlluminance(phaseAngle float64) float64 {
+	α := math.Abs(phaseAngle)
+
+	I := math.Pow(10, -0.4*(3.84+0.026*α+4e-9*math.Pow(α, 4)))
+
+	if α < 7 {
+		I *= 1.35 - 0.05*α
+	}
+
+	return I
+}
+
+/*****************************************************************************************************************/
+
+/*
+the scattering function of the atmosphere for a given scattering angle, in degrees, as the sum of the Rayleigh
+scattering by molecules and the Mie scattering by aerosols
+*/
+func getScatteringFunction(separation float64) float64 {
+	ρ := common.Radians(separation)
+
+	return math.Pow(10, 5.36)*(1.06+math.Pow(math.Cos(ρ), 2)) + math.Pow(10, 6.15-separation/40)
+}
+
+/*****************************************************************************************************************/
+
+/*
+the brightness of the moonlit sky at the position of a target, in nanolamberts, for the given conditions
+
+See Krisciunas & Schaefer, "A model of the brightness of moonlight", PASP 103, 1033 (1991), which is accurate to
+around 8–23% in the V band for separations of more than 10 degrees from the Moon. The brightness is zero when the
+Moon is below the horizon.
+*/
+func GetMoonlightBrightness(conditions Conditions) float64 {
+	if conditions.MoonAltitude < 0 {
+		return 0
+	}
+
+	k := conditions.ExtinctionCoefficient
+
+	I := getMoonIlluminance(conditions.MoonPhaseAngle)
+
+	f := getScatteringFunction(conditions.MoonTargetSeparation)
+
+	Xm := getOpticalPathlength(conditions.MoonAltitude)
+
+	X := getOpticalPathlength(conditions.TargetAltitude)
+
+	return f * I * math.Pow(10, -0.4*k*Xm) * (1 - math.Pow(10, -0.4*k*X))
+}
+
+/*****************************************************************************************************************/
+
+/*
+the brightness of the dark sky at the position of a target, in nanolamberts, for the given conditions
+
+The dark sky brightens towards the horizon, as the pathlength through the emitting layers of the atmosphere
+increases, though less than in proportion to the pathlength, as the light is also extinguished along it.
+*/
+func GetDarkSkyBrightness(conditions Conditions) float64 {
+	X := getOpticalPathlength(conditions.TargetAltitude)
+
+	return ConvertMagnitudeToNanolamberts(conditions.DarkSkyBrightness) *
+		math.Pow(10, -0.4*conditions.ExtinctionCoefficient*(X-1)) * X
+}
+
+/*****************************************************************************************************************/
+
+/*
+the brightness of the twilit sky at the zenith, in nanolamberts, in excess of the dark sky, for the given
+conditions
+
+The twilight is the polynomial fit of Thorstensen's skycalc (ztwilight) to the zenith twilight brightness of
+Meinel & Meinel, "Sunsets, Twilights, and Evening Skies" (1983), in magnitudes brighter than the dark sky, for
+altitudes of the Sun between the horizon and -18 degrees, below which the twilight is zero. The twilight is taken
+as uniform across the sky.
+*/
+func GetTwilightBrightness(conditions Conditions) float64 {
+	h := conditions.SunAltitude
+
+	if h < -18 {
+		return 0
+	}
+
+	y := (-math.Min(h, 0) - 9) / 9
+
+	// the number of magnitudes by which the twilit sky is brighter than the dark sky:
+	Δm := ((2.0635175*y+1.246602)*y-9.4084495)*y + 6.132725
+
+	return ConvertMagnitudeToNanolamberts(conditions.DarkSkyBrightness) * (math.Pow(10, 0.4*math.Max(Δm, 0)) - 1)
+}
+
+/*****************************************************************************************************************/
+
+/*
+the V-band surface brightness of the sky at the position of a target, in magnitudes per square arcsecond, for the
+given conditions
+
+The brightness is the sum of the dark sky, the moonlight scattered by the atmosphere, following Krisciunas &
+Schaefer (1991), and the twilight, and so is a measure of the background against which the target is observed.
+*/
+func GetSkyBrightness(conditions Conditions) float64 {
+	B := GetDarkSkyBrightness(conditions) + GetMoonlightBrightness(conditions) + GetTwilightBrightness(conditions)
+
+	return ConvertNanolambertsToMagnitude(B)
+}
+
+/*****************************************************************************************************************/
+
+/*
+the conditions for a target at a given datetime and observer, for a given dark sky brightness and extinction
+coefficient, from the positions of the Sun, the Moon and the target
+
+The altitude of the Sun is from its apparent position, rather than its mean longitude, as the brightness of the
+twilight changes by around a magnitude for each degree of solar altitude.
+*/
+func GetConditions(
+	datetime time.Time,
+	observer common.GeographicCoordinate,
+	target common.EquatorialCoordinate,
+	darkSkyBrightness float64,
+	extinctionCoefficient float64,
+) Conditions {
+	eq := moon.GetEquatorialCoordinate(datetime)
+
+	return Conditions{
+		MoonPhaseAngle:        moon.GetPhaseAngle(datetime),
+		MoonTargetSeparation:  astrometry.GetAngularSeparation(eq, target),
+		MoonAltitude:          coordinates.ConvertEquatorialToHorizontalCoordinate(datetime, observer, eq).Altitude,
+		TargetAltitude:        coordinates.ConvertEquatorialToHorizontalCoordinate(datetime, observer, target).Altitude,
+		SunAltitude:           sun.GetApparentHorizontalCoordinate(datetime, observer).Altitude,
+		DarkSkyBrightness:     darkSkyBrightness,
+		ExtinctionCoefficient: extinctionCoefficient,
+	}
+}
+
+/*****************************************************************************************************************/
@@ -0,0 +1,256 @@
+/*****************************************************************************************************************/
+
+//	@author		Michael Roberts <michael@observerly.com>
+//	@package	@observerly/sidera
+//	@license	Copyright © 2021-2024 observerly
+
+/*****************************************************************************************************************/
+
+package sky
+
+/*****************************************************************************************************************/
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/observerly/sidera/pkg/common"
+)
+
+/*****************************************************************************************************************/
+
+var observer common.GeographicCoordinate = common.GeographicCoordinate{
+	Latitude:  19.8207,
+	Longitude: -155.468094,
+	Elevation: 4205,
+}
+
+/*****************************************************************************************************************/
+
+// the conditions at full Moon, with the Moon and the target high in the sky, and the Sun well below the horizon:
+var moonlit Conditions = Conditions{
+	MoonPhaseAngle:        0,
+	MoonTargetSeparation:  45,
+	MoonAltitude:          60,
+	TargetAltitude:        60,
+	SunAltitude:           -30,
+	DarkSkyBrightness:     DARK_SKY_BRIGHTNESS,
+	ExtinctionCoefficient: EXTINCTION_COEFFICIENT,
+}
+
+/*****************************************************************************************************************/
+
+func TestConvertMagnitudeToNanolamberts(t *testing.T) {
+	var got float64 = ConvertMagnitudeToNanolamberts(DARK_SKY_BRIGHTNESS)
+
+	var want float64 = 79.0
+
+	if math.Abs(got-want) > 0.1 {
+		t.Errorf("got %f, wanted %f", got, want)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestConvertNanolambertsToMagnitude(t *testing.T) {
+	var got float64 = ConvertNanolambertsToMagnitude(ConvertMagnitudeToNanolamberts(DARK_SKY_BRIGHTNESS))
+
+	var want float64 = DARK_SKY_BRIGHTNESS
+
+	if math.Abs(got-want) > 1e-12 {
+		t.Errorf("got %f, wanted %f", got, want)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetSkyBrightnessDarkZenith(t *testing.T) {
+	conditions := moonlit
+
+	conditions.MoonAltitude = -10
+
+	conditions.TargetAltitude = 90
+
+	// the sky at the zenith, with the Moon below the horizon and no twilight, is the dark sky brightness:
+	var got float64 = GetSkyBrightness(conditions)
+
+	var want float64 = DARK_SKY_BRIGHTNESS
+
+	if math.Abs(got-want) > 1e-9 {
+		t.Errorf("got %f, wanted %f", got, want)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetSkyBrightnessDarkHorizon(t *testing.T) {
+	conditions := moonlit
+
+	conditions.MoonAltitude = -10
+
+	conditions.TargetAltitude = 10
+
+	// the dark sky brightens towards the horizon:
+	var got float64 = GetSkyBrightness(conditions)
+
+	if got >= DARK_SKY_BRIGHTNESS || got < DARK_SKY_BRIGHTNESS-1.5 {
+		t.Errorf("got %f, wanted between %f and %f", got, DARK_SKY_BRIGHTNESS-1.5, DARK_SKY_BRIGHTNESS)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetSkyBrightnessFullMoon(t *testing.T) {
+	// the sky 45 degrees from the full Moon is around 3.7 magnitudes brighter than the dark sky:
+	var got float64 = GetSkyBrightness(moonlit)
+
+	var want float64 = 17.8
+
+	if math.Abs(got-want) > 0.1 {
+		t.Errorf("got %f, wanted %f", got, want)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetSkyBrightnessWithMoonPhase(t *testing.T) {
+	conditions := moonlit
+
+	previous := 0.0
+
+	// the sky darkens as the Moon wanes from full to new:
+	for α := 0.0; α <= 180; α += 10 {
+		conditions.MoonPhaseAngle = α
+
+		got := GetSkyBrightness(conditions)
+
+		if got <= previous {
+			t.Errorf("got %f at phase angle %f, wanted fainter than %f", got, α, previous)
+		}
+
+		previous = got
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetSkyBrightnessWithMoonTargetSeparation(t *testing.T) {
+	conditions := moonlit
+
+	conditions.MoonAltitude = 45
+
+	conditions.TargetAltitude = 45
+
+	previous := 0.0
+
+	// the sky darkens away from the Moon, out to a separation of around 90 degrees:
+	for ρ := 10.0; ρ <= 90; ρ += 10 {
+		conditions.MoonTargetSeparation = ρ
+
+		got := GetSkyBrightness(conditions)
+
+		if got <= previous {
+			t.Errorf("got %f at separation %f, wanted fainter than %f", got, ρ, previous)
+		}
+
+		previous = got
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetMoonlightBrightnessMoonBelowHorizon(t *testing.T) {
+	conditions := moonlit
+
+	conditions.MoonAltitude = -0.1
+
+	if got := GetMoonlightBrightness(conditions); got != 0 {
+		t.Errorf("got %f, wanted 0", got)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetTwilightBrightness(t *testing.T) {
+	conditions := moonlit
+
+	conditions.MoonAltitude = -10
+
+	// there is no twilight when the Sun is more than 18 degrees below the horizon:
+	conditions.SunAltitude = -18.1
+
+	if got := GetTwilightBrightness(conditions); got != 0 {
+		t.Errorf("got %f, wanted 0", got)
+	}
+
+	previous := math.Inf(1)
+
+	// the sky brightens through twilight as the Sun rises towards the horizon:
+	for h := -18.0; h <= 0; h += 1 {
+		conditions.SunAltitude = h
+
+		got := GetSkyBrightness(conditions)
+
+		if got >= previous {
+			t.Errorf("got %f at solar altitude %f, wanted brighter than %f", got, h, previous)
+		}
+
+		previous = got
+	}
+
+	// at the end of nautical twilight, the sky is around three magnitudes brighter than the dark sky:
+	conditions.SunAltitude = -12
+
+	if got := GetSkyBrightness(conditions); math.Abs(got-18.4) > 0.1 {
+		t.Errorf("got %f, wanted %f", got, 18.4)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetConditions(t *testing.T) {
+	// around midnight in Hawaii, ten hours after the full Moon of 23 April 2024 at 23:49 UTC:
+	datetime := time.Date(2024, 4, 24, 10, 0, 0, 0, time.UTC)
+
+	target := common.EquatorialCoordinate{RightAscension: 88.792939, Declination: 7.407064}
+
+	conditions := GetConditions(datetime, observer, target, DARK_SKY_BRIGHTNESS, EXTINCTION_COEFFICIENT)
+
+	if conditions.MoonPhaseAngle > 8 {
+		t.Errorf("got phase angle %f, wanted less than 8", conditions.MoonPhaseAngle)
+	}
+
+	if conditions.MoonAltitude < 30 {
+		t.Errorf("got lunar altitude %f, wanted above 30", conditions.MoonAltitude)
+	}
+
+	if conditions.SunAltitude > -10 {
+		t.Errorf("got solar altitude %f, wanted below -10", conditions.SunAltitude)
+	}
+
+	if conditions.DarkSkyBrightness != DARK_SKY_BRIGHTNESS || conditions.ExtinctionCoefficient != EXTINCTION_COEFFICIENT {
+		t.Errorf("got %f and %f, wanted the given dark sky brightness and extinction", conditions.DarkSkyBrightness, conditions.ExtinctionCoefficient)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetConditionsEquinoxTwilight(t *testing.T) {
+	// an observer on the equator, at the Greenwich meridian, at the end of astronomical twilight on the March
+	// equinox of 2021, i.e., 1h 12m after the geometric sunset of 18:07:30 for the equation of time of -7m 30s:
+	equator := common.GeographicCoordinate{Latitude: 0, Longitude: 0, Elevation: 0}
+
+	datetime := time.Date(2021, 3, 20, 19, 19, 30, 0, time.UTC)
+
+	target := common.EquatorialCoordinate{RightAscension: 88.792939, Declination: 7.407064}
+
+	conditions := GetConditions(datetime, equator, target, DARK_SKY_BRIGHTNESS, EXTINCTION_COEFFICIENT)
+
+	// to within a quarter of a degree, i.e., a minute of time:
+	if math.Abs(conditions.SunAltitude-(-18)) > 0.25 {
+		t.Errorf("got solar altitude %f, wanted %f", conditions.SunAltitude, -18.0)
+	}
+}
+
+/*****************************************************************************************************************/
@@ -0,0 +1,289 @@
+/*****************************************************************************************************************/
+
+//	@author		Michael Roberts <michael@observerly.com>
+//	@package	@observerly/sidera
+//	@license	Copyright © 2021-2024 observerly
+
+/*****************************************************************************************************************/
+
+package sun
+
+/*****************************************************************************************************************/
+
+import (
+	"math"
+	"time"
+
+	"github.com/observerly/sidera/pkg/astrometry"
+	"github.com/observerly/sidera/pkg/common"
+	"github.com/observerly/sidera/pkg/epoch"
+)
+
+/*****************************************************************************************************************/
+
+// the inclination of the solar equator to the ecliptic, in degrees:
+const SOLAR_EQUATOR_INCLINATION float64 = 7.25
+
+/*****************************************************************************************************************/
+
+// the radius of the Sun, in kilometres, corresponding to a semi-diameter of 959.63 arcseconds at 1 AU:
+const SOLAR_RADIUS float64 = 696000
+
+/*****************************************************************************************************************/
+
+// the sidereal period of the Carrington rotation, in days:
+const CARRINGTON_SIDEREAL_PERIOD float64 = 25.38
+
+/*****************************************************************************************************************/
+
+// the mean synodic period of the Carrington rotation, in days:
+const CARRINGTON_SYNODIC_PERIOD float64 = 27.2752316
+
+/*****************************************************************************************************************/
+
+/*
+a heliographic coordinate, i.e., a position on the surface of the Sun, in degrees
+
+The heliographic latitude is measured from the solar equator, positive towards the north pole of the Sun's
+rotation axis. The heliographic longitude is the Stonyhurst longitude, measured from the central meridian of
+the disk as seen from the Earth, positive towards the west limb. The Carrington longitude, which is fixed to
+the rotating Sun, is found by adding the heliographic longitude of the centre of the disk (L0).
+*/
+type HeliographicCoordinate struct {
+	Longitude float64
+	Latitude  float64
+}
+
+/*****************************************************************************************************************/
+
+/*
+a helioprojective coordinate, i.e., an angular position on the sky relative to the centre of the solar disk, in
+arcseconds
+
+The X axis is positive towards the west limb and the Y axis is positive towards the north, where north is along
+the projection of the Sun's rotation axis onto the sky (rather than towards the celestial pole).
+*/
+type HelioprojectiveCoordinate struct {
+	X float64
+	Y float64
+}
+
+/*****************************************************************************************************************/
+
+/*
+the apparent ecliptic longitude of the Sun, corrected for aberration and nutation, the true obliquity of the
+ecliptic, and the longitude of the ascending node of the solar equator on the ecliptic, for a given datetime,
+all in radians
+*/
+func getOrientation(datetime time.Time) (λ float64, ε float64, K float64) {
+	JD := epoch.GetTerrestrialTimeJulianDate(datetime)
+
+	Δψ, Δε := astrometry.GetNutation(datetime)
+
+	// the apparent longitude of the Sun, corrected for the annual aberration of -20.4898" / R:
+	λ = common.Radians(GetTrueEclipticLongitude(datetime) - 20.4898/3600/GetDistance(datetime) + Δψ)
+
+	ε = common.Radians(astrometry.GetObliquityOfTheEcliptic(datetime) + Δε)
+
+	K = common.Radians(73.6667 + 1.3958333*(JD-2396758)/36525)
+
+	return λ, ε, K
+}
+
+/*****************************************************************************************************************/
+
+/*
+the Position Angle of the northern extremity of the Sun's rotation axis for a given datetime, in degrees
+
+The Position Angle (P) is measured eastwards from the north point of the disk, i.e., from the direction of the
+north celestial pole, and varies between approximately -26.3 degrees in early October and +26.3 degrees in early
+April. See Meeus, "Astronomical Algorithms", Chapter 29.
+*/
+func GetPositionAngleOfAxis(datetime time.Time) float64 {
+	λ, ε, K := getOrientation(datetime)
+
+	I := common.Radians(SOLAR_EQUATOR_INCLINATION)
+
+	x := math.Atan(-math.Cos(λ) * math.Tan(ε))
+
+	y := math.Atan(-math.Cos(λ-K) * math.Tan(I))
+
+	return common.Degrees(x + y)
+}
+
+/*****************************************************************************************************************/
+
+/*
+the Heliographic Latitude of the centre of the solar disk for a given datetime, in degrees
+
+The Heliographic Latitude of the centre of the disk (B0) is the latitude of the sub-Earth point on the Sun, and
+varies between approximately -7.25 degrees in early March and +7.25 degrees in early September, as a result of
+the inclination of the solar equator to the ecliptic. See Meeus, "Astronomical Algorithms", Chapter 29.
+*/
+func GetHeliographicLatitude(datetime time.Time) float64 {
+	λ, _, K := getOrientation(datetime)
+
+	I := common.Radians(SOLAR_EQUATOR_INCLINATION)
+
+	return common.Degrees(math.Asin(math.Sin(λ-K) * math.Sin(I)))
+}
+
+/*****************************************************************************************************************/
+
+/*
+the Heliographic Longitude of the centre of the solar disk for a given datetime, in degrees
+
+The Heliographic Longitude of the centre of the disk (L0) is the Carrington longitude of the sub-Earth point on
+the Sun, which decreases by approximately 13.2 degrees per day as the Sun rotates. Carrington longitudes are
+referred to the meridian which passed through the ascending node of the solar equator at 12h on 1 January 1854,
+rotating with a sidereal period of 25.38 days. See Meeus, "Astronomical Algorithms", Chapter 29.
+*/
+func GetHeliographicLongitude(datetime time.Time) float64 {
+	JD := epoch.GetTerrestrialTimeJulianDate(datetime)
+
+	λ, _, K := getOrientation(datetime)
+
+	I := common.Radians(SOLAR_EQUATOR_INCLINATION)
+
+	// the longitude of the sub-Earth point measured from the ascending node of the solar equator:
+	η := common.Degrees(math.Atan2(-math.Sin(λ-K)*math.Cos(I), -math.Cos(λ-K)))
+
+	// the angle through which the Carrington prime meridian has rotated from the ascending node:
+	θ := (JD - 2398220) * 360 / CARRINGTON_SIDEREAL_PERIOD
+
+	L := math.Mod(η-θ, 360)
+
+	// applies modulo correction to the angle, and ensures always positive:
+	if L < 0 {
+		L += 360
+	}
+
+	return L
+}
+
+/*****************************************************************************************************************/
+
+/*
+the Carrington Rotation number for a given datetime
+
+The integer part is the number of the synodic rotation in progress, counted from the rotation which began on
+9 November 1853, and the fractional part is the fraction of that rotation which has elapsed. Each rotation
+begins when the Carrington longitude of the centre of the disk (L0) passes through zero degrees.
+*/
+func GetCarringtonRotation(datetime time.Time) float64 {
+	JD := epoch.GetTerrestrialTimeJulianDate(datetime)
+
+	// the fraction of the current rotation which has elapsed, as L0 decreases from 360 to zero degrees:
+	f := (360 - GetHeliographicLongitude(datetime)) / 360
+
+	// the approximate rotation number from the mean synodic period, which is within a day of the true value:
+	C := (JD - 2398140.2270) / CARRINGTON_SYNODIC_PERIOD
+
+	return math.Round(C-f) + f
+}
+
+/*****************************************************************************************************************/
+
+/*
+the Carrington longitude of a heliographic coordinate for a given datetime, in degrees
+
+The Carrington longitude is fixed to the rotating Sun, and is the sum of the heliographic (Stonyhurst) longitude
+and the Carrington longitude of the centre of the disk (L0).
+*/
+func GetCarringtonLongitude(datetime time.Time, coordinate HeliographicCoordinate) float64 {
+	L := math.Mod(coordinate.Longitude+GetHeliographicLongitude(datetime), 360)
+
+	// applies modulo correction to the angle, and ensures always positive:
+	if L < 0 {
+		L += 360
+	}
+
+	return L
+}
+
+/*****************************************************************************************************************/
+
+/*
+converts a helioprojective coordinate to the heliographic coordinate of the point on the solar surface for a
+given datetime
+
+The conversion is made by projecting the line of sight from the Earth onto the photosphere, as a sphere of radius
+696,000 km, following Thompson, "Coordinate systems for solar image data", A&A 449, 791 (2006). The returned
+boolean is false if the line of sight does not intersect the solar disk, i.e., the position is off the limb.
+*/
+func ConvertHelioprojectiveToHeliographicCoordinate(
+	datetime time.Time,
+	coordinate HelioprojectiveCoordinate,
+) (HeliographicCoordinate, bool) {
+	B0 := common.Radians(GetHeliographicLatitude(datetime))
+
+	// the distance of the observer from the centre of the Sun, in kilometres:
+	D := GetDistance(datetime) * common.AU
+
+	θx := common.Radians(coordinate.X / 3600)
+
+	θy := common.Radians(coordinate.Y / 3600)
+
+	// the distance along the line of sight to the nearer intersection with the photosphere:
+	b := D * math.Cos(θy) * math.Cos(θx)
+
+	Δ := math.Pow(b, 2) - math.Pow(D, 2) + math.Pow(SOLAR_RADIUS, 2)
+
+	if Δ < 0 {
+		return HeliographicCoordinate{}, false
+	}
+
+	d := b - math.Sqrt(Δ)
+
+	// the heliocentric cartesian position, where z is towards the observer and y is towards solar north:
+	x := d * math.Cos(θy) * math.Sin(θx)
+
+	y := d * math.Sin(θy)
+
+	z := D - d*math.Cos(θy)*math.Cos(θx)
+
+	return HeliographicCoordinate{
+		Longitude: common.Degrees(math.Atan2(x, z*math.Cos(B0)-y*math.Sin(B0))),
+		Latitude:  common.Degrees(math.Asin(math.Max(-1, math.Min(1, (y*math.Cos(B0)+z*math.Sin(B0))/SOLAR_RADIUS)))),
+	}, true
+}
+
+/*****************************************************************************************************************/
+
+/*
+converts a heliographic coordinate of a point on the solar surface to a helioprojective coordinate for a given
+datetime
+
+The returned boolean is false if the point is on the far side of the Sun, i.e., is hidden behind the limb as
+seen from the Earth.
+*/
+func ConvertHeliographicToHelioprojectiveCoordinate(
+	datetime time.Time,
+	coordinate HeliographicCoordinate,
+) (HelioprojectiveCoordinate, bool) {
+	B0 := common.Radians(GetHeliographicLatitude(datetime))
+
+	// the distance of the observer from the centre of the Sun, in kilometres:
+	D := GetDistance(datetime) * common.AU
+
+	Θ := common.Radians(coordinate.Latitude)
+
+	Φ := common.Radians(coordinate.Longitude)
+
+	// the heliocentric cartesian position, where z is towards the observer and y is towards solar north:
+	x := SOLAR_RADIUS * math.Cos(Θ) * math.Sin(Φ)
+
+	y := SOLAR_RADIUS * (math.Sin(Θ)*math.Cos(B0) - math.Cos(Θ)*math.Cos(Φ)*math.Sin(B0))
+
+	z := SOLAR_RADIUS * (math.Sin(Θ)*math.Sin(B0) + math.Cos(Θ)*math.Cos(Φ)*math.Cos(B0))
+
+	// the distance of the point from the observer:
+	d := math.Sqrt(math.Pow(x, 2) + math.Pow(y, 2) + math.Pow(D-z, 2))
+
+	return HelioprojectiveCoordinate{
+		X: common.Degrees(math.Atan2(x, D-z)) * 3600,
+		Y: common.Degrees(math.Asin(y/d)) * 3600,
+	}, z >= math.Pow(SOLAR_RADIUS, 2)/D
+}
+
+/*****************************************************************************************************************/
@@ -0,0 +1,162 @@
+/*****************************************************************************************************************/
+
+//	@author		Michael Roberts <michael@observerly.com>
+//	@package	@observerly/sidera
+//	@license	Copyright © 2021-2024 observerly
+
+/*****************************************************************************************************************/
+
+package sun
+
+/*****************************************************************************************************************/
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+/*****************************************************************************************************************/
+
+// Meeus, "Astronomical Algorithms", Example 29.a, 1992 October 13.0 TD:
+var physical time.Time = time.Date(1992, 10, 13, 0, 0, 0, 0, time.UTC)
+
+/*****************************************************************************************************************/
+
+func TestGetSolarPositionAngleOfAxis(t *testing.T) {
+	var got float64 = GetPositionAngleOfAxis(physical)
+
+	var want float64 = 26.27
+
+	if math.Abs(got-want) > 0.01 {
+		t.Errorf("got %f, wanted %f", got, want)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetSolarHeliographicLatitude(t *testing.T) {
+	var got float64 = GetHeliographicLatitude(physical)
+
+	var want float64 = 5.99
+
+	if math.Abs(got-want) > 0.01 {
+		t.Errorf("got %f, wanted %f", got, want)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetSolarHeliographicLongitude(t *testing.T) {
+	var got float64 = GetHeliographicLongitude(physical)
+
+	var want float64 = 238.63
+
+	if math.Abs(got-want) > 0.02 {
+		t.Errorf("got %f, wanted %f", got, want)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetSolarCarringtonRotation(t *testing.T) {
+	var got float64 = GetCarringtonRotation(physical)
+
+	var want float64 = 1861 + (360-238.63)/360
+
+	if math.Abs(got-want) > 0.0001 {
+		t.Errorf("got %f, wanted %f", got, want)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetSolarCarringtonRotationBoundary(t *testing.T) {
+	// Carrington rotation 2000 began on 2003 February 20 at around 07h UT:
+	if got := GetCarringtonRotation(time.Date(2003, 2, 20, 0, 0, 0, 0, time.UTC)); math.Floor(got) != 1999 {
+		t.Errorf("got %f, wanted rotation 1999", got)
+	}
+
+	if got := GetCarringtonRotation(time.Date(2003, 2, 20, 12, 0, 0, 0, time.UTC)); math.Floor(got) != 2000 {
+		t.Errorf("got %f, wanted rotation 2000", got)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetSolarCarringtonLongitude(t *testing.T) {
+	var got float64 = GetCarringtonLongitude(physical, HeliographicCoordinate{Longitude: 150, Latitude: 10})
+
+	var want float64 = 28.63
+
+	if math.Abs(got-want) > 0.02 {
+		t.Errorf("got %f, wanted %f", got, want)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestConvertHelioprojectiveToHeliographicCoordinateDiskCentre(t *testing.T) {
+	got, ok := ConvertHelioprojectiveToHeliographicCoordinate(physical, HelioprojectiveCoordinate{X: 0, Y: 0})
+
+	if !ok {
+		t.Fatalf("expected the centre of the disk to be on the Sun")
+	}
+
+	// the centre of the disk is at the central meridian, at the heliographic latitude B0:
+	if math.Abs(got.Longitude) > 1e-9 {
+		t.Errorf("got %f, wanted %f", got.Longitude, 0.0)
+	}
+
+	if math.Abs(got.Latitude-GetHeliographicLatitude(physical)) > 1e-9 {
+		t.Errorf("got %f, wanted %f", got.Latitude, GetHeliographicLatitude(physical))
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestConvertHelioprojectiveToHeliographicCoordinateOffLimb(t *testing.T) {
+	_, ok := ConvertHelioprojectiveToHeliographicCoordinate(physical, HelioprojectiveCoordinate{X: 1000, Y: 0})
+
+	if ok {
+		t.Errorf("expected a position beyond the limb to be off the Sun")
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestConvertHeliographicToHelioprojectiveCoordinateRoundTrip(t *testing.T) {
+	want := HelioprojectiveCoordinate{X: -400, Y: 300}
+
+	hg, ok := ConvertHelioprojectiveToHeliographicCoordinate(physical, want)
+
+	if !ok {
+		t.Fatalf("expected the position to be on the Sun")
+	}
+
+	got, ok := ConvertHeliographicToHelioprojectiveCoordinate(physical, hg)
+
+	if !ok {
+		t.Fatalf("expected the position to be visible")
+	}
+
+	if math.Abs(got.X-want.X) > 1e-6 {
+		t.Errorf("got %f, wanted %f", got.X, want.X)
+	}
+
+	if math.Abs(got.Y-want.Y) > 1e-6 {
+		t.Errorf("got %f, wanted %f", got.Y, want.Y)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestConvertHeliographicToHelioprojectiveCoordinateFarSide(t *testing.T) {
+	_, ok := ConvertHeliographicToHelioprojectiveCoordinate(physical, HeliographicCoordinate{Longitude: 180, Latitude: 0})
+
+	if ok {
+		t.Errorf("expected a position on the far side of the Sun to be hidden")
+	}
+}
+
+/*****************************************************************************************************************/
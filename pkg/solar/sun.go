@@ -109,6 +109,96 @@ func GetEclipticLongitude(datetime time.Time) float64 {
 
 /*****************************************************************************************************************/
 
+/*
+the True Ecliptic Longitude of the Sun for a given datetime
+
+The Solar True Ecliptic Longitude is the geometric longitude of the Sun referred to the mean equinox of
+date, and is the sum of the Sun's mean longitude and the equation of center.
+
+The Solar True Ecliptic Longitude differs from the mean longitude by up to two degrees over the course of
+the year, as a result of the eccentricity of the Earth's orbit around the Sun.
+*/
+func GetTrueEclipticLongitude(datetime time.Time) float64 {
+	// get the solar mean ecliptic longitude:
+	L := GetEclipticLongitude(datetime)
+
+	// get the equation of center:
+	C := GetEquationOfCenter(datetime)
+
+	// the true ecliptic longitude is the sum of the mean longitude and the equation of center:
+	λ := math.Mod(L+C, 360)
+
+	// applies modulo correction to the angle, and ensures always positive:
+	if λ < 0 {
+		λ += 360
+	}
+
+	return λ
+}
+
+/*****************************************************************************************************************/
+
+/*
+the Apparent Ecliptic Longitude of the Sun for a given datetime
+
+The Solar Apparent Ecliptic Longitude is the true ecliptic longitude of the Sun corrected for the annual
+aberration of -20.4898" / R, where R is the distance of the Sun in astronomical units, referred to the mean
+equinox of date, i.e., without the nutation in longitude of up to 17 arcseconds.
+
+Unlike the mean longitude, the apparent longitude accounts for the equation of center, and so places the Sun
+to within around a hundredth of a degree, rather than up to two degrees.
+*/
+func GetApparentEclipticLongitude(datetime time.Time) float64 {
+	// get the solar true ecliptic longitude, corrected for the annual aberration:
+	λ := math.Mod(GetTrueEclipticLongitude(datetime)-20.4898/3600/GetDistance(datetime), 360)
+
+	// applies modulo correction to the angle, and ensures always positive:
+	if λ < 0 {
+		λ += 360
+	}
+
+	return λ
+}
+
+/*****************************************************************************************************************/
+
+/*
+the Distance of the Sun from the Earth for a given datetime, in astronomical units (AU)
+
+The Solar Distance (or radius vector) varies between approximately 0.983 AU at perihelion in early January
+and 1.017 AU at aphelion in early July, as a result of the eccentricity of the Earth's orbit.
+*/
+func GetDistance(datetime time.Time) float64 {
+	// get the Julian Date for the current epoch:
+	JD := epoch.GetJulianDate(datetime)
+
+	// calculate the number of centuries since J2000.0:
+	T := (JD - 2451545.0) / 36525
+
+	// the eccentricity of the Earth's orbit:
+	e := 0.016708634 - 0.000042037*T - 0.0000001267*math.Pow(T, 2)
+
+	// the true anomaly of the Sun is the sum of the mean anomaly and the equation of center:
+	ν := common.Radians(GetMeanAnomaly(datetime) + GetEquationOfCenter(datetime))
+
+	// return the radius vector of the Sun, in astronomical units:
+	return 1.000001018 * (1 - math.Pow(e, 2)) / (1 + e*math.Cos(ν))
+}
+
+/*****************************************************************************************************************/
+
+/*
+the Semi-Diameter of the Sun for a given datetime, in degrees
+
+The Solar Semi-Diameter is 959.63 arcseconds at a distance of one astronomical unit, and varies between
+approximately 0.2634 degrees in early July and 0.2716 degrees in early January.
+*/
+func GetSemiDiameter(datetime time.Time) float64 {
+	return 959.63 / 3600 / GetDistance(datetime)
+}
+
+/*****************************************************************************************************************/
+
 /*
 the Ecliptic Coordinate of the Sun for a given datetime
 
@@ -172,3 +262,45 @@ func GetHorizontalCoordinate(
 }
 
 /*****************************************************************************************************************/
+
+/*
+the Apparent Equatorial Coordinate of the Sun for a given datetime
+
+The Solar Apparent Equatorial Coordinate is the position of the Sun in the sky relative to the celestial equator,
+from its apparent ecliptic longitude, referred to the mean equator and equinox of date.
+
+The Solar Apparent Equatorial Coordinate should be preferred to the Solar Equatorial Coordinate wherever the
+position of the Sun matters to better than a couple of degrees, e.g., for the times of twilight, or the shadow
+of the Earth.
+*/
+func GetApparentEquatorialCoordinate(datetime time.Time) common.EquatorialCoordinate {
+	// get the solar apparent ecliptic coordinate:
+	ec := common.EclipticCoordinate{
+		Longitude: GetApparentEclipticLongitude(datetime),
+		Latitude:  0,
+	}
+
+	// convert the solar apparent ecliptic coordinate to the solar apparent equatorial coordinate:
+	return coordinates.ConvertEclipticToEquatorialCoordinate(datetime, ec)
+}
+
+/*****************************************************************************************************************/
+
+/*
+the Apparent Horizontal Coordinate of the Sun for a given datetime
+
+The Solar Apparent Horizontal Coordinate is the position of the Sun in the sky relative to the observer's local
+horizon, from its apparent equatorial coordinate. The altitude is geometric, i.e., without refraction.
+*/
+func GetApparentHorizontalCoordinate(
+	datetime time.Time,
+	observer common.GeographicCoordinate,
+) common.HorizontalCoordinate {
+	// get the solar apparent equatorial coordinate:
+	eq := GetApparentEquatorialCoordinate(datetime)
+
+	// convert the solar apparent equatorial coordinate to the solar apparent horizontal coordinate:
+	return coordinates.ConvertEquatorialToHorizontalCoordinate(datetime, observer, eq)
+}
+
+/*****************************************************************************************************************/
@@ -11,6 +11,7 @@ package sun
 /*****************************************************************************************************************/
 
 import (
+	"math"
 	"testing"
 	"time"
 
@@ -121,3 +122,71 @@ func TestSolarHorizontalCoordinate(t *testing.T) {
 }
 
 /*****************************************************************************************************************/
+
+func TestGetSolarTrueEclipticLongitude(t *testing.T) {
+	var got float64 = GetTrueEclipticLongitude(datetime)
+
+	var want float64 = 51.96564888161902 + 1.4754839423594457
+
+	if math.Abs(got-want) > 0.0001 {
+		t.Errorf("got %f, wanted %f", got, want)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetSolarDistance(t *testing.T) {
+	var got float64 = GetDistance(datetime)
+
+	var want float64 = 1.010643
+
+	if math.Abs(got-want) > 0.0001 {
+		t.Errorf("got %f, wanted %f", got, want)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetSolarSemiDiameter(t *testing.T) {
+	var got float64 = GetSemiDiameter(datetime)
+
+	var want float64 = 959.63 / 3600 / 1.010643
+
+	if math.Abs(got-want) > 0.00005 {
+		t.Errorf("got %f, wanted %f", got, want)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetSolarApparentEclipticLongitude(t *testing.T) {
+	// 1992 October 13, 0h TD, see Meeus, "Astronomical Algorithms", Example 25.a:
+	d := time.Date(1992, 10, 13, 0, 0, 0, 0, time.UTC).Add(-59 * time.Second)
+
+	// the true longitude of 199.90988 degrees, less the aberration of -20.539 arcseconds:
+	var want float64 = 199.90988 - 20.539/3600
+
+	if got := GetApparentEclipticLongitude(d); math.Abs(got-want) > 0.001 {
+		t.Errorf("got %f, wanted %f", got, want)
+	}
+}
+
+/*****************************************************************************************************************/
+
+func TestGetSolarApparentEquatorialCoordinate(t *testing.T) {
+	// 1992 October 13, 0h TD, see Meeus, "Astronomical Algorithms", Example 25.a:
+	d := time.Date(1992, 10, 13, 0, 0, 0, 0, time.UTC).Add(-59 * time.Second)
+
+	got := GetApparentEquatorialCoordinate(d)
+
+	// to within the nutation, which is not applied:
+	if math.Abs(got.RightAscension-198.38083) > 0.01 {
+		t.Errorf("got %f, wanted %f", got.RightAscension, 198.38083)
+	}
+
+	if math.Abs(got.Declination-(-7.78507)) > 0.01 {
+		t.Errorf("got %f, wanted %f", got.Declination, -7.78507)
+	}
+}
+
+/*****************************************************************************************************************/